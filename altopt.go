@@ -0,0 +1,178 @@
+// This file provides support for detecting and sampling alternative optimal
+// solutions of linear programs.
+
+package highs
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// DualDegenerate returns the indexes of the nonbasic columns and rows whose
+// dual values (reduced costs) have magnitude at most tol.  The solution must
+// include a basis, as an optimal solution produced by HiGHS's simplex solver
+// does.  If the solution is optimal and DualDegenerate returns any columns or
+// rows, the model may have alternative optimal solutions: increasing such a
+// column or row from its bound, if that is possible, leaves the objective
+// value unchanged.  Conversely, if DualDegenerate returns no columns or rows,
+// the optimal solution is unique.
+func (s *Solution) DualDegenerate(tol float64) (cols, rows []int) {
+	for c, b := range s.ColumnBasis {
+		if b != Basic && c < len(s.ColumnDual) && math.Abs(s.ColumnDual[c]) <= tol {
+			cols = append(cols, c)
+		}
+	}
+	for r, b := range s.RowBasis {
+		if b != Basic && r < len(s.RowDual) && math.Abs(s.RowDual[r]) <= tol {
+			rows = append(rows, r)
+		}
+	}
+	return cols, rows
+}
+
+// OptimalFace returns a copy of a linear program restricted to its solutions
+// whose objective value lies within tol of a given value, typically the
+// optimal objective value.  The restriction is expressed as an additional
+// row, Σ ColCosts[c]·x_c + Offset ∈ [objective − tol, objective + tol], which
+// follows the original rows and, if the model has row names, is named
+// "objective".  The returned model minimizes a zero objective function, so
+// the caller should assign it a secondary objective, such as one that favors
+// some columns over others, before solving.  OptimalFace returns an error if
+// the model has a Hessian matrix or soft rows.
+func (m *Model) OptimalFace(objective, tol float64) (*Model, error) {
+	// Check the arguments.
+	if len(m.HessianMatrix) > 0 {
+		return nil, errors.New("the optimal face of a quadratic program is not supported")
+	}
+	if m.hasSoftRows() {
+		return nil, ErrSoftRows
+	}
+	if tol < 0.0 {
+		return nil, fmt.Errorf("tolerance %v is negative", tol)
+	}
+
+	// Fill in defaults as ToRawModel does.
+	nr, nc := m.modelSize()
+	costs, ok1 := expandToLen(nc, m.ColCosts, 1.0)
+	if !ok1 {
+		return nil, fmt.Errorf("inconsistent column counts")
+	}
+	f := *m
+	var ok2 bool
+	f.RowLower, ok1 = expandToLen(nr, m.RowLower, math.Inf(-1))
+	f.RowUpper, ok2 = expandToLen(nr, m.RowUpper, math.Inf(1))
+	if !(ok1 && ok2) {
+		return nil, fmt.Errorf("inconsistent row counts")
+	}
+
+	// Add the objective row, copying the slices that are extended so as
+	// not to modify the caller's model.
+	f.RowLower = append(append([]float64(nil), f.RowLower...), objective-m.Offset-tol)
+	f.RowUpper = append(append([]float64(nil), f.RowUpper...), objective-m.Offset+tol)
+	f.ConstMatrix = append([]Nonzero(nil), m.ConstMatrix...)
+	for c, v := range costs {
+		if v != 0.0 {
+			f.ConstMatrix = append(f.ConstMatrix, Nonzero{Row: nr, Col: c, Val: v})
+		}
+	}
+	if len(m.RowNames) > 0 {
+		f.RowNames = append(padTo(append([]string(nil), m.RowNames...), nr, ""), "objective")
+	}
+	if len(m.RowTags) > 0 {
+		f.RowTags = append(padTo(append([]any(nil), m.RowTags...), nr, nil), nil)
+	}
+
+	// Replace the objective function.
+	f.Maximize = false
+	f.Offset = 0.0
+	f.ColCosts = make([]float64, nc)
+	f.Start = nil
+	return &f, nil
+}
+
+// SampleOptima searches for up to n optimal solutions of a linear program,
+// given its optimal objective value, by repeatedly minimizing a random
+// secondary objective function over the model's optimal face (see
+// OptimalFace).  Each random objective function drives the solver to a vertex
+// of the optimal face, so the returned solutions are typically vertices.
+// Duplicate solutions are discarded, so fewer than n solutions are returned
+// if the optimal solution is unique or the optimal face has few vertices.
+// Each solution's Objective is the value of the original objective function.
+// The tolerance on the objective value is 1e-7 relative to its magnitude (or
+// absolute, for magnitudes below 1).  rng provides the random secondary
+// objectives; a nil rng uses a fixed seed so that results are reproducible.
+// Because the secondary objectives replace the original objective, the
+// returned solutions' dual values pertain to the secondary objectives.
+func (m *Model) SampleOptima(objective float64, n int, rng *rand.Rand) ([]Solution, error) {
+	// Restrict the model to its optimal face.
+	face, err := m.OptimalFace(objective, 1e-7*math.Max(1.0, math.Abs(objective)))
+	if err != nil {
+		return nil, err
+	}
+	if rng == nil {
+		rng = rand.New(rand.NewSource(1))
+	}
+
+	// Solve the face with one random objective function after another.
+	nr, nc := m.modelSize()
+	var solns []Solution
+	for i := 0; i < n; i++ {
+		for c := range face.ColCosts {
+			face.ColCosts[c] = 2.0*rng.Float64() - 1.0
+		}
+		soln, err := face.Solve()
+		if err != nil && !isWarning(err) {
+			return solns, err
+		}
+		if soln.Status != Optimal {
+			return solns, fmt.Errorf("optimal face could not be solved (status %s)", soln.Status)
+		}
+
+		// Discard duplicate solutions.
+		dup := false
+		for _, s := range solns {
+			if sameValues(s.ColumnPrimal, soln.ColumnPrimal, 1e-6) {
+				dup = true
+				break
+			}
+		}
+		if dup {
+			continue
+		}
+
+		// Remove the objective row, and report the original objective
+		// value.
+		if len(soln.RowPrimal) > nr {
+			soln.RowPrimal = soln.RowPrimal[:nr]
+		}
+		if len(soln.RowDual) > nr {
+			soln.RowDual = soln.RowDual[:nr]
+		}
+		if len(soln.RowBasis) > nr {
+			soln.RowBasis = soln.RowBasis[:nr]
+		}
+		if len(soln.ColumnPrimal) == nc {
+			if soln.Objective, err = m.EvalObjective(soln.ColumnPrimal); err != nil {
+				return solns, err
+			}
+		}
+		solns = append(solns, soln)
+	}
+	return solns, nil
+}
+
+// sameValues reports whether two slices have the same length and no pair of
+// corresponding elements differs by more than tol.
+func sameValues(a, b []float64, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > tol {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,61 @@
+// This file tests support for alternative optimal solutions.
+
+package highs
+
+import (
+	"math"
+	"testing"
+)
+
+// segmentModel returns a model whose optimal solutions form a line segment:
+//
+//	Min  x_0 + x_1
+//	s.t. x_0 + x_1 >= 2
+//	0 <= x_0 <= 2; 0 <= x_1 <= 2
+func segmentModel() *Model {
+	m := &Model{
+		ColLower: []float64{0.0, 0.0},
+		ColUpper: []float64{2.0, 2.0},
+	}
+	m.AddDenseRow(2.0, []float64{1.0, 1.0}, math.Inf(1))
+	return m
+}
+
+// TestDualDegenerate tests that DualDegenerate reports only nonbasic columns
+// and rows with zero duals.
+func TestDualDegenerate(t *testing.T) {
+	soln := Solution{
+		ColumnBasis: []BasisStatus{Basic, Lower, Upper},
+		ColumnDual:  []float64{0.0, 0.0, 2.0},
+		RowBasis:    []BasisStatus{Lower, Basic},
+		RowDual:     []float64{1e-12, 0.0},
+	}
+	cols, rows := soln.DualDegenerate(1e-9)
+	compSlices(t, "cols", cols, []int{1})
+	compSlices(t, "rows", rows, []int{0})
+}
+
+// TestOptimalFace tests that OptimalFace appends an objective row and
+// replaces the objective function without modifying the original model.
+func TestOptimalFace(t *testing.T) {
+	m := segmentModel()
+	m.Offset = 1.0
+	m.RowNames = []string{"cover"}
+	face, err := m.OptimalFace(3.0, 0.5)
+	checkErr(t, err)
+	compSlices(t, "RowLower", face.RowLower, []float64{2.0, 1.5})
+	compSlices(t, "RowUpper", face.RowUpper, []float64{math.Inf(1), 2.5})
+	compSlices(t, "ColCosts", face.ColCosts, []float64{0.0, 0.0})
+	if face.Offset != 0.0 || len(face.ConstMatrix) != 4 || face.RowNames[1] != "objective" {
+		t.Fatalf("unexpected face %+v", face)
+	}
+	if len(m.RowLower) != 1 || len(m.ConstMatrix) != 2 || len(m.RowNames) != 1 {
+		t.Fatalf("OptimalFace modified the original model")
+	}
+
+	// Ensure that quadratic programs are rejected.
+	m.HessianMatrix = []Nonzero{{0, 0, 1.0}}
+	if _, err = m.OptimalFace(3.0, 0.5); err == nil {
+		t.Fatal("OptimalFace accepted a quadratic program")
+	}
+}
@@ -0,0 +1,180 @@
+// This file provides support for stripping identifying information from a
+// model so that it can be shared, for example in a bug report.
+
+package highs
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// AnonymizeOptions control how Anonymize transforms a model.  The zero value
+// strips names and tags but otherwise leaves the model unchanged.
+type AnonymizeOptions struct {
+	Permute        bool  // true=randomly renumber the rows and columns
+	Seed           int64 // Seed for the random permutation
+	ScaleRows      bool  // true=scale each row so its largest coefficient has magnitude 1
+	ScaleObjective bool  // true=scale the objective so its largest coefficient has magnitude 1
+}
+
+// Anonymize returns a copy of the model with its column and row names and
+// tags removed and, optionally, its rows and columns renumbered and its rows
+// and objective function normalized.  Each transformation preserves the set of
+// optimal solutions up to the renumbering: scaling a row by a positive factor
+// changes only that row's dual value, and scaling the objective function
+// changes only the optimal objective value and the dual values.  HiGHS may
+// nevertheless take a different path to the solution, so a transformed model
+// should be checked to reproduce the behavior being reported.
+func (m *Model) Anonymize(opts AnonymizeOptions) (Model, error) {
+	// Copy the model's numerical data, omitting names and tags.
+	nr, nc := m.modelSize()
+	anon := Model{
+		Maximize:      m.Maximize,
+		ColCosts:      append([]float64(nil), m.ColCosts...),
+		Offset:        m.Offset,
+		ColLower:      append([]float64(nil), m.ColLower...),
+		ColUpper:      append([]float64(nil), m.ColUpper...),
+		RowLower:      append([]float64(nil), m.RowLower...),
+		RowUpper:      append([]float64(nil), m.RowUpper...),
+		ConstMatrix:   append([]Nonzero(nil), m.ConstMatrix...),
+		HessianMatrix: append([]Nonzero(nil), m.HessianMatrix...),
+		VarTypes:      append([]VariableType(nil), m.VarTypes...),
+	}
+
+	// Normalize the rows and objective function.
+	if opts.ScaleRows {
+		anon.scaleRows(nr)
+	}
+	if opts.ScaleObjective {
+		anon.scaleObjective()
+	}
+
+	// Renumber the rows and columns.
+	if opts.Permute {
+		rng := rand.New(rand.NewSource(opts.Seed))
+		if err := anon.permute(rng.Perm(nr), rng.Perm(nc)); err != nil {
+			return Model{}, err
+		}
+	}
+	return anon, nil
+}
+
+// scaleRows divides each row's coefficients and bounds by the magnitude of its
+// largest coefficient.  Infinite bounds remain infinite.
+func (m *Model) scaleRows(nr int) {
+	// Find each row's largest coefficient.
+	scale := make([]float64, nr)
+	for _, nz := range m.ConstMatrix {
+		scale[nz.Row] = math.Max(scale[nz.Row], math.Abs(nz.Val))
+	}
+
+	// Scale each nonempty row.
+	for k, nz := range m.ConstMatrix {
+		m.ConstMatrix[k].Val = nz.Val / scale[nz.Row]
+	}
+	for r, s := range scale {
+		if s == 0.0 {
+			continue
+		}
+		if r < len(m.RowLower) {
+			m.RowLower[r] /= s
+		}
+		if r < len(m.RowUpper) {
+			m.RowUpper[r] /= s
+		}
+	}
+}
+
+// scaleObjective divides the column costs, offset, and Hessian matrix by the
+// magnitude of the largest cost or Hessian coefficient.
+func (m *Model) scaleObjective() {
+	s := 0.0
+	for _, c := range m.ColCosts {
+		s = math.Max(s, math.Abs(c))
+	}
+	for _, nz := range m.HessianMatrix {
+		s = math.Max(s, math.Abs(nz.Val))
+	}
+	if s == 0.0 {
+		return
+	}
+	for i := range m.ColCosts {
+		m.ColCosts[i] /= s
+	}
+	m.Offset /= s
+	for k := range m.HessianMatrix {
+		m.HessianMatrix[k].Val /= s
+	}
+}
+
+// permuteSlice returns a copy of a slice in which element i is moved to
+// position perm[i].  An empty slice is returned unmodified.  The caller must
+// ensure that a nonempty slice has the same length as the permutation.
+func permuteSlice[T any](xs []T, perm []int) []T {
+	if len(xs) == 0 {
+		return xs
+	}
+	ys := make([]T, len(xs))
+	for i, x := range xs {
+		ys[perm[i]] = x
+	}
+	return ys
+}
+
+// permute renumbers row r as rowPerm[r] and column c as colPerm[c].  The
+// constraint matrix is sorted in the new row-major order, and Hessian
+// elements are transposed as necessary to keep the matrix upper triangular.
+func (m *Model) permute(rowPerm, colPerm []int) error {
+	// Ensure that each per-row and per-column slice is either empty or
+	// complete.
+	nr, nc := len(rowPerm), len(colPerm)
+	for _, n := range []int{len(m.ColCosts), len(m.ColLower), len(m.ColUpper), len(m.VarTypes)} {
+		if n != 0 && n != nc {
+			return fmt.Errorf("inconsistent column counts")
+		}
+	}
+	for _, n := range []int{len(m.RowLower), len(m.RowUpper)} {
+		if n != 0 && n != nr {
+			return fmt.Errorf("inconsistent row counts")
+		}
+	}
+
+	// Permute the per-row and per-column slices.
+	m.ColCosts = permuteSlice(m.ColCosts, colPerm)
+	m.ColLower = permuteSlice(m.ColLower, colPerm)
+	m.ColUpper = permuteSlice(m.ColUpper, colPerm)
+	m.VarTypes = permuteSlice(m.VarTypes, colPerm)
+	m.RowLower = permuteSlice(m.RowLower, rowPerm)
+	m.RowUpper = permuteSlice(m.RowUpper, rowPerm)
+
+	// Permute the matrices.
+	for k, nz := range m.ConstMatrix {
+		m.ConstMatrix[k].Row = rowPerm[nz.Row]
+		m.ConstMatrix[k].Col = colPerm[nz.Col]
+	}
+	sort.Slice(m.ConstMatrix, func(i, j int) bool {
+		a, b := m.ConstMatrix[i], m.ConstMatrix[j]
+		if a.Row != b.Row {
+			return a.Row < b.Row
+		}
+		return a.Col < b.Col
+	})
+	for k, nz := range m.HessianMatrix {
+		r, c := colPerm[nz.Row], colPerm[nz.Col]
+		if r > c {
+			r, c = c, r
+		}
+		m.HessianMatrix[k].Row = r
+		m.HessianMatrix[k].Col = c
+	}
+	sort.Slice(m.HessianMatrix, func(i, j int) bool {
+		a, b := m.HessianMatrix[i], m.HessianMatrix[j]
+		if a.Row != b.Row {
+			return a.Row < b.Row
+		}
+		return a.Col < b.Col
+	})
+	return nil
+}
@@ -0,0 +1,99 @@
+// This file tests the anonymization of models.
+
+package highs
+
+import (
+	"math"
+	"testing"
+)
+
+// TestAnonymize tests that Anonymize removes names and tags and that a
+// permuted, scaled model has the same feasible region and objective function,
+// up to scaling, as the original.  The model is as follows:
+//
+//	Min.  x_0 + 2*x_1 + 3*x_2 + x_0² + x_1*x_2
+//	s.t.  2 <= 2*x_0 + 4*x_1        <= 8
+//	           x_1 - 10*x_2         <= 5
+//	0 <= x_0 <= 4; x_1 integer
+func TestAnonymize(t *testing.T) {
+	// Prepare the model.
+	var model Model
+	model.ColCosts = []float64{1.0, 2.0, 3.0}
+	model.Offset = 6.0
+	model.ColLower = []float64{0.0, math.Inf(-1), math.Inf(-1)}
+	model.ColUpper = []float64{4.0, math.Inf(1), math.Inf(1)}
+	model.VarTypes = []VariableType{ContinuousType, IntegerType, ContinuousType}
+	model.AddDenseRow(2.0, []float64{2.0, 4.0, 0.0}, 8.0)
+	model.AddDenseRow(math.Inf(-1), []float64{0.0, 1.0, -10.0}, 5.0)
+	model.HessianMatrix = []Nonzero{{0, 0, 2.0}, {1, 2, 1.0}}
+	model.ColNames = []string{"secret0", "secret1", "secret2"}
+	model.RowNames = []string{"secretA", "secretB"}
+	model.SetColTag(0, "tag")
+
+	// Anonymize the model.
+	anon, err := model.Anonymize(AnonymizeOptions{
+		Permute:        true,
+		Seed:           5,
+		ScaleRows:      true,
+		ScaleObjective: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if anon.ColNames != nil || anon.RowNames != nil || anon.ColTags != nil {
+		t.Fatal("Anonymize did not remove names and tags")
+	}
+	compSlices(t, "original ColCosts", model.ColCosts, []float64{1.0, 2.0, 3.0})
+
+	// Recover the permutation from the (distinct) column costs.
+	colPerm := make([]int, 3)
+	for c, v := range model.ColCosts {
+		for a, w := range anon.ColCosts {
+			if w == v/3.0 {
+				colPerm[c] = a
+			}
+		}
+	}
+
+	// Ensure that the objective function scales by 1/3 and that each
+	// column keeps its bounds and type.
+	for _, x := range [][]float64{{1.0, 2.0, 3.0}, {-1.5, 0.0, 7.0}} {
+		y := make([]float64, 3)
+		for c, a := range colPerm {
+			y[a] = x[c]
+			if anon.ColLower[a] != model.ColLower[c] || anon.ColUpper[a] != model.ColUpper[c] ||
+				anon.VarTypes[a] != model.VarTypes[c] {
+				t.Fatalf("column %d was not moved intact to column %d", c, a)
+			}
+		}
+		exp, err := model.EvalObjective(x)
+		checkErr(t, err)
+		obj, err := anon.EvalObjective(y)
+		checkErr(t, err)
+		if math.Abs(obj-exp/3.0) > 1e-12 {
+			t.Fatalf("expected an objective value of %v but saw %v", exp/3.0, obj)
+		}
+	}
+
+	// Ensure that each row was normalized and the Hessian is still upper
+	// triangular.
+	for _, nz := range anon.ConstMatrix {
+		if math.Abs(nz.Val) > 1.0 {
+			t.Fatalf("coefficient %v was not normalized", nz)
+		}
+	}
+	if lb, ub := anon.RowLower, anon.RowUpper; !(lb[0] == 0.5 && ub[0] == 2.0 || lb[1] == 0.5 && ub[1] == 2.0) {
+		t.Fatalf("row bounds %v and %v were not scaled", lb, ub)
+	}
+	for _, nz := range anon.HessianMatrix {
+		if nz.Row > nz.Col {
+			t.Fatalf("Hessian element %v is not upper triangular", nz)
+		}
+	}
+
+	// Ensure that inconsistent models are rejected.
+	model.RowUpper = model.RowUpper[:1]
+	if _, err = model.Anonymize(AnonymizeOptions{Permute: true}); err == nil {
+		t.Fatal("Anonymize accepted inconsistent row counts")
+	}
+}
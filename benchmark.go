@@ -0,0 +1,148 @@
+//go:build cgo
+
+// This file provides support for solving every model in a benchmark set.
+
+package highs
+
+import (
+	"context"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A BenchmarkResult describes the solution of a single model in a
+// benchmark set.
+type BenchmarkResult struct {
+	Name string // Slash-separated path of the model file within the file system
+	SolveStats
+}
+
+// benchmarkExt returns the extension HiGHS uses to recognize the format of a
+// named model file, looking past a trailing ".gz", or the empty string if the
+// file is not a model file.
+func benchmarkExt(name string) string {
+	ext := path.Ext(name)
+	if inner, ok := gzipInnerExt(name); ok {
+		ext = inner
+	}
+	ext = strings.ToLower(ext)
+	for _, e := range modelFormatExts {
+		if ext == e {
+			return ext
+		}
+	}
+	return ""
+}
+
+// benchmarkOne reads, solves, and gathers statistics on a single model file.
+func benchmarkOne(ctx context.Context, fsys fs.FS, name, ext string, opts map[string]any) BenchmarkResult {
+	res := BenchmarkResult{Name: name}
+	res.Time = time.Now()
+	fail := func(err error) BenchmarkResult {
+		res.Error = err.Error()
+		return res
+	}
+
+	// Apply the options in a consistent order.
+	raw := NewRawModel()
+	defer raw.Close()
+	names := make([]string, 0, len(opts))
+	for n := range opts {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	if err := raw.SetBoolOption("output_flag", false); err != nil {
+		return fail(err)
+	}
+	for _, n := range names {
+		if err := applyOption(raw, n, opts[n]); err != nil {
+			return fail(err)
+		}
+	}
+
+	// Read and solve the model.
+	f, err := fsys.Open(name)
+	if err != nil {
+		return fail(err)
+	}
+	err = raw.readModel(f, ext, "RunBenchmark")
+	f.Close()
+	if err != nil && !isWarning(err) {
+		return fail(err)
+	}
+	_, err = raw.SolveContext(ctx)
+
+	// Gather statistics on the solve.
+	obj, lErr := raw.lock()
+	if lErr != nil {
+		return fail(lErr)
+	}
+	defer raw.unlock()
+	res.SolveStats = solveStats(obj, res.Time, err)
+	return res
+}
+
+// RunBenchmark solves every model file in a file system, such as one
+// returned by os.DirFS, and returns one BenchmarkResult per file, sorted by
+// name.  Model files are recognized by their extensions (".mps", ".lp", or
+// ".ems", optionally followed by ".gz"); other files are ignored.  Each model
+// is solved with HiGHS's output disabled and then with the given options,
+// keyed by name, applied as by ModelSolver.SetOption.  Up to workers models
+// are solved concurrently; consider also setting the "threads" option to
+// avoid oversubscribing the processor when workers is greater than one.
+//
+// A failure to read or solve a model is reported in its result's Error field
+// rather than returned.  RunBenchmark returns an error only if the file
+// system cannot be walked or ctx is canceled, in which case the results for
+// models that were not solved contain only their names.  Passing each result's
+// SolveStats to a StatsRecorder produces a table in JSON or CSV format.
+func RunBenchmark(ctx context.Context, fsys fs.FS, opts map[string]any, workers int) ([]BenchmarkResult, error) {
+	// Find all model files.
+	var names, exts []string
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if ext := benchmarkExt(p); !d.IsDir() && ext != "" {
+			names = append(names, p)
+			exts = append(exts, ext)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Solve the models using a pool of workers.
+	if workers < 1 {
+		workers = 1
+	}
+	results := make([]BenchmarkResult, len(names))
+	for i, n := range names {
+		results[i].Name = n
+	}
+	work := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				results[i] = benchmarkOne(ctx, fsys, names[i], exts[i], opts)
+			}
+		}()
+	}
+	for i := range names {
+		if ctx.Err() != nil {
+			break
+		}
+		work <- i
+	}
+	close(work)
+	wg.Wait()
+	return results, ctx.Err()
+}
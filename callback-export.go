@@ -0,0 +1,20 @@
+// This file exports to C the function HiGHS invokes for every callback.  It is
+// kept separate from the rest of the callback code because cgo permits only
+// declarations, not definitions, in the preamble of a file that uses
+// //export.
+
+package highs
+
+import "unsafe"
+
+// #include "highs-externs.h"
+import "C"
+
+// highsGoCallback is the C callback function registered with HiGHS.  It
+// forwards each callback to the Go handlers associated with the HiGHS object
+// and never lets a Go panic propagate back into C.
+//
+//export highsGoCallback
+func highsGoCallback(cbType C.int, msg *C.char, out *C.HighsCallbackDataOut, in *C.HighsCallbackDataIn, userData unsafe.Pointer) {
+	callbackStateFromUserData(userData).dispatch(int(cbType), msg, out, in)
+}
@@ -0,0 +1,295 @@
+// This file provides the Go side of HiGHS's callback mechanism.  HiGHS invokes
+// a single C callback function, which dispatches to any number of Go handlers
+// registered for each callback type.  Panics raised by handlers are recovered
+// here, at the boundary between C and Go, rather than being allowed to unwind
+// through HiGHS's C++ stack frames.
+
+package highs
+
+import (
+	"fmt"
+	"runtime/cgo"
+	"runtime/debug"
+	"sync"
+	"unsafe"
+)
+
+// #include <stdint.h>
+// #include "highs-externs.h"
+//
+// extern void highsGoCallback(int, char*, HighsCallbackDataOut*,
+//                             HighsCallbackDataIn*, void*);
+//
+// // setGoCallback registers highsGoCallback as a HiGHS object's callback
+// // function, with a Go handle as its user data.
+// static HighsInt setGoCallback(void* highs, uintptr_t user_data) {
+//   return Highs_setCallback(highs, (HighsCCallbackType) highsGoCallback,
+//                            (void*) user_data);
+// }
+import "C"
+
+// These are the types of callback HiGHS can invoke, expressed as Go values.
+var (
+	cbLogging              = int(C.kHighsCallbackLogging)
+	cbSimplexInterrupt     = int(C.kHighsCallbackSimplexInterrupt)
+	cbIpmInterrupt         = int(C.kHighsCallbackIpmInterrupt)
+	cbMipSolution          = int(C.kHighsCallbackMipSolution)
+	cbMipImprovingSolution = int(C.kHighsCallbackMipImprovingSolution)
+	cbMipLogging           = int(C.kHighsCallbackMipLogging)
+	cbMipInterrupt         = int(C.kHighsCallbackMipInterrupt)
+	cbMipGetCutPool        = int(C.kHighsCallbackMipGetCutPool)
+	cbMipUserSolution      = int(C.kHighsCallbackMipUserSolution)
+)
+
+// A CallbackError reports that a Go function invoked from a HiGHS callback
+// panicked.  The panic is recovered, HiGHS is asked to interrupt the solve at
+// its next opportunity, no further callback functions are invoked, and Solve
+// returns a CallbackError.
+type CallbackError struct {
+	Value any    // Value that was passed to panic
+	Stack []byte // Stack trace of the panicking goroutine
+}
+
+// Error returns a CallbackError as a string.
+func (e *CallbackError) Error() string {
+	return fmt.Sprintf("callback panicked: %v", e.Value)
+}
+
+// Unwrap returns the value passed to panic if that value is an error.
+func (e *CallbackError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// A callbackFunc handles one type of HiGHS callback.  msg is the message text
+// provided by logging callbacks, out provides data from HiGHS, and in, which
+// may be nil, accepts data to pass back to HiGHS.
+type callbackFunc func(msg string, out *C.HighsCallbackDataOut, in *C.HighsCallbackDataIn)
+
+// A callbackEntry associates a callbackFunc with a callback type.
+type callbackEntry struct {
+	id     int          // Unique identifier for removing the entry
+	cbType int          // Type of callback (cbLogging, cbMipInterrupt, etc.)
+	fn     callbackFunc // Function to invoke
+}
+
+// A callbackState represents the Go side of all callbacks registered with a
+// single HiGHS object.
+type callbackState struct {
+	self     cgo.Handle      // Handle to the callbackState passed to HiGHS
+	entries  []callbackEntry // Registered callback functions
+	nextID   int             // Identifier to assign to the next entry
+	mu       sync.Mutex      // Protects failure
+	failure  *CallbackError  // First panic recovered from a callbackFunc
+	disabled bool            // true=invoke no further callbackFuncs
+	solveID  uint64          // Number of solves begun, used to identify the current solve
+	atEnd    []func()        // Functions to run once when the current solve ends
+}
+
+// addCallback registers a callbackFunc for a given type of callback and tells
+// HiGHS to begin invoking callbacks of that type.  It returns an identifier
+// that can later be passed to removeCallback.  The caller must hold the
+// handle's lock.
+func (h *handle) addCallback(cbType int, fn callbackFunc) (int, error) {
+	// On first use, register our callback function with HiGHS.
+	if h.cb == nil {
+		cs := &callbackState{}
+		cs.self = cgo.NewHandle(cs)
+		status := C.setGoCallback(h.obj, C.uintptr_t(cs.self))
+		err := newCallStatus(status, "Highs_setCallback", "addCallback")
+		if err != nil {
+			cs.self.Delete()
+			return 0, err
+		}
+		h.cb = cs
+	}
+
+	// Start the callback type if this is its first function.
+	cs := h.cb
+	if cs.numEntries(cbType) == 0 {
+		status := C.Highs_startCallback(h.obj, C.int(cbType))
+		err := newCallStatus(status, "Highs_startCallback", "addCallback")
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	// Record the function.
+	id := cs.nextID
+	cs.nextID++
+	cs.entries = append(cs.entries, callbackEntry{
+		id:     id,
+		cbType: cbType,
+		fn:     fn,
+	})
+	return id, nil
+}
+
+// addLogCallback is a convenience wrapper for addCallback that registers a
+// function to receive each log message along with its HiGHS log type.  The
+// caller must hold the handle's lock.
+func (h *handle) addLogCallback(fn func(logType int, msg string)) (int, error) {
+	return h.addCallback(cbLogging, func(msg string, out *C.HighsCallbackDataOut, _ *C.HighsCallbackDataIn) {
+		fn(int(out.log_type), msg)
+	})
+}
+
+// removeCallback unregisters a callbackFunc previously registered with
+// addCallback and tells HiGHS to stop invoking callbacks of that type if no
+// other functions are registered for it.  The caller must hold the handle's
+// lock.
+func (h *handle) removeCallback(id int) error {
+	cs := h.cb
+	if cs == nil {
+		return nil
+	}
+	for i, e := range cs.entries {
+		if e.id != id {
+			continue
+		}
+		cs.entries = append(cs.entries[:i], cs.entries[i+1:]...)
+		if cs.numEntries(e.cbType) > 0 {
+			return nil
+		}
+		status := C.Highs_stopCallback(h.obj, C.int(e.cbType))
+		return newCallStatus(status, "Highs_stopCallback", "removeCallback")
+	}
+	return nil
+}
+
+// beginSolve prepares callbacks for a new solve by discarding any failure
+// recorded during a previous solve.  The caller must hold the handle's lock.
+func (h *handle) beginSolve() {
+	if h.cb == nil {
+		return
+	}
+	h.cb.mu.Lock()
+	h.cb.failure = nil
+	h.cb.disabled = false
+	h.cb.solveID++
+	h.cb.mu.Unlock()
+}
+
+// atSolveEnd arranges for a function to be run when the next solve ends or,
+// if no solve occurs, when the handle's callbacks are freed.  The caller must
+// hold the handle's lock, and the handle must already have callbacks.
+func (h *handle) atSolveEnd(fn func()) {
+	h.cb.atEnd = append(h.cb.atEnd, fn)
+}
+
+// runAtEnd runs and discards all functions registered with atSolveEnd.  The
+// caller must hold the handle's lock.
+func (h *handle) runAtEnd() {
+	if h.cb == nil {
+		return
+	}
+	fns := h.cb.atEnd
+	h.cb.atEnd = nil
+	for _, fn := range fns {
+		fn()
+	}
+}
+
+// endSolve runs all functions registered with atSolveEnd and returns the
+// first panic recovered from a callbackFunc during the solve that just
+// completed or nil if there was none.  The caller must hold the handle's
+// lock.
+func (h *handle) endSolve() error {
+	if h.cb == nil {
+		return nil
+	}
+	h.runAtEnd()
+	h.cb.mu.Lock()
+	defer h.cb.mu.Unlock()
+	if h.cb.failure == nil {
+		return nil
+	}
+	return h.cb.failure
+}
+
+// freeCallbacks releases the Go resources associated with a handle's
+// callbacks.  The caller must hold the handle's lock.
+func (h *handle) freeCallbacks() {
+	if h.cb == nil {
+		return
+	}
+	h.runAtEnd()
+	h.cb.self.Delete()
+	h.cb = nil
+}
+
+// numEntries returns the number of callbackFuncs registered for a given type
+// of callback.
+func (cs *callbackState) numEntries(cbType int) int {
+	n := 0
+	for _, e := range cs.entries {
+		if e.cbType == cbType {
+			n++
+		}
+	}
+	return n
+}
+
+// dispatch invokes in registration order every callbackFunc registered for a
+// given type of callback.  It is called by highsGoCallback.
+func (cs *callbackState) dispatch(cbType int, cMsg *C.char, out *C.HighsCallbackDataOut, in *C.HighsCallbackDataIn) {
+	// Once a callbackFunc has panicked, ask HiGHS to stop at its next
+	// opportunity and invoke no more callbackFuncs.
+	cs.mu.Lock()
+	disabled := cs.disabled
+	cs.mu.Unlock()
+	if disabled {
+		if in != nil {
+			in.user_interrupt = 1
+		}
+		return
+	}
+
+	// Invoke each relevant callbackFunc in turn.
+	var msg string
+	if cMsg != nil {
+		msg = C.GoString(cMsg)
+	}
+	for _, e := range cs.entries {
+		if e.cbType != cbType {
+			continue
+		}
+		if !cs.invoke(e.fn, msg, out, in) {
+			if in != nil {
+				in.user_interrupt = 1
+			}
+			return
+		}
+	}
+}
+
+// invoke calls a single callbackFunc, recovering from any panic it raises.
+// It returns false if the callbackFunc panicked.
+func (cs *callbackState) invoke(fn callbackFunc, msg string, out *C.HighsCallbackDataOut, in *C.HighsCallbackDataIn) (ok bool) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		cs.mu.Lock()
+		if cs.failure == nil {
+			cs.failure = &CallbackError{
+				Value: r,
+				Stack: debug.Stack(),
+			}
+		}
+		cs.disabled = true
+		cs.mu.Unlock()
+		ok = false
+	}()
+	fn(msg, out, in)
+	return true
+}
+
+// callbackStateFromUserData returns the callbackState associated with the user data
+// HiGHS passes to highsGoCallback.
+func callbackStateFromUserData(p unsafe.Pointer) *callbackState {
+	return cgo.Handle(uintptr(p)).Value().(*callbackState)
+}
@@ -0,0 +1,418 @@
+//go:build cgo
+
+// This file tests the highs package's handling of HiGHS callbacks.
+
+package highs
+
+import (
+	"context"
+	"errors"
+	"math"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestCallbackPanic verifies that a panic raised by a Go function invoked
+// from a HiGHS callback is recovered and reported as an error from Solve.
+func TestCallbackPanic(t *testing.T) {
+	// Prepare a model that logs messages but not to the console.
+	model := NewRawModel()
+	checkErr(t, model.SetBoolOption("output_flag", true))
+	checkErr(t, model.SetBoolOption("log_to_console", false))
+	checkErr(t, model.AddColumnBounds([]float64{0.0, 0.0}, []float64{10.0, 10.0}))
+	checkErr(t, model.SetColumnCosts([]float64{1.0, 1.0}))
+	checkErr(t, model.AddDenseRow(4.0, []float64{1.0, 2.0}, 8.0))
+
+	// Register a log callback that panics.
+	boom := errors.New("boom")
+	calls := 0
+	_, err := model.lock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = model.h.addLogCallback(func(logType int, msg string) {
+		calls++
+		panic(boom)
+	})
+	model.unlock()
+	checkErr(t, err)
+
+	// Ensure that Solve reports the panic.
+	_, err = model.Solve()
+	var ce *CallbackError
+	if !errors.As(err, &ce) {
+		t.Fatalf("expected a CallbackError but received %v", err)
+	}
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected the CallbackError to wrap %v but saw %v", boom, ce.Value)
+	}
+	if calls != 1 {
+		t.Fatalf("expected the callback to be invoked once but it was invoked %d times", calls)
+	}
+}
+
+// TestSolveContext tests that SolveContext honors a canceled context and
+// otherwise behaves like Solve.
+func TestSolveContext(t *testing.T) {
+	// Prepare the model.
+	var model Model
+	model.AddDenseRow(1.0, []float64{1.0, -1.0}, 1.0)
+	model.AddDenseRow(5.0, []float64{1.0, 1.0}, 5.0)
+	raw, err := model.ToRawModel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkErr(t, raw.SetBoolOption("output_flag", false))
+
+	// Ensure that a canceled context prevents solving.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err = raw.SolveContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled but saw %v", err)
+	}
+
+	// Ensure that a live context permits solving.
+	soln, err := raw.SolveContext(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{3.0, 2.0})
+}
+
+// TestSolveWithSignals tests that SolveWithSignals behaves like Solve when no
+// signal arrives and that a SignalError names its signal.
+func TestSolveWithSignals(t *testing.T) {
+	// Prepare the model.
+	var model Model
+	model.AddDenseRow(1.0, []float64{1.0, -1.0}, 1.0)
+	model.AddDenseRow(5.0, []float64{1.0, 1.0}, 5.0)
+	raw, err := model.ToRawModel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkErr(t, raw.SetBoolOption("output_flag", false))
+
+	// Solve the model.
+	soln, err := raw.SolveWithSignals()
+	if err != nil {
+		t.Fatal(err)
+	}
+	compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{3.0, 2.0})
+
+	// Check the error message.
+	err = &SignalError{Signal: os.Interrupt}
+	if err.Error() != "solve interrupted by signal interrupt" {
+		t.Fatalf("unexpected error message %q", err)
+	}
+}
+
+// TestSetLogFunc tests that log messages are delivered to a Go function and
+// that they stop once the function is removed.
+func TestSetLogFunc(t *testing.T) {
+	// Prepare a model that logs messages but not to the console.
+	model := NewRawModel()
+	checkErr(t, model.SetBoolOption("output_flag", true))
+	checkErr(t, model.SetBoolOption("log_to_console", false))
+	checkErr(t, model.AddColumnBounds([]float64{0.0, 0.0}, []float64{10.0, 10.0}))
+	checkErr(t, model.SetColumnCosts([]float64{1.0, 1.0}))
+	checkErr(t, model.AddDenseRow(4.0, []float64{1.0, 2.0}, 8.0))
+
+	// Solve the model, counting log lines.
+	nLines := 0
+	checkErr(t, model.SetLogFunc(func(lt LogType, msg string) {
+		if strings.HasSuffix(msg, "\n") {
+			t.Errorf("log line %q ends in a newline", msg)
+		}
+		nLines++
+	}))
+	if _, err := model.Solve(); err != nil {
+		t.Fatal(err)
+	}
+	if nLines == 0 {
+		t.Fatal("no log lines were received")
+	}
+
+	// Remove the log function and ensure no more lines are received.
+	checkErr(t, model.SetLogFunc(nil))
+	nLines = 0
+	if _, err := model.Solve(); err != nil {
+		t.Fatal(err)
+	}
+	if nLines != 0 {
+		t.Fatalf("received %d log lines after removing the log function", nLines)
+	}
+}
+
+// TestSetLogPrefix tests that a log prefix is prepended to every log line.
+func TestSetLogPrefix(t *testing.T) {
+	// Prepare a model that logs messages but not to the console.
+	model := NewRawModel()
+	checkErr(t, model.SetBoolOption("output_flag", true))
+	checkErr(t, model.SetBoolOption("log_to_console", false))
+	checkErr(t, model.AddColumnBounds([]float64{0.0, 0.0}, []float64{10.0, 10.0}))
+	checkErr(t, model.SetColumnCosts([]float64{1.0, 1.0}))
+	checkErr(t, model.AddDenseRow(4.0, []float64{1.0, 2.0}, 8.0))
+
+	// Solve the model, checking each log line's prefix.
+	nLines := 0
+	checkErr(t, model.SetLogFunc(func(lt LogType, msg string) {
+		if !strings.HasPrefix(msg, "[job 7] ") {
+			t.Errorf("log line %q lacks the expected prefix", msg)
+		}
+		nLines++
+	}))
+	checkErr(t, model.SetLogPrefix("[job 7] "))
+	if _, err := model.Solve(); err != nil {
+		t.Fatal(err)
+	}
+	if nLines == 0 {
+		t.Fatal("no log lines were received")
+	}
+}
+
+// smallMIP is a helper function that constructs, but does not solve, the
+// MIP model from modelAndSolve with HiGHS's output disabled.
+func smallMIP() (*RawModel, error) {
+	var model Model
+	model.ColCosts = []float64{3.0, 2.0, 1.0}
+	model.ColLower = []float64{0.0, 0.0, 0.0}
+	model.RowLower = []float64{1.0, 1.0, 10.0}
+	model.ConstMatrix = []Nonzero{
+		{0, 0, 1.0},
+		{0, 1, -1.0},
+		{1, 1, 1.0},
+		{1, 2, -1.0},
+		{2, 0, 1.0},
+		{2, 1, 1.0},
+		{2, 2, 1.0},
+	}
+	model.VarTypes = []VariableType{IntegerType, IntegerType, IntegerType}
+	raw, err := model.ToRawModel()
+	if err != nil {
+		return nil, err
+	}
+	err = raw.SetBoolOption("output_flag", false)
+	if err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+// TestIncumbents tests that improving solutions are delivered on a channel
+// that is closed when the solve completes.  It solves the model from
+// modelAndSolve.
+func TestIncumbents(t *testing.T) {
+	// Prepare the model.
+	raw, err := smallMIP()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Collect incumbents in the background while solving.
+	incs, err := raw.Incumbents(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan []Incumbent)
+	go func() {
+		var all []Incumbent
+		for inc := range incs {
+			all = append(all, inc)
+		}
+		done <- all
+	}()
+	soln, err := raw.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	all := <-done
+
+	// The final incumbent should match the optimal solution.
+	if len(all) == 0 {
+		t.Fatal("no incumbents were received")
+	}
+	last := all[len(all)-1]
+	if last.Objective != soln.Objective {
+		t.Fatalf("final incumbent has objective %v but solution has %v", last.Objective, soln.Objective)
+	}
+	compSlices(t, "Solution", last.Solution, soln.ColumnPrimal)
+}
+
+// TestSolveWithProgress tests that SolveWithProgress delivers at least a
+// final progress update and closes its channel.  It solves the model from
+// modelAndSolve.
+func TestSolveWithProgress(t *testing.T) {
+	// Prepare the model.
+	raw, err := smallMIP()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Solve the model, and collect progress updates in a separate
+	// goroutine.  The channel is unbuffered, so periodic updates may be
+	// skipped, but the final update must still arrive.
+	ch := make(chan ProgressUpdate)
+	received := make(chan []ProgressUpdate, 1)
+	go func() {
+		var all []ProgressUpdate
+		for pu := range ch {
+			all = append(all, pu)
+		}
+		received <- all
+	}()
+	soln, err := raw.SolveWithProgress(time.Millisecond, ch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	all := <-received
+	if len(all) == 0 {
+		t.Fatal("no progress updates were received")
+	}
+	last := all[len(all)-1]
+	if last.Elapsed <= 0 {
+		t.Fatalf("final update reported an elapsed time of %v", last.Elapsed)
+	}
+	if !math.IsNaN(last.Objective) && last.Objective != soln.Objective {
+		t.Fatalf("final update has objective %v but solution has %v", last.Objective, soln.Objective)
+	}
+
+	// Ensure that a nonpositive interval is rejected.
+	ch = make(chan ProgressUpdate)
+	if _, err = raw.SolveWithProgress(0, ch); err == nil {
+		t.Fatal("SolveWithProgress accepted a zero interval")
+	}
+	if _, ok := <-ch; ok {
+		t.Fatal("SolveWithProgress did not close its channel")
+	}
+}
+
+// TestInjectSolutions tests that a solution can be injected into a MIP solve
+// and that injection is refused once the solve has ended.  It solves the
+// model from modelAndSolve, injecting an optimal solution.
+func TestInjectSolutions(t *testing.T) {
+	// Prepare the model.
+	raw, err := smallMIP()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Inject a solution before solving.
+	si, err := raw.InjectSolutions()
+	checkErr(t, err)
+	if err = si.Inject([]float64{5.0, 3.0}); err == nil {
+		t.Fatal("expected an error for a solution of the wrong length")
+	}
+	checkErr(t, si.Inject([]float64{5.0, 3.0, 2.0}))
+
+	// Solve the model, and ensure that injection now fails.
+	soln, err := raw.Solve()
+	checkErr(t, err)
+	if soln.Objective != 23.0 {
+		t.Fatalf("expected an objective value of 23 but saw %v", soln.Objective)
+	}
+	if err = si.Inject([]float64{5.0, 3.0, 2.0}); err == nil {
+		t.Fatal("expected an error for a solution injected after the solve")
+	}
+}
+
+// TestCutPool tests that CutPool reports HiGHS's cut pool during a MIP solve
+// and that every reported cut is satisfied by the optimal solution.  It
+// solves the model from modelAndSolve with presolve disabled so that the
+// root node's relaxation is fractional.
+func TestCutPool(t *testing.T) {
+	// Prepare the model.
+	raw, err := smallMIP()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkErr(t, raw.SetStringOption("presolve", "off"))
+
+	// Record every cut HiGHS reports while solving.
+	var nCalls int
+	var pool []Cut
+	checkErr(t, raw.CutPool(func(cuts []Cut) {
+		nCalls++
+		pool = append(pool, cuts...)
+	}))
+	soln, err := raw.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nCalls == 0 {
+		t.Fatal("the cut-pool function was never invoked")
+	}
+
+	// Cuts are valid for every feasible solution, so the optimal solution
+	// must satisfy them.
+	const tol = 1e-6
+	for i, cut := range pool {
+		v := 0.0
+		for c, coeff := range cut.Coeffs {
+			if c < 0 || c >= len(soln.ColumnPrimal) {
+				t.Fatalf("cut %d refers to nonexistent column %d", i, c)
+			}
+			v += coeff * soln.ColumnPrimal[c]
+		}
+		if v < cut.Lower-tol || v > cut.Upper+tol {
+			t.Fatalf("cut %d (%v <= %v <= %v) is violated by the optimal solution", i, cut.Lower, v, cut.Upper)
+		}
+	}
+
+	// Ensure that the function is not invoked by subsequent solves.
+	nCalls = 0
+	if _, err = raw.Solve(); err != nil {
+		t.Fatal(err)
+	}
+	if nCalls != 0 {
+		t.Fatalf("the cut-pool function was invoked %d times after its solve", nCalls)
+	}
+}
+
+// TestSetCallback tests that SetCallback delivers only the requested events,
+// that improving MIP solutions carry their column values, and that the
+// events stop once the function is removed.  It solves the model from
+// modelAndSolve.
+func TestSetCallback(t *testing.T) {
+	// Prepare the model.
+	raw, err := smallMIP()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Reject unrecognized event types.
+	if err = raw.SetCallback(func(*CallbackData) bool { return false }, CallbackType(99)); err == nil {
+		t.Fatal("expected an error for an unrecognized callback type")
+	}
+
+	// Collect improving solutions while solving.
+	var all []CallbackData
+	checkErr(t, raw.SetCallback(func(d *CallbackData) bool {
+		all = append(all, *d)
+		return false
+	}, CallbackMIPImprovingSolution))
+	soln, err := raw.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) == 0 {
+		t.Fatal("no improving solutions were received")
+	}
+	for _, d := range all {
+		if d.Type != CallbackMIPImprovingSolution {
+			t.Fatalf("received an event of type %d", d.Type)
+		}
+	}
+	compSlices(t, "MIPSolution", all[len(all)-1].MIPSolution, soln.ColumnPrimal)
+
+	// Remove the callback function and ensure no more events are received.
+	checkErr(t, raw.SetCallback(nil))
+	all = nil
+	if _, err = raw.Solve(); err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 0 {
+		t.Fatalf("received %d events after removing the callback function", len(all))
+	}
+}
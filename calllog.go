@@ -0,0 +1,83 @@
+// This file provides a debug mode that logs each HiGHS C function the
+// highs package invokes, for use in producing minimal reproducers of HiGHS
+// bugs.
+
+package highs
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// A CallRecord describes a single call to a HiGHS C function.
+type CallRecord struct {
+	CName  string      // Name of the HiGHS C function
+	GoName string      // Name of the Go method that invoked it
+	Args   []TraceAttr // Shapes of the function's arguments (e.g., array lengths)
+	Status int         // kHighsStatus value the function returned
+}
+
+// String formats a CallRecord on a single line, as in
+// "Highs_addRows(num_new_row=2, num_new_nz=3) = 0 [AddCompSparseRows]".
+func (cr CallRecord) String() string {
+	args := make([]string, len(cr.Args))
+	for i, a := range cr.Args {
+		args[i] = fmt.Sprintf("%s=%v", a.Key, a.Value)
+	}
+	return fmt.Sprintf("%s(%s) = %d [%s]",
+		cr.CName, strings.Join(args, ", "), cr.Status, cr.GoName)
+}
+
+// A CallLogFunc is invoked after each call to a HiGHS C function that returns
+// a status.
+type CallLogFunc func(cr CallRecord)
+
+// callLogFunc is the current CallLogFunc or nil if call logging is disabled.
+var callLogFunc atomic.Pointer[CallLogFunc]
+
+// SetCallLogFunc installs a CallLogFunc that is invoked after every call the
+// highs package makes to a HiGHS C function that returns a status, replacing
+// any previous CallLogFunc.  A nil CallLogFunc disables call logging.  The
+// records describe each call's arguments by their shapes rather than their
+// contents, which, combined with the model written by WriteModelToFile,
+// usually suffices to reproduce a HiGHS failure without Go.  Call logging
+// can also be enabled without modifying a program by setting the
+// HIGHS_GO_CALL_LOG environment variable to a nonempty value, in which case
+// each record is written to the standard log.
+//
+// A CallLogFunc is invoked with the calling model locked and must not call
+// methods on that model.
+func SetCallLogFunc(fn CallLogFunc) {
+	if fn == nil {
+		callLogFunc.Store(nil)
+		return
+	}
+	callLogFunc.Store(&fn)
+}
+
+// logCall passes a CallRecord to the current CallLogFunc, if any.
+func logCall(status int, cName, goName string, args []TraceAttr) {
+	p := callLogFunc.Load()
+	if p == nil {
+		return
+	}
+	(*p)(CallRecord{CName: cName, GoName: goName, Args: args, Status: status})
+}
+
+// callLogging reports whether a CallLogFunc is installed.  It lets callers
+// skip computing arguments' shapes that would be discarded.
+func callLogging() bool {
+	return callLogFunc.Load() != nil
+}
+
+// init enables call logging if HIGHS_GO_CALL_LOG is set.
+func init() {
+	if os.Getenv("HIGHS_GO_CALL_LOG") != "" {
+		SetCallLogFunc(func(cr CallRecord) {
+			log.Printf("highs: %s", cr)
+		})
+	}
+}
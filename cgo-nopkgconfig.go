@@ -0,0 +1,18 @@
+//go:build highs_nopkgconfig
+
+// This file tells cgo to locate HiGHS in standard locations without invoking
+// pkg-config.  Additional locations can be specified with the CGO_CFLAGS and
+// CGO_LDFLAGS environment variables, whose contents cgo appends to the flags
+// below.
+//
+// cgo directives cannot expand environment variables, so there is no way for
+// this file to honor variables such as HIGHS_INC, HIGHS_LIB, or HIGHS_HOME.
+// CGO_CFLAGS and CGO_LDFLAGS take their place: for example, instead of setting
+// HIGHS_HOME, set CGO_CFLAGS to -I$HIGHS_HOME/include/highs and CGO_LDFLAGS to
+// -L$HIGHS_HOME/lib.
+
+package highs
+
+// #cgo CFLAGS: -I/usr/local/include/highs -I/usr/include/highs
+// #cgo LDFLAGS: -L/usr/local/lib -lhighs
+import "C"
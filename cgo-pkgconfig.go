@@ -0,0 +1,9 @@
+//go:build !highs_nopkgconfig
+
+// This file tells cgo to locate HiGHS using pkg-config.  Build with the
+// highs_nopkgconfig tag to use cgo-nopkgconfig.go instead.
+
+package highs
+
+// #cgo pkg-config: highs
+import "C"
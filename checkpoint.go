@@ -0,0 +1,195 @@
+// This file provides support for saving a model's state to a checkpoint and
+// restoring it later.
+
+package highs
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"unsafe"
+)
+
+// #include <stdlib.h>
+// #include "highs-externs.h"
+import "C"
+
+// These are the names of the members of a checkpoint archive.
+const (
+	checkpointOptions  = "options.set"
+	checkpointModel    = "model.mps"
+	checkpointSolution = "solution.sol"
+)
+
+// WriteCheckpoint writes to an io.Writer a checkpoint of the model's state
+// from which a later process can continue optimizing, for example after a
+// planned restart or the preemption of a long MIP solve that was stopped by
+// a time limit or SolveContext.  The checkpoint is a zip archive containing
+// the model in MPS format (model.mps), the options whose values differ from
+// their defaults in HiGHS's options format (options.set), and, if HiGHS holds
+// a primal solution, that solution and its basis in HiGHS's raw solution
+// format (solution.sol).  For a MIP, the solution is the best incumbent.
+func (m *RawModel) WriteCheckpoint(w io.Writer) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+
+	// Write the options.
+	zw := zip.NewWriter(w)
+	f, err := zw.Create(checkpointOptions)
+	if err != nil {
+		return err
+	}
+	err = writeViaTempFile(f, ".set", func(fn string) error {
+		cFName := C.CString(fn)
+		defer C.free(unsafe.Pointer(cFName))
+		status := C.Highs_writeOptionsDeviations(obj, cFName)
+		err := newCallStatus(status, "Highs_writeOptionsDeviations", "WriteCheckpoint")
+		if err != nil && !isWarning(err) {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Write the model.
+	f, err = zw.Create(checkpointModel)
+	if err != nil {
+		return err
+	}
+	err = writeModelVia(obj, f, ".mps", "WriteCheckpoint")
+	if err != nil && !isWarning(err) {
+		return err
+	}
+
+	// Write the solution, if any.
+	if pss, err := getIntInfo(obj, "primal_solution_status"); err == nil &&
+		pss != int(C.kHighsSolutionStatusNone) {
+		f, err = zw.Create(checkpointSolution)
+		if err != nil {
+			return err
+		}
+		err = writeViaTempFile(f, ".sol", func(fn string) error {
+			cFName := C.CString(fn)
+			defer C.free(unsafe.Pointer(cFName))
+			status := C.Highs_writeSolution(obj, cFName)
+			err := newCallStatus(status, "Highs_writeSolution", "WriteCheckpoint")
+			if err != nil && !isWarning(err) {
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// WriteCheckpointToFile writes a checkpoint, as described under
+// WriteCheckpoint, to a named file.
+func (m *RawModel) WriteCheckpointToFile(fn string) error {
+	f, err := os.Create(fn)
+	if err != nil {
+		return err
+	}
+	err = m.WriteCheckpoint(f)
+	if cErr := f.Close(); err == nil {
+		err = cErr
+	}
+	return err
+}
+
+// openCheckpointMember opens a named member of a checkpoint archive.  It
+// returns nil if the member does not exist.
+func openCheckpointMember(zr *zip.Reader, name string) (io.ReadCloser, error) {
+	for _, f := range zr.File {
+		if f.Name == name {
+			return f.Open()
+		}
+	}
+	return nil, nil
+}
+
+// ReadCheckpoint restores the model's state from a checkpoint written by
+// WriteCheckpoint, replacing the model and applying the checkpoint's options.
+// If the checkpoint contains a solution, it is loaded as with
+// ReadSolutionFromFile, so a subsequent Solve starts from it: a MIP solve
+// uses it as its initial incumbent, and an LP solve starts from its basis.
+func (m *RawModel) ReadCheckpoint(r io.Reader) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+
+	// Open the archive.
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return err
+	}
+
+	// Restore each member in turn.  The options are applied first, and
+	// the solution is loaded only after the model it describes.
+	for _, member := range []struct {
+		name     string
+		ext      string
+		required bool
+		read     func(fn *C.char) C.HighsInt
+		cName    string
+	}{
+		{checkpointOptions, ".set", true,
+			func(fn *C.char) C.HighsInt { return C.Highs_readOptions(obj, fn) },
+			"Highs_readOptions"},
+		{checkpointModel, ".mps", true, nil, ""},
+		{checkpointSolution, ".sol", false,
+			func(fn *C.char) C.HighsInt { return C.Highs_readSolution(obj, fn) },
+			"Highs_readSolution"},
+	} {
+		rc, err := openCheckpointMember(zr, member.name)
+		if err != nil {
+			return err
+		}
+		if rc == nil {
+			if member.required {
+				return fmt.Errorf("checkpoint lacks %s", member.name)
+			}
+			continue
+		}
+		if member.read == nil {
+			err = readModelVia(obj, rc, member.ext, "ReadCheckpoint")
+		} else {
+			err = readViaTempFile(rc, member.ext, func(fn string) error {
+				cFName := C.CString(fn)
+				defer C.free(unsafe.Pointer(cFName))
+				return newCallStatus(member.read(cFName), member.cName, "ReadCheckpoint")
+			})
+		}
+		rc.Close()
+		if err != nil && !isWarning(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadCheckpointFromFile restores the model's state from a named file
+// written by WriteCheckpointToFile.
+func (m *RawModel) ReadCheckpointFromFile(fn string) error {
+	f, err := os.Open(fn)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return m.ReadCheckpoint(f)
+}
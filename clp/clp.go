@@ -0,0 +1,386 @@
+//go:build cgo
+
+/*
+Package clp is a compatibility layer that implements a subset of the API
+provided by the [clp] package—a Go interface to the COIN-OR Linear Programming
+(CLP) solver—atop the HiGHS solver.  Code that uses only the subset can switch
+from CLP to HiGHS simply by replacing
+
+	import "github.com/lanl/clp"
+
+with
+
+	import "github.com/lanl/highs/clp"
+
+The subset includes the Simplex type's problem-loading, solving, and
+solution-retrieval methods, the PackedMatrix type, and the associated
+constants.  Options that have no HiGHS equivalent are accepted but ignored.
+
+[clp]: https://github.com/lanl/clp
+*/
+package clp
+
+import (
+	"errors"
+	"math"
+
+	"github.com/lanl/highs"
+)
+
+// An OptDirection specifies the direction of optimization (maximize,
+// minimize, or ignore the objective function).
+type OptDirection float64
+
+// These are the values an OptDirection accepts:
+const (
+	Maximize OptDirection = -1.0
+	Ignore   OptDirection = 0.0
+	Minimize OptDirection = 1.0
+)
+
+// A SimplexStatus represents the status of a simplex solve.
+type SimplexStatus int
+
+// These are the values a SimplexStatus accepts:
+const (
+	Optimal SimplexStatus = iota
+	PrimalInfeasible
+	DualInfeasible
+	StoppedOnIterations
+	StoppedDueToErrors
+)
+
+// A ValuesPass specifies whether the simplex solver should perform an initial
+// pass over the variable values.  It is ignored by HiGHS.
+type ValuesPass int
+
+// These are the values a ValuesPass accepts:
+const (
+	NoValuesPass ValuesPass = iota
+	DoValuesPass
+	DoValuesPassAndCleanup
+)
+
+// A StartFinishOptions specifies how the simplex solver should manage its
+// internal state across solves.  It is ignored by HiGHS.
+type StartFinishOptions uint
+
+// These are the values a StartFinishOptions accepts.  They can be ORed
+// together.
+const (
+	NoStartFinishOptions StartFinishOptions = 0
+	KeepWorkAreas        StartFinishOptions = 1 << (iota - 1)
+	OldFactorization
+	ReduceInitialization
+)
+
+// A Nonzero represents a nonzero element of a column of a PackedMatrix.
+type Nonzero struct {
+	Index int     // Row index
+	Value float64 // Value of the element
+}
+
+// A Matrix is a sparse matrix that can be loaded into a Simplex model.
+type Matrix interface {
+	Dims() (rows, cols int)     // Return the matrix's dimensions
+	DenseMatrix() [][]float64   // Return the matrix in dense form
+	AppendColumn(col []Nonzero) // Append a column to the matrix
+}
+
+// A PackedMatrix is a column-major sparse matrix.
+type PackedMatrix struct {
+	nz   []highs.Nonzero // Nonzero elements
+	rows int             // Number of rows
+	cols int             // Number of columns
+}
+
+// NewPackedMatrix returns an empty PackedMatrix.
+func NewPackedMatrix() *PackedMatrix {
+	return &PackedMatrix{}
+}
+
+// AppendColumn appends a sparse column to a PackedMatrix.
+func (pm *PackedMatrix) AppendColumn(col []Nonzero) {
+	for _, nz := range col {
+		pm.nz = append(pm.nz, highs.Nonzero{Row: nz.Index, Col: pm.cols, Val: nz.Value})
+		if nz.Index >= pm.rows {
+			pm.rows = nz.Index + 1
+		}
+	}
+	pm.cols++
+}
+
+// Dims returns a PackedMatrix's dimensions.
+func (pm *PackedMatrix) Dims() (rows, cols int) {
+	return pm.rows, pm.cols
+}
+
+// SetDimensions sets a PackedMatrix's dimensions.  Dimensions can be
+// increased to add empty rows or columns but not decreased.
+func (pm *PackedMatrix) SetDimensions(rows, cols int) {
+	if rows > pm.rows {
+		pm.rows = rows
+	}
+	if cols > pm.cols {
+		pm.cols = cols
+	}
+}
+
+// DenseMatrix returns a PackedMatrix as a dense matrix.
+func (pm *PackedMatrix) DenseMatrix() [][]float64 {
+	dense := make([][]float64, pm.rows)
+	for r := range dense {
+		dense[r] = make([]float64, pm.cols)
+	}
+	for _, nz := range pm.nz {
+		dense[nz.Row][nz.Col] = nz.Val
+	}
+	return dense
+}
+
+// A Simplex represents a linear-programming model to be solved with a simplex
+// method.
+type Simplex struct {
+	model    highs.Model    // Problem to solve
+	dir      OptDirection   // Direction of optimization
+	costs    []float64      // Objective function
+	intOpts  map[string]int // Integer options to pass to HiGHS
+	fltOpts  map[string]float64
+	soln     highs.Solution // Most recent solution or zero if none
+	logLevel int            // 0=quiet; >0=display HiGHS output
+}
+
+// NewSimplex returns an empty Simplex model.
+func NewSimplex() *Simplex {
+	return &Simplex{
+		dir:     Minimize,
+		intOpts: make(map[string]int),
+		fltOpts: make(map[string]float64),
+	}
+}
+
+// LoadProblem loads a problem into a Simplex model, replacing any existing
+// problem.  Each argument other than the matrix can be nil.  A nil obj or
+// colLB is treated as all zeroes.  A nil colUB or rowUB is treated as all
+// +∞.  A nil rowLB is treated as all −∞.
+func (s *Simplex) LoadProblem(m Matrix, colLB, colUB, obj, rowLB, rowUB []float64) {
+	// Extract the nonzeros from the matrix.
+	nr, nc := m.Dims()
+	var nz []highs.Nonzero
+	if pm, ok := m.(*PackedMatrix); ok {
+		nz = append(nz, pm.nz...)
+	} else {
+		for r, row := range m.DenseMatrix() {
+			for c, v := range row {
+				if v != 0.0 {
+					nz = append(nz, highs.Nonzero{Row: r, Col: c, Val: v})
+				}
+			}
+		}
+	}
+
+	// Construct a model with all defaults made explicit.
+	pInf, mInf := math.Inf(1), math.Inf(-1)
+	s.model = highs.Model{
+		ColLower:    orFill(colLB, nc, 0.0),
+		ColUpper:    orFill(colUB, nc, pInf),
+		RowLower:    orFill(rowLB, nr, mInf),
+		RowUpper:    orFill(rowUB, nr, pInf),
+		ConstMatrix: nz,
+	}
+	s.costs = orFill(obj, nc, 0.0)
+	s.soln = highs.Solution{}
+}
+
+// EasyLoadDenseProblem is a convenience method that loads a problem expressed
+// densely.  obj is the objective function.  varBounds contains one {lower,
+// upper} pair per variable; variables beyond the end of varBounds are bounded
+// below by 0 and unbounded above.  ineqs contains one row per constraint,
+// where each row contains a lower bound, one coefficient per variable, and an
+// upper bound.
+func (s *Simplex) EasyLoadDenseProblem(obj []float64, varBounds [][2]float64, ineqs [][]float64) {
+	// Convert the variable bounds.
+	nc := len(obj)
+	colLB := make([]float64, nc)
+	colUB := make([]float64, nc)
+	for c := range colUB {
+		colUB[c] = math.Inf(1)
+	}
+	for c, b := range varBounds {
+		colLB[c], colUB[c] = b[0], b[1]
+	}
+
+	// Convert the inequalities.
+	pm := NewPackedMatrix()
+	pm.SetDimensions(len(ineqs), nc)
+	rowLB := make([]float64, len(ineqs))
+	rowUB := make([]float64, len(ineqs))
+	for r, row := range ineqs {
+		rowLB[r] = row[0]
+		rowUB[r] = row[len(row)-1]
+		for c, v := range row[1 : len(row)-1] {
+			if v != 0.0 {
+				pm.nz = append(pm.nz, highs.Nonzero{Row: r, Col: c, Val: v})
+			}
+		}
+	}
+	s.LoadProblem(pm, colLB, colUB, obj, rowLB, rowUB)
+}
+
+// orFill returns xs if it is non-nil or a slice of n copies of v otherwise.
+func orFill(xs []float64, n int, v float64) []float64 {
+	if xs != nil {
+		return xs
+	}
+	ys := make([]float64, n)
+	for i := range ys {
+		ys[i] = v
+	}
+	return ys
+}
+
+// Dims returns the number of rows and columns in a Simplex model.
+func (s *Simplex) Dims() (rows, cols int) {
+	return len(s.model.RowLower), len(s.model.ColLower)
+}
+
+// SetOptimizationDirection specifies whether the objective function should be
+// maximized, minimized, or ignored.
+func (s *Simplex) SetOptimizationDirection(d OptDirection) {
+	s.dir = d
+}
+
+// OptimizationDirection returns the current direction of optimization.
+func (s *Simplex) OptimizationDirection() OptDirection {
+	return s.dir
+}
+
+// SetPrimalTolerance sets the primal feasibility tolerance.
+func (s *Simplex) SetPrimalTolerance(tol float64) {
+	s.fltOpts["primal_feasibility_tolerance"] = tol
+}
+
+// SetDualTolerance sets the dual feasibility tolerance.
+func (s *Simplex) SetDualTolerance(tol float64) {
+	s.fltOpts["dual_feasibility_tolerance"] = tol
+}
+
+// SetMaximumIterations limits the number of simplex iterations.
+func (s *Simplex) SetMaximumIterations(n int) {
+	s.intOpts["simplex_iteration_limit"] = n
+}
+
+// SetMaximumSeconds limits the solver's execution time.
+func (s *Simplex) SetMaximumSeconds(secs float64) {
+	s.fltOpts["time_limit"] = secs
+}
+
+// SetLogLevel specifies the amount of output the solver should produce.  Any
+// level greater than zero enables HiGHS's output.
+func (s *Simplex) SetLogLevel(l int) {
+	s.logLevel = l
+}
+
+// Primal solves a Simplex model with the primal simplex method.  Its
+// arguments are accepted for compatibility but ignored.
+func (s *Simplex) Primal(vp ValuesPass, sf StartFinishOptions) SimplexStatus {
+	return s.solve(4) // HiGHS's primal simplex strategy
+}
+
+// Dual solves a Simplex model with the dual simplex method.  Its arguments are
+// accepted for compatibility but ignored.
+func (s *Simplex) Dual(vp ValuesPass, sf StartFinishOptions) SimplexStatus {
+	return s.solve(1) // HiGHS's dual simplex strategy
+}
+
+// solve solves a Simplex model using a given HiGHS simplex strategy.
+func (s *Simplex) solve(strategy int) SimplexStatus {
+	// Finalize the model.
+	s.soln = highs.Solution{}
+	s.model.Maximize = s.dir == Maximize
+	s.model.ColCosts = s.costs
+	if s.dir == Ignore {
+		s.model.ColCosts = make([]float64, len(s.costs))
+	}
+
+	// Convert the model to a RawModel and apply all options.
+	raw, err := s.model.ToRawModel()
+	if err != nil && !isWarning(err) {
+		return StoppedDueToErrors
+	}
+	defer raw.Close()
+	err = raw.SetBoolOption("output_flag", s.logLevel > 0)
+	if err != nil {
+		return StoppedDueToErrors
+	}
+	err = raw.SetStringOption("solver", "simplex")
+	if err != nil {
+		return StoppedDueToErrors
+	}
+	err = raw.SetIntOption("simplex_strategy", strategy)
+	if err != nil {
+		return StoppedDueToErrors
+	}
+	for k, v := range s.intOpts {
+		if err = raw.SetIntOption(k, v); err != nil {
+			return StoppedDueToErrors
+		}
+	}
+	for k, v := range s.fltOpts {
+		if err = raw.SetFloat64Option(k, v); err != nil {
+			return StoppedDueToErrors
+		}
+	}
+
+	// Solve the model and map the HiGHS status to a CLP status.
+	soln, err := raw.Solve()
+	if err != nil && !isWarning(err) {
+		return StoppedDueToErrors
+	}
+	s.soln = soln.Solution
+	switch soln.Status {
+	case highs.Optimal, highs.ModelEmpty:
+		return Optimal
+	case highs.Infeasible:
+		return PrimalInfeasible
+	case highs.Unbounded, highs.UnboundedOrInfeasible:
+		return DualInfeasible
+	case highs.IterationLimit, highs.TimeLimit, highs.SolutionLimit:
+		return StoppedOnIterations
+	default:
+		return StoppedDueToErrors
+	}
+}
+
+// isWarning returns true if an error from the highs package represents only
+// a warning, in which case a usable model or solution accompanies it.
+func isWarning(err error) bool {
+	var cs highs.CallStatus
+	return errors.As(err, &cs) && cs.IsWarning()
+}
+
+// ObjectiveValue returns the value of the objective function after a solve.
+func (s *Simplex) ObjectiveValue() float64 {
+	return s.soln.Objective
+}
+
+// PrimalColumnSolution returns the primal column solution after a solve.
+func (s *Simplex) PrimalColumnSolution() []float64 {
+	return s.soln.ColumnPrimal
+}
+
+// PrimalRowSolution returns the primal row solution after a solve.
+func (s *Simplex) PrimalRowSolution() []float64 {
+	return s.soln.RowPrimal
+}
+
+// DualColumnSolution returns the dual column solution (i.e., the reduced
+// costs) after a solve.
+func (s *Simplex) DualColumnSolution() []float64 {
+	return s.soln.ColumnDual
+}
+
+// DualRowSolution returns the dual row solution after a solve.
+func (s *Simplex) DualRowSolution() []float64 {
+	return s.soln.RowDual
+}
@@ -0,0 +1,112 @@
+//go:build cgo
+
+// This file tests the clp compatibility layer.
+
+package clp
+
+import (
+	"math"
+	"testing"
+)
+
+// compSlices compares two slices of float64 values and aborts the test if
+// they differ by more than a small tolerance.
+func compSlices(t *testing.T, name string, act, exp []float64) {
+	t.Helper()
+	if len(act) != len(exp) {
+		t.Fatalf("%s: expected %v but saw %v", name, exp, act)
+	}
+	for i := range act {
+		if math.Abs(act[i]-exp[i]) > 1e-6 {
+			t.Fatalf("%s: expected %v but saw %v", name, exp, act)
+		}
+	}
+}
+
+// TestEasyLoadDenseProblem solves the following problem, taken from HiGHS's
+// examples/call_highs_from_c.c:
+//
+//	Max    f  =  x_0 +  x_1
+//	s.t.                x_1 <= 7
+//	       5 <=  x_0 + 2x_1 <= 15
+//	       6 <= 3x_0 + 2x_1
+//	0 <= x_0 <= 4; 1 <= x_1
+func TestEasyLoadDenseProblem(t *testing.T) {
+	// Prepare the model.
+	pInf, mInf := math.Inf(1), math.Inf(-1)
+	simp := NewSimplex()
+	simp.EasyLoadDenseProblem(
+		[]float64{1.0, 1.0},
+		[][2]float64{
+			{0.0, 4.0},
+			{1.0, pInf},
+		},
+		[][]float64{
+			{mInf, 0.0, 1.0, 7.0},
+			{5.0, 1.0, 2.0, 15.0},
+			{6.0, 3.0, 2.0, pInf},
+		})
+	simp.SetOptimizationDirection(Maximize)
+
+	// Solve the model.
+	if st := simp.Primal(NoValuesPass, NoStartFinishOptions); st != Optimal {
+		t.Fatalf("Primal returned status %d instead of Optimal", st)
+	}
+	compSlices(t, "PrimalColumnSolution", simp.PrimalColumnSolution(), []float64{4.0, 5.5})
+	compSlices(t, "PrimalRowSolution", simp.PrimalRowSolution(), []float64{5.5, 15.0, 23.0})
+	if obj := simp.ObjectiveValue(); obj != 9.5 {
+		t.Fatalf("objective value was %.2f but should have been 9.5", obj)
+	}
+}
+
+// TestEasyLoadDenseMissingBounds confirms that variables omitted from
+// EasyLoadDenseProblem's varBounds are unbounded above.  It solves the
+// following problem:
+//
+//	Max    f  =  x_0 +  x_1
+//	s.t.         x_0 + 2x_1 <= 6
+//	0 <= x_0 <= 4; 0 <= x_1
+func TestEasyLoadDenseMissingBounds(t *testing.T) {
+	// Prepare the model.
+	simp := NewSimplex()
+	simp.EasyLoadDenseProblem(
+		[]float64{1.0, 1.0},
+		[][2]float64{{0.0, 4.0}},
+		[][]float64{{math.Inf(-1), 1.0, 2.0, 6.0}})
+	simp.SetOptimizationDirection(Maximize)
+
+	// Solve the model.
+	if st := simp.Primal(NoValuesPass, NoStartFinishOptions); st != Optimal {
+		t.Fatalf("Primal returned status %d instead of Optimal", st)
+	}
+	compSlices(t, "PrimalColumnSolution", simp.PrimalColumnSolution(), []float64{4.0, 1.0})
+}
+
+// TestLoadProblem solves a problem loaded from a PackedMatrix with the dual
+// simplex method:
+//
+//	Min    f  =  x_0 + x_1
+//	s.t.   3 <=  x_0 + x_1
+//	       1 <=  x_0 - x_1 <= 1
+//	0 <= x_0, x_1
+func TestLoadProblem(t *testing.T) {
+	// Prepare the model.
+	mat := NewPackedMatrix()
+	mat.AppendColumn([]Nonzero{{Index: 0, Value: 1.0}, {Index: 1, Value: 1.0}})
+	mat.AppendColumn([]Nonzero{{Index: 0, Value: 1.0}, {Index: 1, Value: -1.0}})
+	if nr, nc := mat.Dims(); nr != 2 || nc != 2 {
+		t.Fatalf("expected a 2x2 matrix but saw %dx%d", nr, nc)
+	}
+	simp := NewSimplex()
+	simp.LoadProblem(mat, nil, nil, []float64{1.0, 1.0},
+		[]float64{3.0, 1.0}, []float64{math.Inf(1), 1.0})
+
+	// Solve the model.
+	if st := simp.Dual(NoValuesPass, NoStartFinishOptions); st != Optimal {
+		t.Fatalf("Dual returned status %d instead of Optimal", st)
+	}
+	compSlices(t, "PrimalColumnSolution", simp.PrimalColumnSolution(), []float64{2.0, 1.0})
+	if obj := simp.ObjectiveValue(); obj != 3.0 {
+		t.Fatalf("objective value was %.2f but should have been 3", obj)
+	}
+}
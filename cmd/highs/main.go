@@ -0,0 +1,160 @@
+//go:build cgo
+
+/*
+Highs is a command-line front end to the HiGHS solver, built atop the highs
+package.  It reads a model from a file in any format HiGHS supports (e.g., MPS
+or LP, optionally gzip-compressed), applies options, solves the model, and
+writes the solution.  Warnings from HiGHS are reported on standard error but
+do not stop the program.
+
+Usage:
+
+	highs [flags] model-file
+
+The flags are:
+
+	-options file
+		Read HiGHS options from file, which contains one "name = value"
+		line per option.
+	-set name=value
+		Assign value to the HiGHS option name.  This flag can be
+		repeated and takes precedence over -options.
+	-solution file
+		Write the solution to file instead of to standard output.
+	-pretty
+		Write the solution in a human-friendly format rather than in
+		HiGHS's raw format.
+	-quiet
+		Suppress HiGHS's progress output.
+*/
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/lanl/highs"
+)
+
+// optionList is a flag.Value that accumulates "name=value" pairs.
+type optionList [][2]string
+
+// String returns an optionList as a string.
+func (ol *optionList) String() string {
+	strs := make([]string, len(*ol))
+	for i, nv := range *ol {
+		strs[i] = nv[0] + "=" + nv[1]
+	}
+	return strings.Join(strs, " ")
+}
+
+// Set appends a "name=value" pair to an optionList.
+func (ol *optionList) Set(s string) error {
+	name, value, found := strings.Cut(s, "=")
+	if !found {
+		return fmt.Errorf("expected name=value but saw %q", s)
+	}
+	*ol = append(*ol, [2]string{strings.TrimSpace(name), strings.TrimSpace(value)})
+	return nil
+}
+
+// config represents the program's command-line arguments.
+type config struct {
+	modelFile string     // File from which to read the model
+	optFile   string     // File from which to read options
+	opts      optionList // Individual options to set
+	solnFile  string     // File to which to write the solution
+	pretty    bool       // true=human-friendly solution output
+	quiet     bool       // true=suppress HiGHS output
+}
+
+// parseCommandLine parses the command line into a config.
+func parseCommandLine() *config {
+	var cfg config
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] model-file\n\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.StringVar(&cfg.optFile, "options", "", "read HiGHS options from `file`")
+	flag.Var(&cfg.opts, "set", "assign a HiGHS option as `name=value` (repeatable)")
+	flag.StringVar(&cfg.solnFile, "solution", "", "write the solution to `file` instead of standard output")
+	flag.BoolVar(&cfg.pretty, "pretty", false, "write the solution in a human-friendly format")
+	flag.BoolVar(&cfg.quiet, "quiet", false, "suppress HiGHS's progress output")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(1)
+	}
+	cfg.modelFile = flag.Arg(0)
+	return &cfg
+}
+
+// run reads, configures, and solves a model as specified by a config.
+func run(cfg *config) error {
+	// Read the model.
+	model := highs.NewRawModel()
+	defer model.Close()
+	err := model.SetBoolOption("output_flag", !cfg.quiet)
+	if err != nil {
+		return err
+	}
+	err = model.ReadModelFromFile(cfg.modelFile)
+	if isWarning(err) {
+		warn(fmt.Errorf("%s: %w", cfg.modelFile, err))
+	} else if err != nil {
+		return fmt.Errorf("failed to read %s (%w)", cfg.modelFile, err)
+	}
+
+	// Apply options, first from the options file then from the command
+	// line.
+	if cfg.optFile != "" {
+		err = model.ReadOptionsFromFile(cfg.optFile)
+		if err != nil {
+			return fmt.Errorf("failed to read options from %s (%w)", cfg.optFile, err)
+		}
+	}
+	for _, nv := range cfg.opts {
+		err = model.SetOptionFromString(nv[0], nv[1])
+		if err != nil {
+			return fmt.Errorf("failed to set option %s (%w)", nv[0], err)
+		}
+	}
+
+	// Solve the model.
+	soln, err := model.Solve()
+	if isWarning(err) {
+		warn(err)
+	} else if err != nil {
+		return err
+	}
+
+	// Write the solution.
+	if cfg.solnFile == "" {
+		return soln.WriteSolution(os.Stdout, cfg.pretty)
+	}
+	return soln.WriteSolutionToFile(cfg.solnFile, cfg.pretty)
+}
+
+// isWarning returns true if an error from the highs package represents only a
+// warning, in which case the model or solution is still usable.
+func isWarning(err error) bool {
+	var cs highs.CallStatus
+	return errors.As(err, &cs) && cs.IsWarning()
+}
+
+// warn reports a non-fatal error to the standard error device.
+func warn(err error) {
+	fmt.Fprintf(os.Stderr, "%s: warning: %s\n", os.Args[0], err)
+}
+
+func main() {
+	cfg := parseCommandLine()
+	err := run(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", os.Args[0], err)
+		os.Exit(1)
+	}
+}
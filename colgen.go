@@ -0,0 +1,138 @@
+//go:build cgo
+
+// This file provides a driver for column-generation algorithms.
+
+package highs
+
+import (
+	"fmt"
+)
+
+// A CGColumn describes a column to add to a column-generation master problem.
+type CGColumn struct {
+	Cost   float64         // Objective coefficient
+	Lower  float64         // Lower bound
+	Upper  float64         // Upper bound
+	Coeffs map[int]float64 // Map from row index to constraint coefficient
+}
+
+// A ColumnGeneration manages the restricted master problem of a
+// column-generation algorithm.  Columns are added to the master problem in
+// place, so HiGHS retains its basis across re-solves and each re-solve
+// starts from the previous optimal basis rather than from scratch.
+type ColumnGeneration struct {
+	Master   *RawModel    // Restricted master problem
+	maximize bool         // true=the master problem is maximized
+	last     *RawSolution // Most recent solution of the master problem
+}
+
+// NewColumnGeneration returns a ColumnGeneration that manages a given
+// restricted master problem.  The master problem should already contain all
+// of its rows and any initial columns.
+func NewColumnGeneration(master *RawModel) (*ColumnGeneration, error) {
+	maximize, err := master.Maximization()
+	if err != nil {
+		return nil, renameCallStatus(err, "NewColumnGeneration")
+	}
+	return &ColumnGeneration{Master: master, maximize: maximize}, nil
+}
+
+// AddColumn adds a column to the master problem and returns its index.
+func (cg *ColumnGeneration) AddColumn(col CGColumn) (int, error) {
+	err := cg.Master.AddColumnMap(col.Cost, col.Lower, col.Upper, col.Coeffs)
+	if err != nil {
+		return 0, renameCallStatus(err, "AddColumn")
+	}
+	nc, err := cg.Master.NumColumns()
+	return nc - 1, err
+}
+
+// Solve re-solves the master problem and returns its solution.  It returns
+// an error if the master problem is not solved to optimality, because the
+// row duals needed for pricing are meaningful only at an optimum.  As with
+// RawModel.Solve, a warning from HiGHS is returned along with an optimal
+// solution.
+func (cg *ColumnGeneration) Solve() (*RawSolution, error) {
+	soln, err := cg.Master.Solve()
+	if err != nil && !isWarning(err) {
+		return soln, renameCallStatus(err, "Solve")
+	}
+	if soln.Status != Optimal {
+		return soln, fmt.Errorf("master problem was not solved to optimality (status %s)", soln.Status)
+	}
+	cg.last = soln
+	return soln, renameCallStatus(err, "Solve")
+}
+
+// RowDuals returns the row duals from the most recent optimal solution of the
+// master problem, or nil if the master problem has not been solved.
+func (cg *ColumnGeneration) RowDuals() []float64 {
+	if cg.last == nil {
+		return nil
+	}
+	return cg.last.RowDual
+}
+
+// ReducedCost prices a candidate column against the row duals from the most
+// recent optimal solution of the master problem.  The result follows the
+// convention of Solution.ReducedCosts: it is the amount by which the
+// objective value would worsen per unit of the column, so a column whose
+// reduced cost is negative would improve the master problem, whether it is
+// minimized or maximized.  ReducedCost returns an error if the master
+// problem has not been solved or a coefficient refers to a nonexistent row.
+func (cg *ColumnGeneration) ReducedCost(col CGColumn) (float64, error) {
+	duals := cg.RowDuals()
+	if duals == nil {
+		return 0.0, fmt.Errorf("master problem has no dual solution")
+	}
+	d := col.Cost
+	for r, v := range col.Coeffs {
+		if r < 0 || r >= len(duals) {
+			return 0.0, fmt.Errorf("row %d is out of range [0, %d)", r, len(duals))
+		}
+		d -= duals[r] * v
+	}
+	if cg.maximize {
+		d = -d
+	}
+	return d, nil
+}
+
+// A Pricer proposes columns to add to a column-generation master problem
+// given the row duals of its current optimal solution.  Returning no columns
+// indicates that no column with negative reduced cost exists, meaning that
+// the current solution is optimal for the full problem.
+type Pricer func(rowDuals []float64) ([]CGColumn, error)
+
+// Run alternates between solving the master problem and adding the columns
+// proposed by a Pricer until the Pricer proposes no more columns or, if
+// maxRounds is positive, maxRounds rounds have been performed.  It returns
+// the final solution of the master problem.  Run does not itself check that
+// the proposed columns have negative reduced cost; the Pricer can use
+// ReducedCost for that purpose.
+func (cg *ColumnGeneration) Run(price Pricer, maxRounds int) (*RawSolution, error) {
+	for round := 0; ; round++ {
+		// Solve the master problem, tolerating warnings.
+		soln, err := cg.Solve()
+		if err != nil && !isWarning(err) {
+			return soln, err
+		}
+		if maxRounds > 0 && round >= maxRounds {
+			return soln, nil
+		}
+
+		// Price out new columns, and stop if there are none.
+		cols, err := price(soln.RowDual)
+		if err != nil {
+			return soln, err
+		}
+		if len(cols) == 0 {
+			return soln, nil
+		}
+		for _, col := range cols {
+			if _, err = cg.AddColumn(col); err != nil {
+				return soln, err
+			}
+		}
+	}
+}
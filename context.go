@@ -0,0 +1,109 @@
+// This file provides support for canceling a solve via a context.Context.
+
+package highs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+)
+
+// #include "highs-externs.h"
+import "C"
+
+// SolveContext is like Solve but additionally asks HiGHS to stop at its next
+// opportunity once ctx is canceled or its deadline passes.  If HiGHS stops
+// for that reason, SolveContext returns ctx.Err() along with whatever
+// solution HiGHS produced before stopping, which may be empty.  A solve that
+// finishes before HiGHS notices the cancellation is reported as usual.
+func (m *RawModel) SolveContext(ctx context.Context) (*RawSolution, error) {
+	if err := ctx.Err(); err != nil {
+		return &RawSolution{}, err
+	}
+	obj, err := m.lock()
+	if err != nil {
+		return &RawSolution{}, err
+	}
+	defer m.unlock()
+
+	// Register an interrupt handler with each solver that accepts one.
+	// HiGHS invokes these periodically during the solve.  Note whether
+	// any of them asked HiGHS to stop.
+	var interrupted atomic.Bool
+	interrupt := func(_ string, _ *C.HighsCallbackDataOut, in *C.HighsCallbackDataIn) {
+		if in != nil && ctx.Err() != nil {
+			in.user_interrupt = 1
+			interrupted.Store(true)
+		}
+	}
+	ids := make([]int, 0, 3)
+	defer func() {
+		for _, id := range ids {
+			_ = m.h.removeCallback(id)
+		}
+	}()
+	for _, cbType := range []int{cbSimplexInterrupt, cbIpmInterrupt, cbMipInterrupt} {
+		id, err := m.h.addCallback(cbType, interrupt)
+		if err != nil {
+			return &RawSolution{}, err
+		}
+		ids = append(ids, id)
+	}
+
+	// Solve the model.  Report cancellation in preference to any error
+	// HiGHS returned as a consequence of being interrupted.
+	soln, err := m.solve(ctx, obj, "SolveContext")
+	if interrupted.Load() {
+		return soln, ctx.Err()
+	}
+	return soln, err
+}
+
+// A SignalError reports that a solve was stopped because the process received
+// a signal.
+type SignalError struct {
+	Signal os.Signal // Signal that stopped the solve
+}
+
+// Error returns a SignalError as a string.
+func (e *SignalError) Error() string {
+	return fmt.Sprintf("solve interrupted by signal %v", e.Signal)
+}
+
+// SolveWithSignals is like Solve but asks HiGHS to stop at its next
+// opportunity if the process receives SIGINT or SIGTERM.  In that case,
+// SolveWithSignals returns a *SignalError along with the best solution HiGHS
+// found before stopping, so a command-line program can report partial
+// results and exit cleanly.  The signals' previous handling is restored
+// before SolveWithSignals returns.
+func (m *RawModel) SolveWithSignals() (*RawSolution, error) {
+	// Cancel a context when a signal arrives.
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigs)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	received := make(chan os.Signal, 1)
+	go func() {
+		select {
+		case sig := <-sigs:
+			received <- sig
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	// Solve the model, and report any signal that interrupted the solve.
+	// SolveContext reports cancellation only if it interrupted the
+	// solve, and the signal is always recorded before the context is
+	// canceled.
+	soln, err := m.SolveContext(ctx)
+	if errors.Is(err, context.Canceled) {
+		return soln, &SignalError{Signal: <-received}
+	}
+	return soln, renameCallStatus(err, "SolveWithSignals")
+}
@@ -0,0 +1,211 @@
+// This file provides summary statistics describing a model's composition.
+
+package highs
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// highsInfinity is the smallest magnitude HiGHS treats as infinite, assuming
+// the default value of its infinite_bound option.
+const highsInfinity = 1e20
+
+// ModelCounts summarizes the composition of a model.  It is intended for
+// quick sanity checks, for example after reading a model from a file.
+type ModelCounts struct {
+	Columns             int // Total number of columns
+	ContinuousCols      int // Number of continuous columns
+	IntegerCols         int // Number of integer columns
+	ImplicitIntegerCols int // Number of implicit-integer columns
+	SemiContinuousCols  int // Number of semi-continuous columns
+	SemiIntegerCols     int // Number of semi-integer columns
+
+	Rows           int // Total number of rows
+	EqualityRows   int // Number of rows with equal, finite lower and upper bounds
+	RangeRows      int // Number of rows with unequal, finite lower and upper bounds
+	InequalityRows int // Number of rows with exactly one finite bound
+	FreeRows       int // Number of rows with no finite bound
+
+	Nonzeros        int // Number of nonzeros in the constraint matrix
+	HessianNonzeros int // Number of nonzeros in the (upper triangle of the) Hessian matrix
+}
+
+// Counts returns the number of columns of each variable type, the number of
+// rows of each kind, and the number of nonzeros in the constraint and Hessian
+// matrices.  A bound whose magnitude is at least 1e20 is considered infinite,
+// as in HiGHS.
+func (m *Model) Counts() ModelCounts {
+	nr, nc := m.modelSize()
+	mc := ModelCounts{
+		Columns:         nc,
+		Rows:            nr,
+		Nonzeros:        len(m.ConstMatrix),
+		HessianNonzeros: len(m.HessianMatrix),
+	}
+
+	// Count the columns of each type.
+	for c := 0; c < nc; c++ {
+		vt := ContinuousType
+		if c < len(m.VarTypes) {
+			vt = m.VarTypes[c]
+		}
+		switch vt {
+		case ContinuousType:
+			mc.ContinuousCols++
+		case IntegerType:
+			mc.IntegerCols++
+		case ImplicitIntegerType:
+			mc.ImplicitIntegerCols++
+		case SemiContinuousType:
+			mc.SemiContinuousCols++
+		case SemiIntegerType:
+			mc.SemiIntegerCols++
+		}
+	}
+
+	// Count the rows of each kind.  Missing bounds are infinite.
+	for r := 0; r < nr; r++ {
+		lb, ub := math.Inf(-1), math.Inf(1)
+		if r < len(m.RowLower) {
+			lb = m.RowLower[r]
+		}
+		if r < len(m.RowUpper) {
+			ub = m.RowUpper[r]
+		}
+		lbFinite, ubFinite := lb > -highsInfinity, ub < highsInfinity
+		switch {
+		case lbFinite && ubFinite && lb == ub:
+			mc.EqualityRows++
+		case lbFinite && ubFinite:
+			mc.RangeRows++
+		case lbFinite || ubFinite:
+			mc.InequalityRows++
+		default:
+			mc.FreeRows++
+		}
+	}
+	return mc
+}
+
+// A magnitudeRange tracks the smallest and largest magnitudes of a set of
+// finite, nonzero values.
+type magnitudeRange struct {
+	min, max float64 // Smallest and largest magnitudes observed
+	n        int     // Number of values observed
+}
+
+// add includes a value in a magnitudeRange unless it is zero or infinite.
+func (mr *magnitudeRange) add(v float64) {
+	v = math.Abs(v)
+	if v == 0.0 || v >= highsInfinity || math.IsNaN(v) {
+		return
+	}
+	if mr.n == 0 || v < mr.min {
+		mr.min = v
+	}
+	if mr.n == 0 || v > mr.max {
+		mr.max = v
+	}
+	mr.n++
+}
+
+// String returns a magnitudeRange as an interval.
+func (mr magnitudeRange) String() string {
+	return fmt.Sprintf("[%.0e, %.0e]", mr.min, mr.max)
+}
+
+// countList formats a list of labeled counts as a comma-separated list,
+// omitting zero counts.
+func countList(counts []int, labels []string) string {
+	var parts []string
+	for i, n := range counts {
+		if n != 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, labels[i]))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// String summarizes a model in a single paragraph: its objective sense; its
+// numbers of columns, rows, and nonzeros, broken down as in Counts; and, as
+// in HiGHS's log output, the range of magnitudes of the finite, nonzero
+// costs, bounds, and matrix coefficients.  String is intended for log
+// messages and debugging sessions.
+func (m *Model) String() string {
+	// Describe the model's dimensions.
+	mc := m.Counts()
+	var sb strings.Builder
+	if m.Maximize {
+		sb.WriteString("maximization model with ")
+	} else {
+		sb.WriteString("minimization model with ")
+	}
+	fmt.Fprintf(&sb, "%d columns", mc.Columns)
+	cols := countList(
+		[]int{mc.ContinuousCols, mc.IntegerCols, mc.ImplicitIntegerCols, mc.SemiContinuousCols, mc.SemiIntegerCols},
+		[]string{"continuous", "integer", "implicit integer", "semi-continuous", "semi-integer"})
+	if cols != "" {
+		fmt.Fprintf(&sb, " (%s)", cols)
+	}
+	fmt.Fprintf(&sb, ", %d rows", mc.Rows)
+	rows := countList(
+		[]int{mc.EqualityRows, mc.RangeRows, mc.InequalityRows, mc.FreeRows},
+		[]string{"equality", "range", "inequality", "free"})
+	if rows != "" {
+		fmt.Fprintf(&sb, " (%s)", rows)
+	}
+	fmt.Fprintf(&sb, ", %d nonzeros", mc.Nonzeros)
+	if mc.HessianNonzeros > 0 {
+		fmt.Fprintf(&sb, ", and %d Hessian nonzeros", mc.HessianNonzeros)
+	}
+
+	// Describe the ranges of the model's coefficients.  Omitted costs
+	// default to 1, as in ToRawModel.
+	var cost, colBnd, rowBnd, matrix, hess magnitudeRange
+	for c := 0; c < mc.Columns; c++ {
+		if c < len(m.ColCosts) {
+			cost.add(m.ColCosts[c])
+		} else {
+			cost.add(1.0)
+		}
+	}
+	for _, v := range m.ColLower {
+		colBnd.add(v)
+	}
+	for _, v := range m.ColUpper {
+		colBnd.add(v)
+	}
+	for _, v := range m.RowLower {
+		rowBnd.add(v)
+	}
+	for _, v := range m.RowUpper {
+		rowBnd.add(v)
+	}
+	for _, nz := range m.ConstMatrix {
+		matrix.add(nz.Val)
+	}
+	for _, nz := range m.HessianMatrix {
+		hess.add(nz.Val)
+	}
+	var ranges []string
+	for _, r := range []struct {
+		name string
+		mr   magnitudeRange
+	}{
+		{"costs", cost},
+		{"column bounds", colBnd},
+		{"row bounds", rowBnd},
+		{"matrix", matrix},
+		{"Hessian", hess},
+	} {
+		if r.mr.n > 0 {
+			ranges = append(ranges, r.name+" "+r.mr.String())
+		}
+	}
+	if len(ranges) > 0 {
+		fmt.Fprintf(&sb, "; coefficient magnitudes: %s", strings.Join(ranges, ", "))
+	}
+	return sb.String()
+}
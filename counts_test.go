@@ -0,0 +1,79 @@
+// This file tests the computation of a model's summary statistics.
+
+package highs
+
+import (
+	"math"
+	"testing"
+)
+
+// TestCounts tests that Counts classifies each column and row correctly.  The
+// model has one column of each variable type, one row of each kind, and a
+// sixth column and row whose bounds are implied.
+func TestCounts(t *testing.T) {
+	// Prepare the model.
+	var model Model
+	model.VarTypes = []VariableType{
+		ContinuousType,
+		IntegerType,
+		ImplicitIntegerType,
+		SemiContinuousType,
+		SemiIntegerType,
+	}
+	model.ColCosts = make([]float64, 6)
+	model.AddDenseRow(2.0, []float64{1.0, 1.0, 0.0, 0.0, 0.0, 0.0}, 2.0)
+	model.AddDenseRow(1.0, []float64{0.0, 1.0, 1.0, 0.0, 0.0, 0.0}, 3.0)
+	model.AddDenseRow(1.0, []float64{0.0, 0.0, 1.0, 0.0, 0.0, 0.0}, math.Inf(1))
+	model.AddDenseRow(-1.0e30, []float64{0.0, 0.0, 0.0, 1.0, 0.0, 0.0}, 4.0)
+	model.AddDenseRow(math.Inf(-1), []float64{0.0, 0.0, 0.0, 0.0, 1.0, 1.0}, 1.0e30)
+	model.RowNames = []string{"a", "b", "c", "d", "e", "f"}
+	model.HessianMatrix = []Nonzero{{0, 0, 1.0}, {0, 5, 0.5}}
+
+	// Compare the counts to the expected values.
+	exp := ModelCounts{
+		Columns:             6,
+		ContinuousCols:      2,
+		IntegerCols:         1,
+		ImplicitIntegerCols: 1,
+		SemiContinuousCols:  1,
+		SemiIntegerCols:     1,
+		Rows:                6,
+		EqualityRows:        1,
+		RangeRows:           1,
+		InequalityRows:      2,
+		FreeRows:            2,
+		Nonzeros:            8,
+		HessianNonzeros:     2,
+	}
+	if mc := model.Counts(); mc != exp {
+		t.Fatalf("expected %+v but saw %+v", exp, mc)
+	}
+}
+
+// TestModelString tests the one-paragraph summary of a model.
+func TestModelString(t *testing.T) {
+	// Summarize a small mixed-integer model.
+	var model Model
+	model.Maximize = true
+	model.ColCosts = []float64{2.0, 0.0, 30.0}
+	model.ColLower = []float64{0.0, -5.0, math.Inf(-1)}
+	model.ColUpper = []float64{100.0, 5.0, math.Inf(1)}
+	model.VarTypes = []VariableType{ContinuousType, IntegerType, ContinuousType}
+	model.AddDenseRow(1.0, []float64{1.0, 0.5, 0.0}, 1.0)
+	model.AddDenseRow(math.Inf(-1), []float64{0.0, 4.0, 1.0}, 8.0)
+	model.HessianMatrix = []Nonzero{{0, 0, 2.0}}
+	exp := "maximization model with 3 columns (2 continuous, 1 integer), " +
+		"2 rows (1 equality, 1 inequality), 4 nonzeros, and 1 Hessian nonzeros; " +
+		"coefficient magnitudes: costs [2e+00, 3e+01], column bounds [5e+00, 1e+02], " +
+		"row bounds [1e+00, 8e+00], matrix [5e-01, 4e+00], Hessian [2e+00, 2e+00]"
+	if act := model.String(); act != exp {
+		t.Fatalf("expected %q but saw %q", exp, act)
+	}
+
+	// Summarize an empty model.
+	var empty Model
+	exp = "minimization model with 0 columns, 0 rows, 0 nonzeros"
+	if act := empty.String(); act != exp {
+		t.Fatalf("expected %q but saw %q", exp, act)
+	}
+}
@@ -0,0 +1,63 @@
+// This file provides support for converting an interior-point solution to a
+// basic solution.
+
+package highs
+
+import "fmt"
+
+// #include "highs-externs.h"
+import "C"
+
+// Crossover runs HiGHS's crossover procedure on a given point, typically the
+// solution of an interior-point or first-order (PDLP) solve, to produce a
+// basic (vertex) solution.  The resulting solution includes a basis, which
+// makes it suitable for hot-starting subsequent simplex re-solves.  Each
+// non-nil slice in sp must have one value per column or row, as appropriate.
+// sp.ColumnPrimal must be non-nil, and sp.ColumnDual and sp.RowDual must be
+// either both nil or both non-nil.  sp.RowPrimal is ignored because HiGHS
+// computes row values from column values.  As with Solve, a warning from
+// HiGHS is returned along with the solution.
+func (m *RawModel) Crossover(sp StartingPoint) (*RawSolution, error) {
+	obj, err := m.lock()
+	if err != nil {
+		return &RawSolution{}, err
+	}
+	defer m.unlock()
+
+	// Check the dimensions of the point.
+	nc := int(C.Highs_getNumCol(obj))
+	nr := int(C.Highs_getNumRow(obj))
+	switch {
+	case len(sp.ColumnPrimal) != nc:
+		return &RawSolution{}, fmt.Errorf("expected %d primal column values but saw %d", nc, len(sp.ColumnPrimal))
+	case (sp.ColumnDual == nil) != (sp.RowDual == nil):
+		return &RawSolution{}, fmt.Errorf("crossover requires either both or neither of the column and row duals")
+	case sp.ColumnDual != nil && len(sp.ColumnDual) != nc:
+		return &RawSolution{}, fmt.Errorf("expected %d dual column values but saw %d", nc, len(sp.ColumnDual))
+	case sp.RowDual != nil && len(sp.RowDual) != nr:
+		return &RawSolution{}, fmt.Errorf("expected %d dual row values but saw %d", nr, len(sp.RowDual))
+	}
+
+	// Run crossover.
+	colValue := convertSlice[C.double, float64](sp.ColumnPrimal)
+	var colDual, rowDual []C.double
+	if sp.ColumnDual != nil {
+		colDual = convertSlice[C.double, float64](sp.ColumnDual)
+		rowDual = convertSlice[C.double, float64](sp.RowDual)
+	}
+	status := C.Highs_crossover(obj, C.int(nc), C.int(nr), sliceToPointer(colValue),
+		sliceToPointer(colDual), sliceToPointer(rowDual))
+	xErr := newCallStatus(status, "Highs_crossover", "Crossover",
+		TraceAttr{"num_col", nc}, TraceAttr{"num_row", nr},
+		TraceAttr{"dual", colDual != nil})
+	if xErr != nil && !isWarning(xErr) {
+		return &RawSolution{}, xErr
+	}
+
+	// Extract the basic solution as Go data.
+	soln, err := extractSolution(m.h, obj, "Crossover")
+	if err != nil {
+		return soln, err
+	}
+	return soln, xErr
+}
@@ -0,0 +1,72 @@
+// This file provides access to the cut pool HiGHS builds during a MIP solve.
+//
+// HiGHS also declares a callback for adding lazy constraints, but its MIP
+// solver never invokes it, so the highs package does not expose it.
+
+package highs
+
+import "unsafe"
+
+// #include "highs-externs.h"
+import "C"
+
+// A Cut is a row in HiGHS's MIP cut pool.
+type Cut struct {
+	Lower  float64         // Lower bound
+	Coeffs map[int]float64 // Map from column index to coefficient
+	Upper  float64         // Upper bound
+}
+
+// CutPool arranges for fn to be invoked during the next call to Solve or
+// SolveContext each time HiGHS reports the contents of its MIP cut pool.
+// This lets an application inspect the cuts HiGHS has generated, for example
+// to reuse them in a related model.  fn is invoked with the model locked and
+// must not call methods on the model.
+func (m *RawModel) CutPool(fn func(cuts []Cut)) error {
+	_, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+
+	// Register a callback that converts the cut pool from CSR form.
+	h := m.h
+	id, err := h.addCallback(cbMipGetCutPool, func(_ string, out *C.HighsCallbackDataOut, _ *C.HighsCallbackDataIn) {
+		numCut := int(out.cutpool_num_cut)
+		numNz := int(out.cutpool_num_nz)
+		if numCut == 0 || out.cutpool_start == nil {
+			fn(nil)
+			return
+		}
+		start := unsafe.Slice((*C.HighsInt)(unsafe.Pointer(out.cutpool_start)), numCut)
+		index := unsafe.Slice((*C.HighsInt)(unsafe.Pointer(out.cutpool_index)), numNz)
+		value := unsafe.Slice((*C.double)(unsafe.Pointer(out.cutpool_value)), numNz)
+		lower := unsafe.Slice((*C.double)(unsafe.Pointer(out.cutpool_lower)), numCut)
+		upper := unsafe.Slice((*C.double)(unsafe.Pointer(out.cutpool_upper)), numCut)
+		cuts := make([]Cut, numCut)
+		for i := range cuts {
+			end := numNz
+			if i+1 < numCut {
+				end = int(start[i+1])
+			}
+			cuts[i] = Cut{
+				Lower:  float64(lower[i]),
+				Coeffs: make(map[int]float64, end-int(start[i])),
+				Upper:  float64(upper[i]),
+			}
+			for k := int(start[i]); k < end; k++ {
+				cuts[i].Coeffs[int(index[k])] = float64(value[k])
+			}
+		}
+		fn(cuts)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Unregister the callback when the solve ends.
+	h.atSolveEnd(func() {
+		_ = h.removeCallback(id)
+	})
+	return nil
+}
@@ -0,0 +1,273 @@
+// This file provides support for comparing two models.
+
+package highs
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// A DiffKind indicates how a model element differs between two models.
+type DiffKind int
+
+// These are the values a DiffKind accepts:
+const (
+	DiffAdded   DiffKind = iota // Element is present only in the second model
+	DiffRemoved                 // Element is present only in the first model
+	DiffChanged                 // Element is present in both models with different values
+)
+
+// A ModelChange describes a single difference between two models.
+type ModelChange struct {
+	Kind   DiffKind // How the element differs
+	Object string   // Element that differs, such as `column "x"` or `coefficient (row 2, column "x")`
+	Attr   string   // Attribute that changed, such as "cost" or "upper bound" (DiffChanged only)
+	Old    any      // Value in the first model (DiffChanged only)
+	New    any      // Value in the second model (DiffChanged only)
+}
+
+// String returns a ModelChange as a human-readable string.
+func (mc ModelChange) String() string {
+	switch mc.Kind {
+	case DiffAdded:
+		return mc.Object + " added"
+	case DiffRemoved:
+		return mc.Object + " removed"
+	default:
+		if mc.Attr == "" {
+			return fmt.Sprintf("%s changed from %v to %v", mc.Object, mc.Old, mc.New)
+		}
+		return fmt.Sprintf("%s: %s changed from %v to %v", mc.Object, mc.Attr, mc.Old, mc.New)
+	}
+}
+
+// A diffSide represents one of the two models being compared, with default
+// values filled in as ToRawModel does.
+type diffSide struct {
+	colKeys, rowKeys   []string           // Identifier of each column and row
+	colIdx, rowIdx     map[string]int     // Map from identifier to index
+	colCost            []float64          // Cost of each column
+	colLower, colUpper []float64          // Bounds on each column
+	rowLower, rowUpper []float64          // Bounds on each row
+	varTypes           []VariableType     // Type of each column
+	matrix, hessian    map[[2]int]float64 // Nonzero coefficients, indexed by (row, column)
+}
+
+// elementKeys returns an identifier for each of n rows or columns.  The
+// identifiers are the elements' names if all are present and distinct or
+// their indices otherwise.
+func elementKeys(kind string, n int, names []string) []string {
+	keys := make([]string, n)
+	seen := make(map[string]bool, n)
+	byName := len(names) == n
+	for i := 0; i < n && byName; i++ {
+		keys[i] = fmt.Sprintf("%s %q", kind, names[i])
+		byName = names[i] != "" && !seen[names[i]]
+		seen[names[i]] = true
+	}
+	if !byName {
+		for i := range keys {
+			keys[i] = fmt.Sprintf("%s %d", kind, i)
+		}
+	}
+	return keys
+}
+
+// newDiffSide prepares a model for comparison.
+func newDiffSide(m *Model) (*diffSide, error) {
+	// Fill in defaults as ToRawModel does.
+	nr, nc := m.modelSize()
+	mInf, pInf := math.Inf(-1), math.Inf(1)
+	var ok bool
+	ds := &diffSide{
+		colKeys: elementKeys("column", nc, m.ColNames),
+		rowKeys: elementKeys("row", nr, m.RowNames),
+		matrix:  make(map[[2]int]float64, len(m.ConstMatrix)),
+		hessian: make(map[[2]int]float64, len(m.HessianMatrix)),
+	}
+	if ds.colCost, ok = expandToLen(nc, m.ColCosts, 1.0); !ok {
+		return nil, fmt.Errorf("inconsistent column counts")
+	}
+	if ds.colLower, ok = expandToLen(nc, m.ColLower, mInf); !ok {
+		return nil, fmt.Errorf("inconsistent column counts")
+	}
+	if ds.colUpper, ok = expandToLen(nc, m.ColUpper, pInf); !ok {
+		return nil, fmt.Errorf("inconsistent column counts")
+	}
+	if ds.rowLower, ok = expandToLen(nr, m.RowLower, mInf); !ok {
+		return nil, fmt.Errorf("inconsistent row counts")
+	}
+	if ds.rowUpper, ok = expandToLen(nr, m.RowUpper, pInf); !ok {
+		return nil, fmt.Errorf("inconsistent row counts")
+	}
+	if ds.varTypes, ok = expandToLen(nc, m.VarTypes, ContinuousType); !ok {
+		return nil, fmt.Errorf("inconsistent column counts")
+	}
+
+	// Index the rows and columns by identifier.
+	ds.colIdx = make(map[string]int, nc)
+	for c, k := range ds.colKeys {
+		ds.colIdx[k] = c
+	}
+	ds.rowIdx = make(map[string]int, nr)
+	for r, k := range ds.rowKeys {
+		ds.rowIdx[k] = r
+	}
+
+	// Index the matrices' nonzeros by coordinate.  As in ToRawModel, later
+	// duplicates replace earlier ones.  The Hessian matrix is stored in
+	// full, symmetric form so that it can be compared even if the columns
+	// appear in a different order in the other model.
+	for _, nz := range m.ConstMatrix {
+		ds.matrix[[2]int{nz.Row, nz.Col}] = nz.Val
+	}
+	for _, nz := range m.HessianMatrix {
+		ds.hessian[[2]int{nz.Row, nz.Col}] = nz.Val
+		ds.hessian[[2]int{nz.Col, nz.Row}] = nz.Val
+	}
+	return ds, nil
+}
+
+// DiffModels reports the differences between two models.  Columns and rows
+// are matched by name if all of a model's columns (respectively, rows) have
+// distinct, nonempty names and by index otherwise.  Differences are reported
+// in the following order: the objective sense and offset; added, removed,
+// and changed columns; added, removed, and changed rows; and changed
+// constraint-matrix and Hessian coefficients between columns and rows present
+// in both models.  Missing values are treated as ToRawModel treats them, and
+// names, tags, the Verbose and Strict fields, the starting point, and row
+// penalties are not otherwise compared.
+func DiffModels(a, b *Model) ([]ModelChange, error) {
+	// Prepare both models for comparison.
+	da, err := newDiffSide(a)
+	if err != nil {
+		return nil, err
+	}
+	db, err := newDiffSide(b)
+	if err != nil {
+		return nil, err
+	}
+	var changes []ModelChange
+	changed := func(obj, attr string, old, new any) {
+		if old != new {
+			changes = append(changes, ModelChange{DiffChanged, obj, attr, old, new})
+		}
+	}
+
+	// Compare the objective function's sense and offset.
+	changed("objective", "maximize", a.Maximize, b.Maximize)
+	changed("objective", "offset", a.Offset, b.Offset)
+
+	// Compare the columns.
+	changes = appendAddedRemoved(changes, da.colKeys, db.colKeys, da.colIdx, db.colIdx)
+	for ca, k := range da.colKeys {
+		cb, ok := db.colIdx[k]
+		if !ok {
+			continue
+		}
+		changed(k, "cost", da.colCost[ca], db.colCost[cb])
+		changed(k, "lower bound", da.colLower[ca], db.colLower[cb])
+		changed(k, "upper bound", da.colUpper[ca], db.colUpper[cb])
+		changed(k, "type", da.varTypes[ca], db.varTypes[cb])
+	}
+
+	// Compare the rows.
+	changes = appendAddedRemoved(changes, da.rowKeys, db.rowKeys, da.rowIdx, db.rowIdx)
+	for ra, k := range da.rowKeys {
+		rb, ok := db.rowIdx[k]
+		if !ok {
+			continue
+		}
+		changed(k, "lower bound", da.rowLower[ra], db.rowLower[rb])
+		changed(k, "upper bound", da.rowUpper[ra], db.rowUpper[rb])
+	}
+
+	// Compare the coefficients of the constraint and Hessian matrices.
+	coeffChanges := func(what string, ma, mb map[[2]int]float64, rowKeysA []string, rowIdxB map[string]int, sym bool) {
+		var diffs []ModelChange
+		for _, rc := range matrixCoords(ma, mb, da, db, rowKeysA, rowIdxB) {
+			va, vb := ma[rc[0]], mb[rc[1]]
+			if va == vb || (sym && rc[0][0] > rc[0][1]) {
+				continue
+			}
+			obj := fmt.Sprintf("%s (%s, %s)", what, rowKeysA[rc[0][0]], da.colKeys[rc[0][1]])
+			diffs = append(diffs, ModelChange{DiffChanged, obj, "", va, vb})
+		}
+		changes = append(changes, diffs...)
+	}
+	coeffChanges("coefficient", da.matrix, db.matrix, da.rowKeys, db.rowIdx, false)
+	coeffChanges("Hessian coefficient", da.hessian, db.hessian, da.colKeys, db.colIdx, true)
+	return changes, nil
+}
+
+// appendAddedRemoved appends to a list of changes the elements present in
+// only one of two lists of row or column identifiers.
+func appendAddedRemoved(changes []ModelChange, keysA, keysB []string, idxA, idxB map[string]int) []ModelChange {
+	for _, k := range keysB {
+		if _, ok := idxA[k]; !ok {
+			changes = append(changes, ModelChange{Kind: DiffAdded, Object: k})
+		}
+	}
+	for _, k := range keysA {
+		if _, ok := idxB[k]; !ok {
+			changes = append(changes, ModelChange{Kind: DiffRemoved, Object: k})
+		}
+	}
+	return changes
+}
+
+// matrixCoords returns, in row-major order of the first model, each pair of
+// corresponding coordinates at which at least one of two matrices has a
+// nonzero and whose row and column are present in both models.  Each element
+// of the result holds a coordinate in the first model followed by the
+// corresponding coordinate in the second.
+func matrixCoords(ma, mb map[[2]int]float64, da, db *diffSide, rowKeysA []string, rowIdxB map[string]int) [][2][2]int {
+	// Map each coordinate in the second model back to the first.
+	rowBack := make(map[int]int, len(rowKeysA))
+	for ra, k := range rowKeysA {
+		if rb, ok := rowIdxB[k]; ok {
+			rowBack[rb] = ra
+		}
+	}
+	colBack := make(map[int]int, len(da.colKeys))
+	for ca, k := range da.colKeys {
+		if cb, ok := db.colIdx[k]; ok {
+			colBack[cb] = ca
+		}
+	}
+
+	// Collect the union of both matrices' coordinates.
+	seen := make(map[[2]int]bool, len(ma)+len(mb))
+	var coords [][2][2]int
+	add := func(rcA [2]int) {
+		if seen[rcA] || rcA[0] >= len(rowKeysA) || rcA[1] >= len(da.colKeys) {
+			return
+		}
+		rb, okR := rowIdxB[rowKeysA[rcA[0]]]
+		cb, okC := db.colIdx[da.colKeys[rcA[1]]]
+		if !okR || !okC {
+			return
+		}
+		seen[rcA] = true
+		coords = append(coords, [2][2]int{rcA, {rb, cb}})
+	}
+	for rc := range ma {
+		add(rc)
+	}
+	for rc := range mb {
+		ra, okR := rowBack[rc[0]]
+		ca, okC := colBack[rc[1]]
+		if okR && okC {
+			add([2]int{ra, ca})
+		}
+	}
+	sort.Slice(coords, func(i, j int) bool {
+		a, b := coords[i][0], coords[j][0]
+		if a[0] != b[0] {
+			return a[0] < b[0]
+		}
+		return a[1] < b[1]
+	})
+	return coords
+}
@@ -0,0 +1,73 @@
+// This file tests the comparison of models.
+
+package highs
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// TestDiffModels tests that DiffModels reports each kind of difference between
+// two versions of a named model, matching columns and rows by name even though
+// they appear in different orders.
+func TestDiffModels(t *testing.T) {
+	// Prepare the first model.
+	var a Model
+	a.ColCosts = []float64{1.0, 2.0, 3.0}
+	a.ColUpper = []float64{10.0, 10.0, 10.0}
+	a.ColNames = []string{"x", "y", "z"}
+	a.AddDenseRow(1.0, []float64{1.0, 1.0, 0.0}, math.Inf(1))
+	a.AddDenseRow(0.0, []float64{0.0, 1.0, 1.0}, 5.0)
+	a.RowNames = []string{"cover", "cap"}
+	a.HessianMatrix = []Nonzero{{0, 1, 1.0}}
+
+	// Prepare the second model, which reorders and modifies the first.
+	var b Model
+	b.Offset = 2.0
+	b.ColCosts = []float64{2.0, 1.0, 4.0}
+	b.ColUpper = []float64{10.0, 20.0, 10.0}
+	b.VarTypes = []VariableType{IntegerType, ContinuousType, ContinuousType}
+	b.ColNames = []string{"y", "x", "w"}
+	b.AddDenseRow(1.0, []float64{3.0, 1.0, 1.0}, math.Inf(1))
+	b.RowNames = []string{"cover"}
+	b.HessianMatrix = []Nonzero{{0, 1, 1.5}}
+
+	// Compare the models.
+	changes, err := DiffModels(&a, &b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var act []string
+	for _, c := range changes {
+		act = append(act, c.String())
+	}
+	exp := []string{
+		"objective: offset changed from 0 to 2",
+		`column "w" added`,
+		`column "z" removed`,
+		`column "x": upper bound changed from 10 to 20`,
+		`column "y": type changed from ContinuousType to IntegerType`,
+		`row "cap" removed`,
+		`coefficient (row "cover", column "y") changed from 1 to 3`,
+		`Hessian coefficient (column "x", column "y") changed from 1 to 1.5`,
+	}
+	if !reflect.DeepEqual(act, exp) {
+		t.Fatalf("expected\n%q\nbut saw\n%q", exp, act)
+	}
+
+	// Ensure that identical models have no differences and that models
+	// without names are compared by index.
+	a.ColNames, a.RowNames = nil, nil
+	changes, err = DiffModels(&a, &a)
+	if err != nil || len(changes) != 0 {
+		t.Fatalf("expected no differences but saw %v (%v)", changes, err)
+	}
+	c := a
+	c.ColLower = []float64{0.0, 0.0, 0.0, 0.0}
+	c.ColCosts = append(c.ColCosts, 1.0)
+	changes, err = DiffModels(&a, &c)
+	if err == nil {
+		t.Fatalf("expected an error but saw %v", changes)
+	}
+}
@@ -1,3 +1,5 @@
+//go:build cgo
+
 // This file provides a few highs usage examples.
 
 package highs_test
@@ -0,0 +1,282 @@
+// This file provides support for solving models with an external HiGHS
+// executable rather than with the HiGHS library.
+
+package highs
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// modelStatusNames maps the model-status strings HiGHS writes to solution
+// files to ModelStatus values.
+var modelStatusNames = map[string]ModelStatus{
+	"Not Set":                        NotSet,
+	"Load error":                     LoadError,
+	"Model error":                    ModelError,
+	"Presolve error":                 PresolveError,
+	"Solve error":                    SolveError,
+	"Postsolve error":                PostsolveError,
+	"Empty":                          ModelEmpty,
+	"Optimal":                        Optimal,
+	"Infeasible":                     Infeasible,
+	"Primal infeasible or unbounded": UnboundedOrInfeasible,
+	"Unbounded":                      Unbounded,
+	"Bound on objective reached":     ObjectiveBound,
+	"Target for objective reached":   ObjectiveTarget,
+	"Time limit reached":             TimeLimit,
+	"Iteration limit reached":        IterationLimit,
+	"Solution limit reached":         SolutionLimit,
+	"Interrupted by user":            Interrupt,
+}
+
+// basisStatusValues maps the integers HiGHS writes to basis files to
+// BasisStatus values.
+var basisStatusValues = []BasisStatus{Lower, Basic, Upper, Zero, NonBasic}
+
+// A solutionScanner reads a HiGHS solution file line by line.
+type solutionScanner struct {
+	sc     *bufio.Scanner
+	lineNo int
+}
+
+// next returns the next line, with surrounding whitespace removed, or an
+// error if no lines remain.
+func (ss *solutionScanner) next() (string, error) {
+	if !ss.sc.Scan() {
+		if err := ss.sc.Err(); err != nil {
+			return "", err
+		}
+		return "", io.ErrUnexpectedEOF
+	}
+	ss.lineNo++
+	return strings.TrimSpace(ss.sc.Text()), nil
+}
+
+// count parses a "# Columns n" or "# Rows n" line and returns n.
+func (ss *solutionScanner) count(what string) (int, error) {
+	line, err := ss.next()
+	if err != nil {
+		return 0, err
+	}
+	prefix := "# " + what + " "
+	if !strings.HasPrefix(line, prefix) {
+		return 0, fmt.Errorf("line %d: expected %q but saw %q", ss.lineNo, prefix+"<n>", line)
+	}
+	return strconv.Atoi(strings.TrimPrefix(line, prefix))
+}
+
+// values parses a header line followed by n "name value" lines and returns
+// the values.
+func (ss *solutionScanner) values(what string) ([]float64, error) {
+	n, err := ss.count(what)
+	if err != nil {
+		return nil, err
+	}
+	vs := make([]float64, n)
+	for i := range vs {
+		line, err := ss.next()
+		if err != nil {
+			return nil, err
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("line %d: expected a value", ss.lineNo)
+		}
+		vs[i], err = strconv.ParseFloat(fields[len(fields)-1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", ss.lineNo, err)
+		}
+	}
+	return vs, nil
+}
+
+// basis parses a header line followed by a single line of n basis statuses.
+func (ss *solutionScanner) basis(what string) ([]BasisStatus, error) {
+	n, err := ss.count(what)
+	if err != nil {
+		return nil, err
+	}
+	line, err := ss.next()
+	if err != nil {
+		return nil, err
+	}
+	fields := strings.Fields(line)
+	if len(fields) != n {
+		return nil, fmt.Errorf("line %d: expected %d basis statuses but saw %d", ss.lineNo, n, len(fields))
+	}
+	bs := make([]BasisStatus, n)
+	for i, f := range fields {
+		v, err := strconv.Atoi(f)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", ss.lineNo, err)
+		}
+		if v >= 0 && v < len(basisStatusValues) {
+			bs[i] = basisStatusValues[v]
+		}
+	}
+	return bs, nil
+}
+
+// readSolution parses a solution written by HiGHS in its raw solution style.
+func readSolution(r io.Reader) (Solution, error) {
+	var soln Solution
+	ss := &solutionScanner{sc: bufio.NewScanner(r)}
+	ss.sc.Buffer(nil, 1<<20)
+	for {
+		line, err := ss.next()
+		if err == io.ErrUnexpectedEOF {
+			return soln, nil
+		}
+		if err != nil {
+			return Solution{}, err
+		}
+		switch line {
+		case "Model status":
+			// Parse the model status.
+			line, err = ss.next()
+			if err != nil {
+				return Solution{}, err
+			}
+			st, ok := modelStatusNames[line]
+			if !ok {
+				st = UnknownModelStatus
+			}
+			soln.Status = st
+
+		case "# Primal solution values":
+			// Parse the primal solution, if any.
+			line, err = ss.next()
+			if err != nil {
+				return Solution{}, err
+			}
+			if line == "None" {
+				continue
+			}
+			line, err = ss.next()
+			if err != nil {
+				return Solution{}, err
+			}
+			obj := strings.TrimPrefix(line, "Objective ")
+			soln.Objective, err = strconv.ParseFloat(obj, 64)
+			if err != nil {
+				return Solution{}, fmt.Errorf("line %d: %w", ss.lineNo, err)
+			}
+			if soln.ColumnPrimal, err = ss.values("Columns"); err != nil {
+				return Solution{}, err
+			}
+			if soln.RowPrimal, err = ss.values("Rows"); err != nil {
+				return Solution{}, err
+			}
+
+		case "# Dual solution values":
+			// Parse the dual solution, if it is feasible.
+			line, err = ss.next()
+			if err != nil {
+				return Solution{}, err
+			}
+			if line != "Feasible" {
+				continue
+			}
+			if soln.ColumnDual, err = ss.values("Columns"); err != nil {
+				return Solution{}, err
+			}
+			if soln.RowDual, err = ss.values("Rows"); err != nil {
+				return Solution{}, err
+			}
+
+		case "# Basis":
+			// Parse the basis, if it is valid.
+			if _, err = ss.next(); err != nil { // "HiGHS v1"
+				return Solution{}, err
+			}
+			line, err = ss.next()
+			if err != nil {
+				return Solution{}, err
+			}
+			if line != "Valid" {
+				continue
+			}
+			if soln.ColumnBasis, err = ss.basis("Columns"); err != nil {
+				return Solution{}, err
+			}
+			if soln.RowBasis, err = ss.basis("Rows"); err != nil {
+				return Solution{}, err
+			}
+		}
+	}
+}
+
+// SolveExternal solves a model by writing it to a temporary MPS file, running
+// an external HiGHS executable (typically named "highs") on that file, and
+// parsing the solution the executable writes.  Additional command-line
+// arguments, such as "--time_limit=60", can be appended.  SolveExternal does
+// not require cgo, which makes it useful in environments where the HiGHS
+// library cannot be linked.  If the model's Verbose field is true, the
+// executable's output is copied to standard output.  Soft rows and the
+// Strict field are handled as in Solve.
+func (m *Model) SolveExternal(exe string, args ...string) (Solution, error) {
+	// Reject models HiGHS cannot solve correctly.
+	if m.isMIQP() {
+		return Solution{}, ErrUnsupportedMIQP
+	}
+	if m.Strict {
+		if err := m.Validate(); err != nil {
+			return Solution{}, err
+		}
+	}
+
+	// Solve models with soft rows via their softened equivalents.
+	if m.hasSoftRows() {
+		return m.solveSoft(func(s *Model) (Solution, error) {
+			return s.SolveExternal(exe, args...)
+		})
+	}
+
+	// Write the model to a temporary directory.
+	dir, err := os.MkdirTemp("", "highs-*")
+	if err != nil {
+		return Solution{}, err
+	}
+	defer os.RemoveAll(dir)
+	mpsName := filepath.Join(dir, "model.mps")
+	solnName := filepath.Join(dir, "model.sol")
+	f, err := os.Create(mpsName)
+	if err != nil {
+		return Solution{}, err
+	}
+	err = m.WriteMPS(f)
+	if cErr := f.Close(); err == nil {
+		err = cErr
+	}
+	if err != nil {
+		return Solution{}, err
+	}
+
+	// Run the executable.
+	cmdArgs := append([]string{
+		"--model_file", mpsName,
+		"--solution_file", solnName,
+	}, args...)
+	out, err := exec.Command(exe, cmdArgs...).CombinedOutput()
+	if err != nil {
+		return Solution{}, fmt.Errorf("%s failed (%w): %s", exe, err, strings.TrimSpace(string(out)))
+	}
+	if m.Verbose {
+		_, _ = os.Stdout.Write(out)
+	}
+
+	// Read the solution.
+	f, err = os.Open(solnName)
+	if err != nil {
+		return Solution{}, err
+	}
+	defer f.Close()
+	return readSolution(f)
+}
@@ -0,0 +1,97 @@
+// This file tests solving models with an external HiGHS executable.
+
+package highs
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// TestReadSolution tests parsing a solution file written in HiGHS's raw
+// style.
+func TestReadSolution(t *testing.T) {
+	const solnText = `Model status
+Optimal
+
+# Primal solution values
+Feasible
+Objective 5.75
+# Columns 2
+C0 0.5
+C1 2.25
+# Rows 3
+R0 2.25
+R1 5
+R2 6
+
+# Dual solution values
+Feasible
+# Columns 2
+C0 0
+C1 0
+# Rows 3
+R0 0
+R1 0.25
+R2 0.25
+
+# Basis
+HiGHS v1
+Valid
+# Columns 2
+1 1 
+# Rows 3
+1 0 0 
+`
+	soln, err := readSolution(strings.NewReader(solnText))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if soln.Status != Optimal {
+		t.Fatalf("expected Optimal but saw %s", soln.Status)
+	}
+	if soln.Objective != 5.75 {
+		t.Fatalf("objective value was %.2f but should have been 5.75", soln.Objective)
+	}
+	compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{0.5, 2.25})
+	compSlices(t, "RowPrimal", soln.RowPrimal, []float64{2.25, 5.0, 6.0})
+	compSlices(t, "ColumnDual", soln.ColumnDual, []float64{0.0, 0.0})
+	compSlices(t, "RowDual", soln.RowDual, []float64{0.0, 0.25, 0.25})
+	compSlices(t, "ColumnBasis", soln.ColumnBasis, []BasisStatus{Basic, Basic})
+	compSlices(t, "RowBasis", soln.RowBasis, []BasisStatus{Basic, Lower, Lower})
+}
+
+// TestSolveExternal solves the model from TestMinimalAPIMin with an external
+// HiGHS executable.  It is skipped if no such executable is installed.
+func TestSolveExternal(t *testing.T) {
+	exe, err := exec.LookPath("highs")
+	if err != nil {
+		t.Skip("no highs executable was found")
+	}
+
+	// Prepare the model.
+	var model Model
+	model.Offset = 3.0
+	model.ColCosts = []float64{1.0, 1.0}
+	model.ColLower = []float64{0.0, 1.0}
+	model.ColUpper = []float64{4.0, 1.0e30}
+	model.RowLower = []float64{-1.0e30, 5.0, 6.0}
+	model.RowUpper = []float64{7.0, 15.0, 1.0e30}
+	model.ConstMatrix = []Nonzero{
+		{0, 1, 1.0},
+		{1, 0, 1.0},
+		{1, 1, 2.0},
+		{2, 0, 3.0},
+		{2, 1, 2.0},
+	}
+
+	// Solve the model.
+	soln, err := model.SolveExternal(exe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if soln.Status != Optimal {
+		t.Fatalf("SolveExternal returned %s instead of Optimal", soln.Status)
+	}
+	compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{0.5, 2.25})
+}
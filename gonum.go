@@ -0,0 +1,158 @@
+// This file provides adapters between the highs package's high-level Model
+// and the matrix types defined by the gonum numerical library.
+
+package highs
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// NewModelFromMatrix returns a Model whose constraint matrix comprises the
+// nonzero elements of a gonum matrix.  To preserve the matrix's dimensions
+// even when it contains rows or columns of all zeroes, the Model's RowLower
+// and RowUpper are initialized to −∞ and +∞, respectively, and its ColCosts
+// are initialized to zero.  All of these can be overwritten by the caller.
+// Matrices that implement gonum's mat.NonZeroDoer interface, such as the
+// sparse matrix types provided by github.com/james-bowman/sparse, are
+// traversed without visiting their zero elements.
+func NewModelFromMatrix(a mat.Matrix) *Model {
+	nr, nc := a.Dims()
+	m := newModelWithDims(nr, nc)
+	m.ConstMatrix = matrixNonzeros(a)
+	return m
+}
+
+// matrixNonzeros returns the nonzero elements of a gonum matrix in row-major
+// order or, if the matrix implements mat.NonZeroDoer, in the order in which
+// DoNonZero visits them.
+func matrixNonzeros(a mat.Matrix) []Nonzero {
+	var nzs []Nonzero
+	if nzd, ok := a.(mat.NonZeroDoer); ok {
+		nzd.DoNonZero(func(i, j int, v float64) {
+			nzs = append(nzs, Nonzero{i, j, v})
+		})
+		return nzs
+	}
+	nr, nc := a.Dims()
+	for i := 0; i < nr; i++ {
+		for j := 0; j < nc; j++ {
+			v := a.At(i, j)
+			if v != 0.0 {
+				nzs = append(nzs, Nonzero{i, j, v})
+			}
+		}
+	}
+	return nzs
+}
+
+// NewModelFromDense is a variant of NewModelFromMatrix that reads directly
+// from a mat.Dense's underlying storage.
+func NewModelFromDense(a *mat.Dense) *Model {
+	raw := a.RawMatrix()
+	m := newModelWithDims(raw.Rows, raw.Cols)
+	for i := 0; i < raw.Rows; i++ {
+		row := raw.Data[i*raw.Stride : i*raw.Stride+raw.Cols]
+		for j, v := range row {
+			if v != 0.0 {
+				m.ConstMatrix = append(m.ConstMatrix, Nonzero{i, j, v})
+			}
+		}
+	}
+	return m
+}
+
+// newModelWithDims returns a Model with unbounded rows and zero-cost columns
+// of the given dimensions.
+func newModelWithDims(nr, nc int) *Model {
+	m := &Model{
+		ColCosts: make([]float64, nc),
+		RowLower: make([]float64, nr),
+		RowUpper: make([]float64, nr),
+	}
+	mInf, pInf := math.Inf(-1), math.Inf(1)
+	for i := range m.RowLower {
+		m.RowLower[i] = mInf
+		m.RowUpper[i] = pInf
+	}
+	return m
+}
+
+// ConstMatrixDense returns a model's constraint matrix as a gonum mat.Dense.
+// As in ToRawModel, later duplicates of a coordinate in ConstMatrix take
+// precedence over earlier ones.  ConstMatrixDense returns nil if the model has
+// no rows or no columns, which gonum cannot represent.
+func (m *Model) ConstMatrixDense() *mat.Dense {
+	nr, nc := m.modelSize()
+	if nr == 0 || nc == 0 {
+		return nil
+	}
+	d := mat.NewDense(nr, nc, nil)
+	for _, nz := range m.ConstMatrix {
+		d.Set(nz.Row, nz.Col, nz.Val)
+	}
+	return d
+}
+
+// ErrNotPSD indicates that a model's Hessian matrix is not positive
+// semidefinite.  HiGHS's QP solver assumes a convex objective function and may
+// fail in unhelpful ways when given an indefinite Hessian.
+var ErrNotPSD = errors.New("Hessian matrix is not positive semidefinite")
+
+// CheckHessianPSD returns an error wrapping ErrNotPSD if the model's Hessian
+// matrix has an eigenvalue less than −tol·max(1, |λ|max), where tol is a small
+// relative tolerance.  Only the columns that appear in the Hessian are
+// considered, but the check still requires time cubic in their number, so it
+// is not invoked automatically.  Callers may want to run it before solving
+// QPs whose Hessians were computed rather than written by hand.
+func (m *Model) CheckHessianPSD() error {
+	const tol = 1e-9
+
+	// Map the columns that appear in the Hessian to a dense range.
+	hess, err := filterNonzeros(m.HessianMatrix, true)
+	if err != nil {
+		return err
+	}
+	cols := make(map[int]int)
+	for _, nz := range hess {
+		cols[nz.Row] = 0
+		cols[nz.Col] = 0
+	}
+	if len(cols) == 0 {
+		return nil
+	}
+	order := make([]int, 0, len(cols))
+	for c := range cols {
+		order = append(order, c)
+	}
+	sort.Ints(order)
+	for i, c := range order {
+		cols[c] = i
+	}
+
+	// Compute the eigenvalues of the symmetric matrix.
+	q := mat.NewSymDense(len(order), nil)
+	for _, nz := range hess {
+		q.SetSym(cols[nz.Row], cols[nz.Col], nz.Val)
+	}
+	var eig mat.EigenSym
+	if !eig.Factorize(q, false) {
+		return errors.New("failed to compute the eigenvalues of the Hessian matrix")
+	}
+	vals := eig.Values(nil)
+
+	// Compare the smallest eigenvalue to the largest magnitude.
+	minVal, maxAbs := math.Inf(1), 1.0
+	for _, v := range vals {
+		minVal = math.Min(minVal, v)
+		maxAbs = math.Max(maxAbs, math.Abs(v))
+	}
+	if minVal < -tol*maxAbs {
+		return fmt.Errorf("%w (minimum eigenvalue is %g)", ErrNotPSD, minVal)
+	}
+	return nil
+}
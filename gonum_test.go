@@ -0,0 +1,151 @@
+// This file tests the conversions between Model and gonum matrix types.
+
+package highs
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// TestGonumRoundTrip converts a gonum matrix to a Model, solves it, and
+// converts the constraint matrix back to gonum.  It solves the following
+// problem:
+//
+//	Min.  x_0 + x_1
+//	s.t.  4 <= 1x_0 + 0x_1 <= 4
+//	      6 <= 1x_0 + 2x_1 <= 6
+//	      0 <= 0x_0 + 0x_1 <= 0
+func TestGonumRoundTrip(t *testing.T) {
+	// Construct a model from a dense matrix.
+	a := mat.NewDense(3, 2, []float64{
+		1.0, 0.0,
+		1.0, 2.0,
+		0.0, 0.0,
+	})
+	model := NewModelFromDense(a)
+	if len(model.ConstMatrix) != 3 {
+		t.Fatalf("expected 3 nonzeros but saw %d", len(model.ConstMatrix))
+	}
+	model.ColCosts = []float64{1.0, 1.0}
+	model.RowLower = []float64{4.0, 6.0, 0.0}
+	model.RowUpper = []float64{4.0, 6.0, 0.0}
+
+	// Ensure that the general-matrix path produces the same nonzeros.
+	general := NewModelFromMatrix(a.T())
+	if len(general.ConstMatrix) != 3 || len(general.RowLower) != 2 {
+		t.Fatalf("unexpected transposed model %v", general)
+	}
+
+	// Solve the model.
+	soln, err := model.Solve()
+	skipIfNoSolver(t, err)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if soln.Status != Optimal {
+		t.Fatalf("Solve returned %s instead of Optimal", soln.Status)
+	}
+	compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{4.0, 1.0})
+	compSlices(t, "RowPrimal", soln.RowPrimal, []float64{4.0, 6.0, 0.0})
+
+	// Convert the constraint matrix back to gonum.
+	d := model.ConstMatrixDense()
+	if !mat.Equal(a, d) {
+		t.Fatalf("expected %v but saw %v", mat.Formatted(a), mat.Formatted(d))
+	}
+}
+
+// TestCheckHessianPSD tests that CheckHessianPSD accepts positive
+// semidefinite Hessians and rejects indefinite ones.
+func TestCheckHessianPSD(t *testing.T) {
+	// The Hessian from TestMinimalAPIQPMin is positive definite.
+	var model Model
+	model.HessianMatrix = []Nonzero{
+		{0, 0, 2.0},
+		{0, 2, -1.0},
+		{1, 1, 0.2},
+		{2, 2, 2.0},
+	}
+	if err := model.CheckHessianPSD(); err != nil {
+		t.Fatal(err)
+	}
+
+	// [1 1; 1 1] is positive semidefinite but singular.
+	model.HessianMatrix = []Nonzero{{3, 3, 1.0}, {3, 5, 1.0}, {5, 5, 1.0}}
+	if err := model.CheckHessianPSD(); err != nil {
+		t.Fatal(err)
+	}
+
+	// [1 2; 2 1] is indefinite.
+	model.HessianMatrix = []Nonzero{{0, 0, 1.0}, {0, 1, 2.0}, {1, 1, 1.0}}
+	if err := model.CheckHessianPSD(); !errors.Is(err, ErrNotPSD) {
+		t.Fatalf("expected ErrNotPSD but saw %v", err)
+	}
+}
+
+// TestNewLeastSquaresModel tests that NewLeastSquaresModel constructs the
+// expected QP for minimizing ‖Ax − b‖² with
+//
+//	A = [ 1 0 ]   b = [ 1 ]
+//	    [ 0 1 ]       [ 2 ]
+//	    [ 1 1 ]       [ 2 ]
+func TestNewLeastSquaresModel(t *testing.T) {
+	a := mat.NewDense(3, 2, []float64{1.0, 0.0, 0.0, 1.0, 1.0, 1.0})
+	b := []float64{1.0, 2.0, 2.0}
+	m, err := NewLeastSquaresModel(a, b)
+	checkErr(t, err)
+	compSlices(t, "ColCosts", m.ColCosts, []float64{-6.0, -8.0})
+	expHess := []Nonzero{{0, 0, 4.0}, {0, 1, 2.0}, {1, 1, 4.0}}
+	if !reflect.DeepEqual(m.HessianMatrix, expHess) {
+		t.Fatalf("expected Hessian %v but saw %v", expHess, m.HessianMatrix)
+	}
+	if m.Offset != 9.0 {
+		t.Fatalf("expected an offset of 9 but saw %v", m.Offset)
+	}
+
+	// Ensure that the objective function computes the squared residual.
+	obj, err := m.EvalObjective([]float64{1.0, 1.0})
+	checkErr(t, err)
+	if obj != 1.0 {
+		t.Fatalf("expected a squared residual of 1 but saw %v", obj)
+	}
+
+	// Ensure that mismatched dimensions are rejected.
+	if _, err = NewLeastSquaresModel(a, []float64{1.0}); err == nil {
+		t.Fatal("NewLeastSquaresModel accepted a b of the wrong length")
+	}
+}
+
+// TestNewPortfolioModel tests that NewPortfolioModel applies HiGHS's ½ and
+// upper-triangle conventions, so that the model's objective value equals
+// γ·wᵀΣw − μᵀw.
+func TestNewPortfolioModel(t *testing.T) {
+	cov := mat.NewSymDense(2, []float64{0.04, 0.01, 0.01, 0.09})
+	m, err := NewPortfolioModel(cov, []float64{0.1, 0.2}, 3.0)
+	checkErr(t, err)
+	compSlices(t, "ColCosts", m.ColCosts, []float64{-0.1, -0.2})
+	if len(m.HessianMatrix) != 3 || m.HessianMatrix[1] != (Nonzero{0, 1, 6.0 * 0.01}) {
+		t.Fatalf("unexpected Hessian %v", m.HessianMatrix)
+	}
+
+	// Compare the objective value at w = (0.5, 0.5) to γ·wᵀΣw − μᵀw.
+	w := []float64{0.5, 0.5}
+	obj, err := m.EvalObjective(w)
+	checkErr(t, err)
+	exp := 3.0*(0.25*0.04+2*0.25*0.01+0.25*0.09) - (0.05 + 0.1)
+	if math.Abs(obj-exp) > 1e-12 {
+		t.Fatalf("expected an objective value of %v but saw %v", exp, obj)
+	}
+
+	// Ensure that invalid arguments are rejected.
+	if _, err = NewPortfolioModel(cov, []float64{0.1}, 3.0); err == nil {
+		t.Fatal("NewPortfolioModel accepted returns of the wrong length")
+	}
+	if _, err = NewPortfolioModel(cov, nil, 0.0); err == nil {
+		t.Fatal("NewPortfolioModel accepted a zero risk aversion")
+	}
+}
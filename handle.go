@@ -0,0 +1,106 @@
+// This file provides reference-counted management of the opaque objects
+// allocated by HiGHS.
+
+package highs
+
+import (
+	"errors"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// #include "highs-externs.h"
+import "C"
+
+// ErrClosed is returned by methods invoked on a RawModel that has been closed
+// or on a RawModel or RawSolution that was never properly initialized.
+var ErrClosed = errors.New("use of a closed or uninitialized model")
+
+// A handle wraps a pointer to a HiGHS object.  A RawModel and every
+// RawSolution derived from it share a single handle, which counts references
+// and destroys the HiGHS object only when the last of these is released.
+// Holding a handle's lock guarantees that its HiGHS object will not be
+// destroyed for the duration.  The lock also serializes calls into HiGHS so
+// that, for example, a RawSolution can be queried by one goroutine while
+// another goroutine is solving the model that produced it.
+//
+// Because a solve holds the lock for its entire duration, a second lock, live,
+// lets runTime read obj and lets lockOrLive read the information published
+// during a solve without waiting for the solve to finish.  live is held for
+// writing only while obj is being destroyed or liveInfo is being updated.
+type handle struct {
+	live     sync.RWMutex   // Protects liveInfo and excludes runTime while obj is destroyed
+	liveInfo map[string]any // Information published by the running solve or nil if none
+	mu       sync.Mutex     // Protects all of the following fields
+	obj      unsafe.Pointer // HiGHS object or nil once destroyed; also protected by live
+	refs     int            // Number of outstanding references to obj
+	cb       *callbackState // Go callback functions registered with obj
+}
+
+// newHandle allocates a HiGHS object and returns a handle to it with a
+// reference count of one.
+func newHandle() *handle {
+	return &handle{
+		obj:  C.Highs_create(),
+		refs: 1,
+	}
+}
+
+// lock locks a handle and returns its HiGHS object.  It returns ErrClosed,
+// leaving the handle unlocked, if the HiGHS object has already been destroyed.
+func (h *handle) lock() (unsafe.Pointer, error) {
+	if h == nil {
+		return nil, ErrClosed
+	}
+	h.mu.Lock()
+	if h.obj == nil {
+		h.mu.Unlock()
+		return nil, ErrClosed
+	}
+	return h.obj, nil
+}
+
+// unlock unlocks a handle.
+func (h *handle) unlock() {
+	h.mu.Unlock()
+}
+
+// acquire adds a reference to a handle.  The caller must hold the handle's
+// lock.
+func (h *handle) acquire() {
+	h.refs++
+}
+
+// release drops a reference to a handle and destroys the underlying HiGHS
+// object when no references remain.
+func (h *handle) release() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.refs--
+	if h.refs == 0 && h.obj != nil {
+		h.live.Lock()
+		C.Highs_destroy(h.obj)
+		h.obj = nil
+		h.live.Unlock()
+		h.freeCallbacks()
+	}
+}
+
+// runTime returns the time HiGHS has spent running.  Unlike all other
+// operations on a handle, runTime acquires only the handle's live lock, not
+// its main lock, so it can be called while another goroutine is solving the
+// model.  This is safe because HiGHS merely reads its clock, and the live
+// lock prevents the HiGHS object from being destroyed during the call.
+func (h *handle) runTime() (time.Duration, error) {
+	if h == nil {
+		return 0, ErrClosed
+	}
+	h.live.RLock()
+	defer h.live.RUnlock()
+	if h.obj == nil {
+		return 0, ErrClosed
+	}
+	secs := float64(C.Highs_getRunTime(h.obj))
+	return time.Duration(secs * float64(time.Second)), nil
+}
@@ -0,0 +1,145 @@
+// This file provides support for computing a content hash of a model.
+
+package highs
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"math"
+	"sort"
+)
+
+// hashWriter accumulates the canonical encoding of a model into a hash.
+type hashWriter struct {
+	h   hash.Hash
+	buf [8]byte
+}
+
+// int writes an integer to the hash.
+func (hw *hashWriter) int(v int) {
+	binary.LittleEndian.PutUint64(hw.buf[:], uint64(int64(v)))
+	hw.h.Write(hw.buf[:])
+}
+
+// float writes a floating-point value to the hash.  Values HiGHS would treat
+// as infinite are written as infinities, and negative zero is written as
+// zero.
+func (hw *hashWriter) float(v float64) {
+	switch {
+	case v >= highsInfinity:
+		v = math.Inf(1)
+	case v <= -highsInfinity:
+		v = math.Inf(-1)
+	case v == 0.0:
+		v = 0.0
+	}
+	binary.LittleEndian.PutUint64(hw.buf[:], math.Float64bits(v))
+	hw.h.Write(hw.buf[:])
+}
+
+// floats writes a slice of floating-point values to the hash.
+func (hw *hashWriter) floats(vs []float64) {
+	hw.int(len(vs))
+	for _, v := range vs {
+		hw.float(v)
+	}
+}
+
+// nonzeros writes a sorted list of nonzeros to the hash.
+func (hw *hashWriter) nonzeros(nzs []Nonzero) {
+	hw.int(len(nzs))
+	for _, nz := range nzs {
+		hw.int(nz.Row)
+		hw.int(nz.Col)
+		hw.float(nz.Val)
+	}
+}
+
+// canonicalNonzeros returns a list of nonzeros sorted in row-major order,
+// with later duplicates replacing earlier ones, as in ToRawModel, and with
+// explicit zeros removed.  If sym is true, each coordinate is first mapped
+// to the upper triangle.
+func canonicalNonzeros(nzs []Nonzero, sym bool) []Nonzero {
+	vals := make(map[[2]int]float64, len(nzs))
+	for _, nz := range nzs {
+		r, c := nz.Row, nz.Col
+		if sym && r > c {
+			r, c = c, r
+		}
+		vals[[2]int{r, c}] = nz.Val
+	}
+	out := make([]Nonzero, 0, len(vals))
+	for rc, v := range vals {
+		if v != 0.0 {
+			out = append(out, Nonzero{Row: rc[0], Col: rc[1], Val: v})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Row != out[j].Row {
+			return out[i].Row < out[j].Row
+		}
+		return out[i].Col < out[j].Col
+	})
+	return out
+}
+
+// Hash returns a hexadecimal SHA-256 hash of a model's mathematical content:
+// its objective sense and offset, costs, bounds, constraint matrix, Hessian
+// matrix, variable types, and row penalties.  Names, tags, the Verbose and
+// Strict fields, and the starting point are ignored.  Missing values are
+// treated as ToRawModel treats them, so, for example, a nil ColUpper hashes
+// the same as a slice of infinities.  The matrices are hashed in a canonical
+// order, with duplicate entries resolved as in ToRawModel and explicit zeros
+// omitted, so the order in which nonzeros were added does not affect the
+// result.  The hash does not depend on the process or platform, making it
+// suitable as a cache key or for verifying that two processes are solving the
+// same instance.
+func (m *Model) Hash() (string, error) {
+	// Fill in defaults as ToRawModel does.
+	nr, nc := m.modelSize()
+	mInf, pInf := math.Inf(-1), math.Inf(1)
+	colCost, ok1 := expandToLen(nc, m.ColCosts, 1.0)
+	colLower, ok2 := expandToLen(nc, m.ColLower, mInf)
+	colUpper, ok3 := expandToLen(nc, m.ColUpper, pInf)
+	varTypes, ok4 := expandToLen(nc, m.VarTypes, ContinuousType)
+	if !(ok1 && ok2 && ok3 && ok4) {
+		return "", fmt.Errorf("inconsistent column counts")
+	}
+	rowLower, ok1 := expandToLen(nr, m.RowLower, mInf)
+	rowUpper, ok2 := expandToLen(nr, m.RowUpper, pInf)
+	if !(ok1 && ok2) {
+		return "", fmt.Errorf("inconsistent row counts")
+	}
+
+	// Hash each component of the model in a fixed order.
+	hw := &hashWriter{h: sha256.New()}
+	if m.Maximize {
+		hw.int(1)
+	} else {
+		hw.int(0)
+	}
+	hw.float(m.Offset)
+	hw.int(nc)
+	hw.int(nr)
+	hw.floats(colCost)
+	hw.floats(colLower)
+	hw.floats(colUpper)
+	hw.floats(rowLower)
+	hw.floats(rowUpper)
+	for _, vt := range varTypes {
+		hw.int(int(vt))
+	}
+	hw.nonzeros(canonicalNonzeros(m.ConstMatrix, false))
+	hw.nonzeros(canonicalNonzeros(m.HessianMatrix, true))
+	if m.hasSoftRows() {
+		penalties, ok := expandToLen(nr, m.RowPenalties, 0.0)
+		if !ok {
+			return "", fmt.Errorf("inconsistent row counts")
+		}
+		hw.floats(penalties)
+	}
+	return hex.EncodeToString(hw.h.Sum(nil)), nil
+}
@@ -0,0 +1,78 @@
+// This file tests model hashing.
+
+package highs
+
+import (
+	"math"
+	"testing"
+)
+
+// hashModel returns a model's hash or fails the test.
+func hashModel(t *testing.T, m *Model) string {
+	t.Helper()
+	h, err := m.Hash()
+	checkErr(t, err)
+	return h
+}
+
+// TestModelHash tests that Hash ignores the representation of a model but
+// not its content.  The base model is
+//
+//	Min.  x_0 + 2x_1 + x_0^2
+//	s.t.  1 <= x_0 + x_1 <= 4
+//	      x_0 - x_1 >= 0
+//	with  x_0, x_1 >= 0; x_1 integer
+func TestModelHash(t *testing.T) {
+	// Construct the base model.
+	pInf := math.Inf(1)
+	base := Model{
+		ColCosts:      []float64{1.0, 2.0},
+		ColLower:      []float64{0.0, 0.0},
+		ColUpper:      []float64{pInf, pInf},
+		RowLower:      []float64{1.0, 0.0},
+		RowUpper:      []float64{4.0, pInf},
+		ConstMatrix:   []Nonzero{{0, 0, 1.0}, {0, 1, 1.0}, {1, 0, 1.0}, {1, 1, -1.0}},
+		HessianMatrix: []Nonzero{{0, 0, 2.0}},
+		VarTypes:      []VariableType{ContinuousType, IntegerType},
+	}
+	h := hashModel(t, &base)
+	const golden = "0d978f0bb8f007963f3bea660bf3002bda02fb7771ba22a1d3f46bcc62dbad3e"
+	if h != golden {
+		t.Fatalf("expected hash %s but saw %s", golden, h)
+	}
+
+	// Construct equivalent models.
+	same := base
+	same.ColUpper = nil
+	same.ConstMatrix = []Nonzero{{1, 1, -1.0}, {0, 1, 5.0}, {1, 0, 1.0}, {0, 0, 1.0}, {0, 1, 1.0}, {1, 0, 0.0}, {1, 0, 1.0}}
+	same.RowUpper = []float64{4.0, 1.0e30}
+	same.ColNames = []string{"x", "y"}
+	same.Verbose = true
+	if h2 := hashModel(t, &same); h2 != h {
+		t.Fatalf("equivalent models hashed to %s and %s", h, h2)
+	}
+
+	// Construct models that differ from the base model in one respect.
+	for name, mod := range map[string]func(m *Model){
+		"maximize": func(m *Model) { m.Maximize = true },
+		"offset":   func(m *Model) { m.Offset = 1.0 },
+		"cost":     func(m *Model) { m.ColCosts = []float64{1.0, 3.0} },
+		"bound":    func(m *Model) { m.RowLower = []float64{2.0, 0.0} },
+		"matrix":   func(m *Model) { m.ConstMatrix = []Nonzero{{0, 0, 1.0}, {0, 1, 1.0}, {1, 0, 1.0}} },
+		"hessian":  func(m *Model) { m.HessianMatrix = []Nonzero{{0, 0, 2.0}, {0, 1, 1.0}} },
+		"types":    func(m *Model) { m.VarTypes = []VariableType{IntegerType, IntegerType} },
+	} {
+		diff := base
+		mod(&diff)
+		if h2 := hashModel(t, &diff); h2 == h {
+			t.Fatalf("changing the %s did not change the hash", name)
+		}
+	}
+
+	// Ensure that inconsistent models are rejected.
+	bad := base
+	bad.RowUpper = []float64{4.0}
+	if _, err := bad.Hash(); err == nil {
+		t.Fatal("Hash accepted inconsistent row counts")
+	}
+}
@@ -8,6 +8,7 @@
 #define _EXTERNS_H_
 
 #include "util/HighsInt.h"
+#include "lp_data/HighsCallbackStruct.h"
 
 extern const HighsInt kHighsStatusError;
 extern const HighsInt kHighsStatusOk;
@@ -48,6 +49,12 @@ extern const HighsInt kHighsModelStatusObjectiveTarget;
 extern const HighsInt kHighsModelStatusTimeLimit;
 extern const HighsInt kHighsModelStatusIterationLimit;
 extern const HighsInt kHighsModelStatusUnknown;
+extern const HighsInt kHighsModelStatusSolutionLimit;
+extern const HighsInt kHighsModelStatusInterrupt;
+
+extern const HighsInt kHighsInfoTypeInt64;
+extern const HighsInt kHighsInfoTypeInt;
+extern const HighsInt kHighsInfoTypeDouble;
 
 extern const HighsInt kHighsBasisStatusLower;
 extern const HighsInt kHighsBasisStatusBasic;
@@ -55,6 +62,23 @@ extern const HighsInt kHighsBasisStatusUpper;
 extern const HighsInt kHighsBasisStatusZero;
 extern const HighsInt kHighsBasisStatusNonbasic;
 
+extern const HighsInt kHighsCallbackLogging;
+extern const HighsInt kHighsCallbackSimplexInterrupt;
+extern const HighsInt kHighsCallbackIpmInterrupt;
+extern const HighsInt kHighsCallbackMipSolution;
+extern const HighsInt kHighsCallbackMipImprovingSolution;
+extern const HighsInt kHighsCallbackMipLogging;
+extern const HighsInt kHighsCallbackMipInterrupt;
+extern const HighsInt kHighsCallbackMipGetCutPool;
+extern const HighsInt kHighsCallbackMipDefineLazyConstraints;
+extern const HighsInt kHighsCallbackMipUserSolution;
+
+extern
+void* Highs_create(void);
+
+extern
+void Highs_destroy(void* highs);
+
 extern
 HighsInt Highs_passModel(void* highs, const HighsInt num_col,
                          const HighsInt num_row, const HighsInt num_nz,
@@ -80,10 +104,194 @@ extern
 HighsInt Highs_getInt64InfoValue(const void* highs, const char* info,
                                  int64_t* value);
 
+extern
+HighsInt Highs_setCallback(void* highs, HighsCCallbackType user_callback,
+                           void* user_callback_data);
+
+extern
+HighsInt Highs_startCallback(void* highs, const int callback_type);
+
+extern
+HighsInt Highs_stopCallback(void* highs, const int callback_type);
+
+extern
+double Highs_getRunTime(const void* highs);
+
+extern
+HighsInt Highs_resetGlobalScheduler(const HighsInt blocking);
+
 extern
 HighsInt Highs_writeSolution(const void* highs, const char* filename);
 
 extern
 HighsInt Highs_writeSolutionPretty(const void* highs, const char* filename);
 
+extern
+HighsInt Highs_getNumCol(const void* highs);
+
+extern
+HighsInt Highs_getNumRow(const void* highs);
+
+extern
+HighsInt Highs_getNumNz(const void* highs);
+
+extern
+HighsInt Highs_getColsByRange(const void* highs, const HighsInt from_col,
+                              const HighsInt to_col, HighsInt* num_col,
+                              double* costs, double* lower, double* upper,
+                              HighsInt* num_nz, HighsInt* matrix_start,
+                              HighsInt* matrix_index, double* matrix_value);
+
+extern
+HighsInt Highs_getColsBySet(const void* highs, const HighsInt num_set_entries,
+                            const HighsInt* set, HighsInt* num_col,
+                            double* costs, double* lower, double* upper,
+                            HighsInt* num_nz, HighsInt* matrix_start,
+                            HighsInt* matrix_index, double* matrix_value);
+
+extern
+HighsInt Highs_getColByName(const void* highs, const char* name,
+                            HighsInt* col);
+
+extern
+HighsInt Highs_setSolution(void* highs, const double* col_value,
+                           const double* row_value, const double* col_dual,
+                           const double* row_dual);
+
+extern
+HighsInt Highs_getDualRay(const void* highs, HighsInt* has_dual_ray,
+                          double* dual_ray_value);
+
+extern
+HighsInt Highs_getPrimalRay(const void* highs, HighsInt* has_primal_ray,
+                            double* primal_ray_value);
+
+extern
+HighsInt Highs_getRanging(
+    void* highs,
+    double* col_cost_up_value, double* col_cost_up_objective,
+    HighsInt* col_cost_up_in_var, HighsInt* col_cost_up_ou_var,
+    double* col_cost_dn_value, double* col_cost_dn_objective,
+    HighsInt* col_cost_dn_in_var, HighsInt* col_cost_dn_ou_var,
+    double* col_bound_up_value, double* col_bound_up_objective,
+    HighsInt* col_bound_up_in_var, HighsInt* col_bound_up_ou_var,
+    double* col_bound_dn_value, double* col_bound_dn_objective,
+    HighsInt* col_bound_dn_in_var, HighsInt* col_bound_dn_ou_var,
+    double* row_bound_up_value, double* row_bound_up_objective,
+    HighsInt* row_bound_up_in_var, HighsInt* row_bound_up_ou_var,
+    double* row_bound_dn_value, double* row_bound_dn_objective,
+    HighsInt* row_bound_dn_in_var, HighsInt* row_bound_dn_ou_var);
+
+extern
+HighsInt Highs_changeCoeff(void* highs, const HighsInt row,
+                           const HighsInt col, const double value);
+
+extern
+HighsInt Highs_changeColBounds(void* highs, const HighsInt col,
+                               const double lower, const double upper);
+
+extern
+HighsInt Highs_changeColsBoundsByRange(void* highs, const HighsInt from_col,
+                                       const HighsInt to_col,
+                                       const double* lower,
+                                       const double* upper);
+
+extern
+HighsInt Highs_changeColsBoundsBySet(void* highs,
+                                     const HighsInt num_set_entries,
+                                     const HighsInt* set, const double* lower,
+                                     const double* upper);
+
+extern
+HighsInt Highs_changeRowBounds(void* highs, const HighsInt row,
+                               const double lower, const double upper);
+
+extern
+HighsInt Highs_changeRowsBoundsByRange(void* highs, const HighsInt from_row,
+                                       const HighsInt to_row,
+                                       const double* lower,
+                                       const double* upper);
+
+extern
+HighsInt Highs_changeRowsBoundsBySet(void* highs,
+                                     const HighsInt num_set_entries,
+                                     const HighsInt* set, const double* lower,
+                                     const double* upper);
+
+extern
+HighsInt Highs_changeRowsBoundsByMask(void* highs, const HighsInt* mask,
+                                      const double* lower,
+                                      const double* upper);
+
+extern
+HighsInt Highs_changeColCost(void* highs, const HighsInt col,
+                             const double cost);
+
+extern
+HighsInt Highs_changeColsCostBySet(void* highs,
+                                   const HighsInt num_set_entries,
+                                   const HighsInt* set, const double* cost);
+
+extern
+HighsInt Highs_presolve(void* highs);
+
+extern
+HighsInt Highs_getPresolvedNumCol(const void* highs);
+
+extern
+HighsInt Highs_getPresolvedNumRow(const void* highs);
+
+extern
+HighsInt Highs_getPresolvedNumNz(const void* highs);
+
+extern
+HighsInt Highs_getPresolvedLp(const void* highs, const HighsInt a_format,
+                              HighsInt* num_col, HighsInt* num_row,
+                              HighsInt* num_nz, HighsInt* sense,
+                              double* offset, double* col_cost,
+                              double* col_lower, double* col_upper,
+                              double* row_lower, double* row_upper,
+                              HighsInt* a_start, HighsInt* a_index,
+                              double* a_value, HighsInt* integrality);
+
+extern
+HighsInt Highs_crossover(void* highs, const int num_col, const int num_row,
+                         const double* col_value, const double* col_dual,
+                         const double* row_dual);
+
+extern
+HighsInt Highs_setBasis(void* highs, const HighsInt* col_status,
+                        const HighsInt* row_status);
+
+extern
+HighsInt Highs_setLogicalBasis(void* highs);
+
+extern
+HighsInt Highs_setSparseSolution(void* highs, const HighsInt num_entries,
+                                 const HighsInt* index, const double* value);
+
+extern
+HighsInt Highs_passColName(const void* highs, const HighsInt col,
+                           const char* name);
+
+extern
+HighsInt Highs_passRowName(const void* highs, const HighsInt row,
+                           const char* name);
+
+extern
+HighsInt Highs_getInfoType(const void* highs, const char* info,
+                           HighsInt* type);
+
+extern
+HighsInt Highs_getModelStatus(const void* highs);
+
+extern
+HighsInt Highs_writeOptionsDeviations(const void* highs, const char* filename);
+
+extern
+HighsInt Highs_readOptions(const void* highs, const char* filename);
+
+extern
+HighsInt Highs_readSolution(void* highs, const char* filename);
+
 #endif
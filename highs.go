@@ -27,9 +27,33 @@ vectors, and "ConstMatrix ColumnPrimal" denotes matrix-vector multiplication.
 ColumnPrimal is a member of the [Solution] struct and is what the preceding
 formulation is solving for.
 
+The highs package requires HiGHS 1.9.0 or later.  It relies on the callback
+interface defined in HiGHS's HighsCallbackStruct.h, including the
+kHighsCallbackMipUserSolution callback type and the user_solution and cutpool_*
+fields of HighsCallbackDataIn, none of which exist in earlier versions.  The
+package fails to compile against an older HiGHS.
+
+By default, the highs package locates HiGHS's header files and library using
+pkg-config.  On systems without pkg-config, build with the highs_nopkgconfig
+tag:
+
+    go build -tags highs_nopkgconfig
+
+That tag searches a few standard locations.  To use a HiGHS installed in a
+nonstandard location, point the standard cgo environment variables at it, for
+example,
+
+    CGO_CFLAGS="-I$HIGHS_HOME/include/highs" \
+    CGO_LDFLAGS="-L$HIGHS_HOME/lib -Wl,-rpath,$HIGHS_HOME/lib" \
+    go build -tags highs_nopkgconfig
+
+When cgo is unavailable (e.g., when cross-compiling or targeting WebAssembly),
+the highs package still builds but provides only the high-level [Model] API.
+In that mode, [Model.Solve] writes the model to a temporary MPS file and runs
+an external HiGHS executable, named by the HIGHS_EXECUTABLE environment
+variable or found as "highs" in the PATH.  [Model.SolveExternal] provides the
+same functionality in all builds.
+
 [HiGHS]: https://highs.dev/
 */
 package highs
-
-// #cgo pkg-config: highs
-import "C"
@@ -0,0 +1,83 @@
+// This file provides a channel-based interface to the improving solutions
+// HiGHS finds while solving a MIP.
+
+package highs
+
+import (
+	"time"
+	"unsafe"
+)
+
+// #include "highs-externs.h"
+import "C"
+
+// An Incumbent describes an improving solution found during a MIP solve.
+type Incumbent struct {
+	Objective   float64       // Objective value of the improving solution
+	PrimalBound float64       // Best known objective value
+	DualBound   float64       // Best proven bound on the objective value
+	Gap         float64       // Relative gap between PrimalBound and DualBound
+	NodeCount   int64         // Number of branch-and-bound nodes explored so far
+	RunTime     time.Duration // Time elapsed since the solve began
+	Solution    []float64     // Column values, if requested, or nil
+}
+
+// incumbentBuffer is the capacity of the channel returned by Incumbents.
+const incumbentBuffer = 16
+
+// Incumbents returns a channel that receives an Incumbent each time HiGHS
+// finds an improving solution during the next call to Solve or SolveContext.
+// The channel is closed when that solve completes.  If withSolution is true,
+// each Incumbent includes the improving solution's column values.
+//
+// Sending never blocks the solver.  If the receiver falls behind, the oldest
+// unreceived Incumbent is discarded, so the most recent Incumbent is always
+// delivered.
+func (m *RawModel) Incumbents(withSolution bool) (<-chan Incumbent, error) {
+	_, err := m.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer m.unlock()
+
+	// Register a callback that sends each improving solution.
+	ch := make(chan Incumbent, incumbentBuffer)
+	h := m.h
+	id, err := h.addCallback(cbMipImprovingSolution, func(_ string, out *C.HighsCallbackDataOut, _ *C.HighsCallbackDataIn) {
+		inc := Incumbent{
+			Objective:   float64(out.objective_function_value),
+			PrimalBound: float64(out.mip_primal_bound),
+			DualBound:   float64(out.mip_dual_bound),
+			Gap:         float64(out.mip_gap),
+			NodeCount:   int64(out.mip_node_count),
+			RunTime:     time.Duration(float64(out.running_time) * float64(time.Second)),
+		}
+		if withSolution && out.mip_solution != nil {
+			nc := int(C.Highs_getNumCol(h.obj))
+			cSoln := unsafe.Slice((*C.double)(unsafe.Pointer(out.mip_solution)), nc)
+			inc.Solution = convertSlice[float64, C.double](cSoln)
+		}
+		for {
+			select {
+			case ch <- inc:
+				return
+			default:
+				// Discard the oldest Incumbent to make room.
+				select {
+				case <-ch:
+				default:
+				}
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Unregister the callback and close the channel when the solve ends.
+	h.atSolveEnd(func() {
+		_ = h.removeCallback(id)
+		close(ch)
+	})
+	return ch, nil
+}
@@ -0,0 +1,134 @@
+// This file catalogs the items of information HiGHS reports after a solve.
+
+package highs
+
+// An InfoType indicates the Go type of an item of HiGHS information and
+// therefore which RawSolution method retrieves it.
+type InfoType int
+
+// These are the values an InfoType accepts:
+const (
+	IntInfo     InfoType = iota // Retrieved with GetIntInfo
+	Int64Info                   // Retrieved with GetInt64Info
+	Float64Info                 // Retrieved with GetFloat64Info
+)
+
+//go:generate stringer -type=InfoType
+
+// An InfoItem describes one item of information HiGHS reports after a solve.
+type InfoItem struct {
+	Name        string   // Name to pass to GetIntInfo, GetInt64Info, or GetFloat64Info
+	Type        InfoType // Type of the item's value
+	Description string   // Brief, human-readable description of the item
+}
+
+// infoItems lists every item of information reported by recent versions of
+// HiGHS.  Older versions may lack some of these.
+var infoItems = []InfoItem{
+	{"objective_function_value", Float64Info, "Objective function value"},
+	{"simplex_iteration_count", IntInfo, "Number of simplex iterations"},
+	{"ipm_iteration_count", IntInfo, "Number of interior-point iterations"},
+	{"crossover_iteration_count", IntInfo, "Number of crossover iterations"},
+	{"pdlp_iteration_count", IntInfo, "Number of PDLP iterations"},
+	{"qp_iteration_count", IntInfo, "Number of QP solver iterations"},
+	{"primal_solution_status", IntInfo, "Status of the primal solution"},
+	{"dual_solution_status", IntInfo, "Status of the dual solution"},
+	{"basis_validity", IntInfo, "Validity of the basis"},
+	{"mip_node_count", Int64Info, "Number of branch-and-bound nodes"},
+	{"mip_dual_bound", Float64Info, "Best dual bound on the MIP objective"},
+	{"mip_gap", Float64Info, "Relative gap between the MIP primal and dual bounds"},
+	{"max_integrality_violation", Float64Info, "Maximum violation of integrality"},
+	{"num_primal_infeasibilities", IntInfo, "Number of primal infeasibilities"},
+	{"max_primal_infeasibility", Float64Info, "Maximum primal infeasibility"},
+	{"sum_primal_infeasibilities", Float64Info, "Sum of primal infeasibilities"},
+	{"num_dual_infeasibilities", IntInfo, "Number of dual infeasibilities"},
+	{"max_dual_infeasibility", Float64Info, "Maximum dual infeasibility"},
+	{"sum_dual_infeasibilities", Float64Info, "Sum of dual infeasibilities"},
+	{"primal_dual_objective_error", Float64Info, "Relative difference between the primal and dual objective values"},
+	{"primal_dual_integral", Float64Info, "Primal-dual integral of the MIP solve"},
+}
+
+// InfoItems returns a description of every item of information HiGHS may
+// report after a solve.  Tools can use this list to dump all available
+// statistics without hard-coding item names.  Not every version of HiGHS
+// reports every item; RawSolution.GetAllInfo returns only those the linked
+// version recognizes.
+func InfoItems() []InfoItem {
+	items := make([]InfoItem, len(infoItems))
+	copy(items, infoItems)
+	return items
+}
+
+// An Info holds the value of every item of information listed by InfoItems,
+// as captured by RawSolution.InfoSnapshot.  Items the linked version of HiGHS
+// does not report are left zero.
+type Info struct {
+	ObjectiveFunctionValue   float64 `json:"objective_function_value"`    // Objective function value
+	SimplexIterationCount    int     `json:"simplex_iteration_count"`     // Number of simplex iterations
+	IPMIterationCount        int     `json:"ipm_iteration_count"`         // Number of interior-point iterations
+	CrossoverIterationCount  int     `json:"crossover_iteration_count"`   // Number of crossover iterations
+	PDLPIterationCount       int     `json:"pdlp_iteration_count"`        // Number of PDLP iterations
+	QPIterationCount         int     `json:"qp_iteration_count"`          // Number of QP solver iterations
+	PrimalSolutionStatus     int     `json:"primal_solution_status"`      // Status of the primal solution
+	DualSolutionStatus       int     `json:"dual_solution_status"`        // Status of the dual solution
+	BasisValidity            int     `json:"basis_validity"`              // Validity of the basis
+	MIPNodeCount             int64   `json:"mip_node_count"`              // Number of branch-and-bound nodes
+	MIPDualBound             float64 `json:"mip_dual_bound"`              // Best dual bound on the MIP objective
+	MIPGap                   float64 `json:"mip_gap"`                     // Relative gap between the MIP primal and dual bounds
+	MaxIntegralityViolation  float64 `json:"max_integrality_violation"`   // Maximum violation of integrality
+	NumPrimalInfeasibilities int     `json:"num_primal_infeasibilities"`  // Number of primal infeasibilities
+	MaxPrimalInfeasibility   float64 `json:"max_primal_infeasibility"`    // Maximum primal infeasibility
+	SumPrimalInfeasibilities float64 `json:"sum_primal_infeasibilities"`  // Sum of primal infeasibilities
+	NumDualInfeasibilities   int     `json:"num_dual_infeasibilities"`    // Number of dual infeasibilities
+	MaxDualInfeasibility     float64 `json:"max_dual_infeasibility"`      // Maximum dual infeasibility
+	SumDualInfeasibilities   float64 `json:"sum_dual_infeasibilities"`    // Sum of dual infeasibilities
+	PrimalDualObjectiveError float64 `json:"primal_dual_objective_error"` // Relative difference between the primal and dual objective values
+	PrimalDualIntegral       float64 `json:"primal_dual_integral"`        // Primal-dual integral of the MIP solve
+}
+
+// newInfo constructs an Info from a map of the form returned by
+// RawSolution.GetAllInfo.  Unrecognized names and values of the wrong type
+// are ignored.
+func newInfo(all map[string]any) Info {
+	var in Info
+	fields := map[string]any{
+		"objective_function_value":    &in.ObjectiveFunctionValue,
+		"simplex_iteration_count":     &in.SimplexIterationCount,
+		"ipm_iteration_count":         &in.IPMIterationCount,
+		"crossover_iteration_count":   &in.CrossoverIterationCount,
+		"pdlp_iteration_count":        &in.PDLPIterationCount,
+		"qp_iteration_count":          &in.QPIterationCount,
+		"primal_solution_status":      &in.PrimalSolutionStatus,
+		"dual_solution_status":        &in.DualSolutionStatus,
+		"basis_validity":              &in.BasisValidity,
+		"mip_node_count":              &in.MIPNodeCount,
+		"mip_dual_bound":              &in.MIPDualBound,
+		"mip_gap":                     &in.MIPGap,
+		"max_integrality_violation":   &in.MaxIntegralityViolation,
+		"num_primal_infeasibilities":  &in.NumPrimalInfeasibilities,
+		"max_primal_infeasibility":    &in.MaxPrimalInfeasibility,
+		"sum_primal_infeasibilities":  &in.SumPrimalInfeasibilities,
+		"num_dual_infeasibilities":    &in.NumDualInfeasibilities,
+		"max_dual_infeasibility":      &in.MaxDualInfeasibility,
+		"sum_dual_infeasibilities":    &in.SumDualInfeasibilities,
+		"primal_dual_objective_error": &in.PrimalDualObjectiveError,
+		"primal_dual_integral":        &in.PrimalDualIntegral,
+	}
+	for name, field := range fields {
+		switch p := field.(type) {
+		case *int:
+			if v, ok := all[name].(int); ok {
+				*p = v
+			}
+		case *int64:
+			if v, ok := all[name].(int64); ok {
+				*p = v
+			}
+		case *float64:
+			if v, ok := all[name].(float64); ok {
+				*p = v
+			}
+		}
+	}
+	return in
+}
@@ -0,0 +1,40 @@
+// This file tests the catalog of HiGHS information items.
+
+package highs
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestInfoFields tests that Info has a field of the correct type for every
+// item listed by InfoItems and that newInfo populates each of them.
+func TestInfoFields(t *testing.T) {
+	// Construct a map that assigns a distinct nonzero value to each item.
+	all := make(map[string]any, len(infoItems))
+	for i, item := range infoItems {
+		switch item.Type {
+		case IntInfo:
+			all[item.Name] = i + 1
+		case Int64Info:
+			all[item.Name] = int64(i + 1)
+		case Float64Info:
+			all[item.Name] = float64(i + 1)
+		}
+	}
+	all["no_such_item"] = 123
+
+	// Ensure that each item's value was stored in the field whose JSON
+	// name matches the item's name.
+	in := reflect.ValueOf(newInfo(all))
+	if in.NumField() != len(infoItems) {
+		t.Fatalf("Info has %d fields but there are %d info items", in.NumField(), len(infoItems))
+	}
+	for i := 0; i < in.NumField(); i++ {
+		name := in.Type().Field(i).Tag.Get("json")
+		if v := in.Field(i).Interface(); v != all[name] {
+			t.Fatalf("expected field %s to be %#v but saw %#v",
+				in.Type().Field(i).Name, all[name], v)
+		}
+	}
+}
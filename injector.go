@@ -0,0 +1,114 @@
+// This file provides support for feeding externally discovered solutions
+// into a MIP solve that is already running.
+
+package highs
+
+import (
+	"fmt"
+	"sync"
+	"unsafe"
+)
+
+// #include <stdlib.h>
+// #include "highs-externs.h"
+import "C"
+
+// A SolutionInjector passes feasible solutions found outside of HiGHS, for
+// example by a concurrently running heuristic, to a MIP solve in progress.
+// Its methods may be called from any goroutine.
+type SolutionInjector struct {
+	mu      sync.Mutex  // Protects all of the following
+	numCol  int         // Number of columns in the model
+	pending [][]float64 // Solutions not yet passed to HiGHS
+	ended   bool        // true=the solve has ended
+}
+
+// Inject queues a vector of column values to be passed to HiGHS the next time
+// the MIP solver asks for a user solution.  HiGHS treats the solution as a
+// candidate incumbent: it is accepted if it is feasible and improves on the
+// best solution found so far and is otherwise ignored.  Inject returns an
+// error if the vector has the wrong length or the solve has already ended.
+func (si *SolutionInjector) Inject(colValues []float64) error {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+	if si.ended {
+		return fmt.Errorf("cannot inject a solution after the solve has ended")
+	}
+	if len(colValues) != si.numCol {
+		return fmt.Errorf("expected %d column values but received %d",
+			si.numCol, len(colValues))
+	}
+	si.pending = append(si.pending, append([]float64(nil), colValues...))
+	return nil
+}
+
+// next removes and returns the oldest queued solution or nil if there is
+// none.
+func (si *SolutionInjector) next() []float64 {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+	if len(si.pending) == 0 {
+		return nil
+	}
+	x := si.pending[0]
+	si.pending = si.pending[1:]
+	return x
+}
+
+// end marks the solve as ended and discards any queued solutions.
+func (si *SolutionInjector) end() {
+	si.mu.Lock()
+	si.ended = true
+	si.pending = nil
+	si.mu.Unlock()
+}
+
+// InjectSolutions returns a SolutionInjector whose solutions are passed to
+// HiGHS during the next call to Solve or SolveContext.  HiGHS asks for user
+// solutions at several points in its MIP search and accepts one solution each
+// time, so queued solutions are passed in the order they were injected.
+// Solutions may be injected before the solve begins, in which case they are
+// passed at HiGHS's first request.  Combined with Incumbents, this lets an
+// external heuristic both learn of and improve upon HiGHS's incumbent while
+// the solve runs.
+func (m *RawModel) InjectSolutions() (*SolutionInjector, error) {
+	obj, err := m.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer m.unlock()
+
+	// Register a callback that passes one queued solution to HiGHS.  The
+	// solution is copied to C memory, which HiGHS reads after the callback
+	// returns.
+	si := &SolutionInjector{numCol: int(C.Highs_getNumCol(obj))}
+	var buf *C.double
+	h := m.h
+	id, err := h.addCallback(cbMipUserSolution, func(_ string, _ *C.HighsCallbackDataOut, in *C.HighsCallbackDataIn) {
+		if in == nil {
+			return
+		}
+		x := si.next()
+		if x == nil {
+			return
+		}
+		if buf == nil {
+			buf = (*C.double)(C.malloc(C.size_t(len(x)) * C.size_t(unsafe.Sizeof(C.double(0)))))
+		}
+		copy(unsafe.Slice(buf, len(x)), convertSlice[C.double, float64](x))
+		in.user_solution = buf
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Unregister the callback and free the C memory when the solve ends.
+	h.atSolveEnd(func() {
+		_ = h.removeCallback(id)
+		si.end()
+		if buf != nil {
+			C.free(unsafe.Pointer(buf))
+		}
+	})
+	return si, nil
+}
@@ -0,0 +1,287 @@
+//go:build cgo
+
+// This file provides support for solving models in a child process so that
+// a crash inside HiGHS cannot terminate the calling program.
+
+package highs
+
+import (
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sync"
+)
+
+// isolatedWorkerEnv names the environment variable that instructs a program
+// to act as an IsolatedSolver's worker process.
+const isolatedWorkerEnv = "HIGHS_GO_ISOLATED_WORKER"
+
+// ErrWorkerCrashed indicates that an IsolatedSolver's worker process exited
+// while solving a model.
+var ErrWorkerCrashed = errors.New("HiGHS worker process exited unexpectedly")
+
+// An isolatedRequest is sent from an IsolatedSolver to its worker.
+type isolatedRequest struct {
+	Model   *Model         // Model to solve, without tags
+	Options map[string]any // HiGHS options to apply
+}
+
+// An isolatedResponse is sent from a worker to its IsolatedSolver.
+type isolatedResponse struct {
+	Solution    Solution     // Solution to the model
+	StatusError *StatusError // Failed or non-optimal solve, if any
+	CallStatus  *CallStatus  // Other non-Ok status returned by HiGHS, if any
+	Err         string       // Message of any other error
+}
+
+// An IsolatedSolver solves models in a child worker process, communicating
+// with it over pipes, so that a crash or failed assertion inside the HiGHS
+// library terminates only the worker.  If the worker exits, the current
+// solve fails with ErrWorkerCrashed, and a new worker is started for the
+// next solve.  An IsolatedSolver solves one model at a time; concurrent
+// calls to Solve are serialized.
+//
+// By default, the worker is a second instance of the current executable,
+// which recognizes that it was started as a worker before its main function
+// runs.  The executable must therefore link the highs package with cgo
+// enabled.
+type IsolatedSolver struct {
+	// Path and Args specify the worker executable and its arguments.  If
+	// Path is empty, the current executable is used.
+	Path string
+	Args []string
+
+	mu   sync.Mutex    // Serializes solves
+	cmd  *exec.Cmd     // Running worker, or nil if none
+	enc  *gob.Encoder  // Encoder of requests to the worker
+	dec  *gob.Decoder  // Decoder of responses from the worker
+	pipe [2]*os.File   // Request writer and response reader
+	done chan struct{} // Closed when the worker exits
+}
+
+// NewIsolatedSolver returns an IsolatedSolver whose worker is the current
+// executable.  The worker is started when the first model is solved.
+func NewIsolatedSolver() *IsolatedSolver {
+	return &IsolatedSolver{}
+}
+
+// start launches a worker process.  The caller must hold s.mu.
+func (s *IsolatedSolver) start() error {
+	// Determine the executable to run.
+	path := s.Path
+	if path == "" {
+		var err error
+		path, err = os.Executable()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Create one pipe for requests and one for responses.  These are
+	// passed to the worker as file descriptors 3 and 4 so that HiGHS's
+	// console output cannot corrupt them.
+	reqR, reqW, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	respR, respW, err := os.Pipe()
+	if err != nil {
+		reqR.Close()
+		reqW.Close()
+		return err
+	}
+	cmd := exec.Command(path, s.Args...)
+	cmd.Env = append(os.Environ(), isolatedWorkerEnv+"=1")
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	cmd.ExtraFiles = []*os.File{reqR, respW}
+	err = cmd.Start()
+	reqR.Close()
+	respW.Close()
+	if err != nil {
+		reqW.Close()
+		respR.Close()
+		return err
+	}
+
+	// Reap the worker when it exits.
+	done := make(chan struct{})
+	go func() {
+		_ = cmd.Wait()
+		close(done)
+	}()
+	s.cmd = cmd
+	s.enc = gob.NewEncoder(reqW)
+	s.dec = gob.NewDecoder(respR)
+	s.pipe = [2]*os.File{reqW, respR}
+	s.done = done
+	return nil
+}
+
+// stop kills the worker process, if any, and waits for it to exit.  The
+// caller must hold s.mu.
+func (s *IsolatedSolver) stop() {
+	if s.cmd == nil {
+		return
+	}
+	_ = s.cmd.Process.Kill()
+	<-s.done
+	s.pipe[0].Close()
+	s.pipe[1].Close()
+	s.cmd = nil
+}
+
+// Solve solves a model in the worker process with the given HiGHS options,
+// keyed by name and applied as by ModelSolver.SetOption.  The model's tags
+// are not sent to the worker.  If ctx is canceled before the solve
+// completes, the worker is killed, and Solve returns ctx.Err().  Errors
+// returned by the worker are reproduced as StatusError or CallStatus values if
+// they originated in HiGHS and as plain errors with the same message
+// otherwise.
+func (s *IsolatedSolver) Solve(ctx context.Context, m *Model, opts map[string]any) (Solution, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Start a worker if none is running, including if the previous worker
+	// exited between solves.
+	if s.cmd != nil {
+		select {
+		case <-s.done:
+			s.stop()
+		default:
+		}
+	}
+	if s.cmd == nil {
+		if err := s.start(); err != nil {
+			return Solution{}, err
+		}
+	}
+
+	// Send the request and await the response.
+	mc := *m
+	mc.ColTags = nil
+	mc.RowTags = nil
+	var resp isolatedResponse
+	errc := make(chan error, 1)
+	go func() {
+		err := s.enc.Encode(isolatedRequest{Model: &mc, Options: opts})
+		if err == nil {
+			err = s.dec.Decode(&resp)
+		}
+		errc <- err
+	}()
+	select {
+	case <-ctx.Done():
+		s.stop()
+		<-errc
+		return Solution{}, ctx.Err()
+	case err := <-errc:
+		if err != nil {
+			// Assume the worker crashed, and arrange for a new
+			// worker to be started.
+			s.stop()
+			return Solution{}, fmt.Errorf("%w (%v)", ErrWorkerCrashed, err)
+		}
+	}
+
+	// Reconstruct the error, if any.
+	switch {
+	case resp.StatusError != nil:
+		return resp.Solution, resp.StatusError
+	case resp.CallStatus != nil:
+		return resp.Solution, *resp.CallStatus
+	case resp.Err != "":
+		return resp.Solution, errors.New(resp.Err)
+	default:
+		return resp.Solution, nil
+	}
+}
+
+// Close terminates the worker process, if any.  The IsolatedSolver can still
+// be used after Close, in which case a new worker is started.
+func (s *IsolatedSolver) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stop()
+	return nil
+}
+
+// isolatedSolve solves a single model on behalf of an IsolatedSolver.
+func isolatedSolve(m *Model, opts map[string]any) (Solution, error) {
+	// Reject models HiGHS cannot solve correctly.
+	if m.isMIQP() {
+		return Solution{}, ErrUnsupportedMIQP
+	}
+
+	// Solve models with soft rows via their softened equivalents.
+	if m.hasSoftRows() {
+		return m.solveSoft(func(sm *Model) (Solution, error) {
+			return isolatedSolve(sm, opts)
+		})
+	}
+
+	// Solve the model with a ModelSolver.
+	ms := NewModelSolver()
+	defer ms.Close()
+	if m.Verbose {
+		if err := ms.SetOption("output_flag", true); err != nil {
+			return Solution{}, err
+		}
+	}
+	for name, value := range opts {
+		if err := ms.SetOption(name, value); err != nil {
+			return Solution{}, err
+		}
+	}
+	warn := ms.LoadModel(m)
+	if warn != nil && !isWarning(warn) {
+		return Solution{}, warn
+	}
+	soln, err := ms.Solve()
+	if err == nil {
+		err = warn
+	}
+	return soln, err
+}
+
+// runIsolatedWorker serves requests from an IsolatedSolver until its request
+// pipe is closed.
+func runIsolatedWorker() {
+	dec := gob.NewDecoder(os.NewFile(3, "requests"))
+	enc := gob.NewEncoder(os.NewFile(4, "responses"))
+	for {
+		var req isolatedRequest
+		if err := dec.Decode(&req); err != nil {
+			return
+		}
+		var resp isolatedResponse
+		var err error
+		resp.Solution, err = isolatedSolve(req.Model, req.Options)
+		var se *StatusError
+		var cs CallStatus
+		switch {
+		case errors.As(err, &se):
+			resp.StatusError = se
+		case errors.As(err, &cs):
+			resp.CallStatus = &cs
+		case err != nil:
+			resp.Err = err.Error()
+		}
+		if err = enc.Encode(resp); err != nil {
+			return
+		}
+	}
+}
+
+// init turns the current process into an IsolatedSolver's worker if it was
+// started as one.  The worker exits once its IsolatedSolver closes the
+// request pipe.
+func init() {
+	if os.Getenv(isolatedWorkerEnv) != "" {
+		runIsolatedWorker()
+		os.Exit(0)
+	}
+}
@@ -0,0 +1,90 @@
+// This file provides support for formulating least-squares problems as QPs.
+
+package highs
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// NewLeastSquaresModel returns a Model that minimizes the squared residual
+// ‖Ax − b‖².  The objective function is expanded as
+// xᵀ(AᵀA)x − 2(Aᵀb)ᵀx + bᵀb, so the Model's HessianMatrix holds the upper
+// triangle of 2AᵀA, its ColCosts hold −2Aᵀb, and its Offset holds bᵀb, making
+// the objective value of a solution equal to its squared residual.  The Model
+// has one unbounded, continuous column per column of A and no rows; callers
+// can add column bounds and linear constraints before solving it.  As in
+// NewModelFromMatrix, matrices that implement mat.NonZeroDoer are traversed
+// without visiting their zero elements.
+func NewLeastSquaresModel(a mat.Matrix, b []float64) (*Model, error) {
+	// Check the dimensions of the arguments.
+	nr, nc := a.Dims()
+	if len(b) != nr {
+		return nil, fmt.Errorf("b has %d elements but A has %d rows", len(b), nr)
+	}
+
+	// Group the nonzeros of A by row.
+	byRow := make([][]Nonzero, nr)
+	for _, nz := range matrixNonzeros(a) {
+		byRow[nz.Row] = append(byRow[nz.Row], nz)
+	}
+
+	// Accumulate AᵀA, Aᵀb, and bᵀb.
+	ata := make(map[[2]int]float64)
+	atb := make([]float64, nc)
+	btb := 0.0
+	for r, row := range byRow {
+		btb += b[r] * b[r]
+		for _, nz1 := range row {
+			atb[nz1.Col] += nz1.Val * b[r]
+			for _, nz2 := range row {
+				if nz1.Col <= nz2.Col {
+					ata[[2]int{nz1.Col, nz2.Col}] += nz1.Val * nz2.Val
+				}
+			}
+		}
+	}
+
+	// Construct the model.
+	m := &Model{
+		ColCosts: make([]float64, nc),
+		ColLower: make([]float64, nc),
+		ColUpper: make([]float64, nc),
+		Offset:   btb,
+	}
+	mInf, pInf := math.Inf(-1), math.Inf(1)
+	for c := range m.ColCosts {
+		m.ColCosts[c] = -2.0 * atb[c]
+		m.ColLower[c] = mInf
+		m.ColUpper[c] = pInf
+	}
+	m.HessianMatrix = make([]Nonzero, 0, len(ata))
+	for rc, v := range ata {
+		if v != 0.0 {
+			m.HessianMatrix = append(m.HessianMatrix, Nonzero{rc[0], rc[1], 2.0 * v})
+		}
+	}
+	sort.Slice(m.HessianMatrix, func(i, j int) bool {
+		hi, hj := m.HessianMatrix[i], m.HessianMatrix[j]
+		if hi.Row != hj.Row {
+			return hi.Row < hj.Row
+		}
+		return hi.Col < hj.Col
+	})
+	return m, nil
+}
+
+// SolveLeastSquares finds the x that minimizes ‖Ax − b‖².  It is a
+// convenience wrapper for NewLeastSquaresModel followed by Model.Solve.  The
+// returned Solution's ColumnPrimal field holds x, and its Objective field
+// holds the squared residual.
+func SolveLeastSquares(a mat.Matrix, b []float64) (Solution, error) {
+	m, err := NewLeastSquaresModel(a, b)
+	if err != nil {
+		return Solution{}, err
+	}
+	return m.Solve()
+}
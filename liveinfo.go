@@ -0,0 +1,123 @@
+// This file publishes information from a running solve so that RawSolutions
+// can be polled without waiting for the solve to finish.
+
+package highs
+
+import (
+	"fmt"
+	"math"
+	"time"
+	"unsafe"
+)
+
+// #include "highs-externs.h"
+import "C"
+
+// lockPollInterval is how long lockOrLive waits before retrying when a
+// handle's lock is held by something other than a solve.
+const lockPollInterval = time.Millisecond
+
+// startLiveInfo begins publishing, from HiGHS's interrupt callbacks, the
+// items of information a solve reports as it runs.  It returns a function
+// that stops publishing.  The caller must hold the handle's lock.
+func (h *handle) startLiveInfo() (stop func()) {
+	h.live.Lock()
+	h.liveInfo = map[string]any{
+		"simplex_iteration_count": 0,
+		"ipm_iteration_count":     0,
+		"mip_node_count":          int64(0),
+	}
+	h.live.Unlock()
+	ids := make([]int, 0, 3)
+	for _, cbType := range []int{cbSimplexInterrupt, cbIpmInterrupt, cbMipInterrupt} {
+		id, err := h.addCallback(cbType, h.recordLiveInfo(cbType))
+		if err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return func() {
+		for _, id := range ids {
+			_ = h.removeCallback(id)
+		}
+		h.live.Lock()
+		h.liveInfo = nil
+		h.live.Unlock()
+	}
+}
+
+// recordLiveInfo is a callbackFunc that copies the data HiGHS passes to an
+// interrupt callback of a given type into the handle's live information.
+// Bounds HiGHS reports as infinite are not yet known and are not recorded.
+func (h *handle) recordLiveInfo(cbType int) callbackFunc {
+	return func(_ string, out *C.HighsCallbackDataOut, _ *C.HighsCallbackDataIn) {
+		h.live.Lock()
+		defer h.live.Unlock()
+		switch cbType {
+		case cbSimplexInterrupt:
+			h.liveInfo["simplex_iteration_count"] = int(out.simplex_iteration_count)
+		case cbIpmInterrupt:
+			h.liveInfo["ipm_iteration_count"] = int(out.ipm_iteration_count)
+		case cbMipInterrupt:
+			h.liveInfo["mip_node_count"] = int64(out.mip_node_count)
+			for name, v := range map[string]C.double{
+				"objective_function_value": out.mip_primal_bound,
+				"mip_dual_bound":           out.mip_dual_bound,
+				"mip_gap":                  out.mip_gap,
+			} {
+				if !math.IsInf(float64(v), 0) {
+					h.liveInfo[name] = float64(v)
+				}
+			}
+		}
+	}
+}
+
+// lockOrLive locks a handle and returns its HiGHS object unless a solve is
+// holding the lock.  In that case, it instead returns, without locking, a
+// copy of the information published so far by the solve.  lockOrLive returns
+// ErrClosed, leaving the handle unlocked, if the HiGHS object has already been
+// destroyed.
+func (h *handle) lockOrLive() (unsafe.Pointer, map[string]any, error) {
+	if h == nil {
+		return nil, nil, ErrClosed
+	}
+	for {
+		// Lock the handle if it is available.
+		if h.mu.TryLock() {
+			if h.obj == nil {
+				h.mu.Unlock()
+				return nil, nil, ErrClosed
+			}
+			return h.obj, nil, nil
+		}
+
+		// Return the live information if a solve holds the lock.
+		h.live.RLock()
+		var snap map[string]any
+		if h.liveInfo != nil {
+			snap = make(map[string]any, len(h.liveInfo))
+			for k, v := range h.liveInfo {
+				snap[k] = v
+			}
+		}
+		h.live.RUnlock()
+		if snap != nil {
+			return nil, snap, nil
+		}
+
+		// Another operation holds the lock.  Wait and try again.
+		time.Sleep(lockPollInterval)
+	}
+}
+
+// liveValue returns a named item of information of a given type from the
+// information published by a running solve.  goName is the name of the
+// public method that invoked liveValue.
+func liveValue[T int | int64 | float64](snap map[string]any, info, goName string) (T, error) {
+	v, ok := snap[info].(T)
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("%s: %s is not available while the model is being solved", goName, info)
+	}
+	return v, nil
+}
@@ -1,10 +1,20 @@
+//go:build cgo
+
 // This file tests the high package's low-level API wrappers.
 
 package highs
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"math"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"testing/fstest"
 )
 
 // TestFullAPIMin mimics the first test in HiGHS's full_api function from
@@ -155,3 +165,778 @@ func TestFullAPIQPMin(t *testing.T) {
 		t.Fatalf("objective value was %.2f but should have been -5.25", soln.Objective)
 	}
 }
+
+// TestClose verifies that closing a RawModel renders the model unusable but
+// leaves its solutions valid and that closing more than once is harmless.
+func TestClose(t *testing.T) {
+	// Prepare and solve a model.
+	model := NewRawModel()
+	checkErr(t, model.SetBoolOption("output_flag", false))
+	checkErr(t, model.AddColumnBounds([]float64{0.0, 0.0}, []float64{10.0, 10.0}))
+	checkErr(t, model.SetColumnCosts([]float64{1.0, 1.0}))
+	checkErr(t, model.AddDenseRow(4.0, []float64{1.0, 1.0}, 4.0))
+	soln, err := model.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Close the model twice.
+	checkErr(t, model.Close())
+	checkErr(t, model.Close())
+
+	// Ensure that the model can no longer be used.
+	if err = model.SetOffset(1.0); !errors.Is(err, ErrClosed) {
+		t.Fatalf("expected ErrClosed but saw %v", err)
+	}
+	if _, err = model.Solve(); !errors.Is(err, ErrClosed) {
+		t.Fatalf("expected ErrClosed but saw %v", err)
+	}
+
+	// Ensure that the solution can still be queried.
+	_, err = soln.GetIntInfo("simplex_iteration_count")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Ensure that an uninitialized solution fails gracefully.
+	var empty RawSolution
+	if _, err = empty.GetIntInfo("simplex_iteration_count"); !errors.Is(err, ErrClosed) {
+		t.Fatalf("expected ErrClosed but saw %v", err)
+	}
+}
+
+// TestSetOptionFromString tests that options of each type can be assigned
+// from strings.
+func TestSetOptionFromString(t *testing.T) {
+	model := NewRawModel()
+	checkErr(t, model.SetOptionFromString("output_flag", "false"))
+	checkErr(t, model.SetOptionFromString("simplex_iteration_limit", "1234"))
+	checkErr(t, model.SetOptionFromString("time_limit", "56.5"))
+	checkErr(t, model.SetOptionFromString("solver", "ipm"))
+	if err := model.SetOptionFromString("time_limit", "soon"); err == nil {
+		t.Fatal("SetOptionFromString unexpectedly accepted a non-numeric time limit")
+	}
+
+	// Confirm that the options were assigned.
+	if b, err := model.GetBoolOption("output_flag"); err != nil || b {
+		t.Fatalf("expected output_flag to be false but saw %v (%v)", b, err)
+	}
+	if i, err := model.GetIntOption("simplex_iteration_limit"); err != nil || i != 1234 {
+		t.Fatalf("expected simplex_iteration_limit to be 1234 but saw %d (%v)", i, err)
+	}
+	if f, err := model.GetFloat64Option("time_limit"); err != nil || f != 56.5 {
+		t.Fatalf("expected time_limit to be 56.5 but saw %v (%v)", f, err)
+	}
+	if s, err := model.GetStringOption("solver"); err != nil || s != "ipm" {
+		t.Fatalf("expected solver to be ipm but saw %q (%v)", s, err)
+	}
+}
+
+// TestRowColumnEntries tests that RowEntries and ColumnEntries return the
+// nonzeros of individual rows and columns of the following constraint matrix,
+// which includes an empty row:
+//
+//	[ 1 0 2 ]
+//	[ 0 0 0 ]
+//	[ 0 3 4 ]
+func TestRowColumnEntries(t *testing.T) {
+	// Prepare the model.
+	var model Model
+	model.AddDenseRow(0.0, []float64{1.0, 0.0, 2.0}, 10.0)
+	model.AddDenseRow(0.0, []float64{0.0, 0.0, 0.0}, 10.0)
+	model.AddDenseRow(0.0, []float64{0.0, 3.0, 4.0}, 10.0)
+	model.ColCosts = []float64{1.0, 1.0, 1.0}
+	raw, err := model.ToRawModel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer raw.Close()
+
+	// Check each row and column.
+	for _, tc := range []struct {
+		name string
+		get  func() ([]Nonzero, error)
+		exp  []Nonzero
+	}{
+		{"row 0", func() ([]Nonzero, error) { return raw.RowEntries(0) }, []Nonzero{{0, 0, 1.0}, {0, 2, 2.0}}},
+		{"row 1", func() ([]Nonzero, error) { return raw.RowEntries(1) }, nil},
+		{"row 2", func() ([]Nonzero, error) { return raw.RowEntries(2) }, []Nonzero{{2, 1, 3.0}, {2, 2, 4.0}}},
+		{"column 0", func() ([]Nonzero, error) { return raw.ColumnEntries(0) }, []Nonzero{{0, 0, 1.0}}},
+		{"column 2", func() ([]Nonzero, error) { return raw.ColumnEntries(2) }, []Nonzero{{0, 2, 2.0}, {2, 2, 4.0}}},
+	} {
+		nzs, err := tc.get()
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if !reflect.DeepEqual(nzs, tc.exp) {
+			t.Fatalf("%s: expected %v but observed %v", tc.name, tc.exp, nzs)
+		}
+	}
+
+	// Ensure that out-of-range indices are rejected.
+	if _, err = raw.RowEntries(3); err == nil {
+		t.Fatal("RowEntries accepted an out-of-range row")
+	}
+	if _, err = raw.ColumnEntries(-1); err == nil {
+		t.Fatal("ColumnEntries accepted an out-of-range column")
+	}
+}
+
+// TestAddRowMap tests that AddRowMap adds the same rows as AddDenseRow,
+// including an empty row, by constructing the following model:
+//
+//	Min    f  =  x_0 +  x_1 + 3
+//	s.t.                x_1 <= 7
+//	       5 <=  x_0 + 2x_1 <= 15
+//	       6 <= 3x_0 + 2x_1
+//	       0 <=      0      <= 1
+//	0 <= x_0 <= 4; 1 <= x_1
+func TestAddRowMap(t *testing.T) {
+	// Prepare the model.
+	model := NewRawModel()
+	defer model.Close()
+	checkErr(t, model.SetBoolOption("output_flag", false))
+	checkErr(t, model.SetOffset(3.0))
+	checkErr(t, model.AddColumnBounds([]float64{0.0, 1.0},
+		[]float64{4.0, 1.0e30}))
+	checkErr(t, model.SetColumnCosts([]float64{1.0, 1.0}))
+	checkErr(t, model.AddRowMap(-1.0e30, map[int]float64{1: 1.0}, 7.0))
+	checkErr(t, model.AddRowMap(5.0, map[int]float64{1: 2.0, 0: 1.0}, 15.0))
+	checkErr(t, model.AddRowMap(6.0, map[int]float64{0: 3.0, 1: 2.0}, 1.0e30))
+	checkErr(t, model.AddRowMap(0.0, map[int]float64{0: 0.0}, 1.0))
+	checkErr(t, model.AddDenseRow(0.0, []float64{0.0, 0.0}, 1.0))
+	if err := model.AddRowMap(0.0, map[int]float64{-1: 1.0}, 1.0); err == nil {
+		t.Fatal("AddRowMap accepted a negative column index")
+	}
+
+	// Confirm that the rows were stored correctly.
+	nzs, err := model.RowEntries(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := []Nonzero{{1, 0, 1.0}, {1, 1, 2.0}}
+	if !reflect.DeepEqual(nzs, exp) {
+		t.Fatalf("expected %v but observed %v", exp, nzs)
+	}
+	nzs, err = model.RowEntries(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nzs) != 0 {
+		t.Fatalf("expected an empty row but observed %v", nzs)
+	}
+
+	// Solve the model.
+	soln, err := model.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if soln.Status != Optimal {
+		t.Fatalf("Solve returned %s instead of Optimal", soln.Status)
+	}
+	compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{0.5, 2.25})
+	if soln.Objective != 5.75 {
+		t.Fatalf("objective value was %.2f but should have been 5.75", soln.Objective)
+	}
+}
+
+// TestBasicVariables tests that BasicVariables reports the columns and rows
+// that are basic in the optimal solution of the model from TestFullAPIMin,
+// namely both columns and row 0.
+func TestBasicVariables(t *testing.T) {
+	// Prepare and solve the model.
+	model := NewRawModel()
+	defer model.Close()
+	checkErr(t, model.SetBoolOption("output_flag", false))
+	checkErr(t, model.SetStringOption("solver", "simplex"))
+	checkErr(t, model.SetOffset(3.0))
+	checkErr(t, model.AddColumnBounds([]float64{0.0, 1.0},
+		[]float64{4.0, 1.0e30}))
+	checkErr(t, model.SetColumnCosts([]float64{1.0, 1.0}))
+	checkErr(t, model.AddCompSparseRows([]float64{-1.0e30, 5.0, 6.0},
+		[]int{0, 1, 3}, []int{1, 0, 1, 0, 1}, []float64{1.0, 1.0, 2.0, 3.0, 2.0},
+		[]float64{7.0, 15.0, 1.0e30}))
+	soln, err := model.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if soln.Status != Optimal {
+		t.Fatalf("Solve returned %s instead of Optimal", soln.Status)
+	}
+
+	// Check the basic variables, which HiGHS may report in any order.
+	bvs, err := model.BasicVariables()
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := make(map[BasicVariable]bool, len(bvs))
+	for _, bv := range bvs {
+		seen[bv] = true
+	}
+	exp := map[BasicVariable]bool{
+		{Index: 0}:            true,
+		{Index: 1}:            true,
+		{Index: 0, Row: true}: true,
+	}
+	if !reflect.DeepEqual(seen, exp) {
+		t.Fatalf("expected basic variables %v but saw %v", exp, bvs)
+	}
+}
+
+// TestColumnGeneration tests that ColumnGeneration adds priced-out columns
+// until none has negative reduced cost.  The master problem covers a demand
+// of 2 for item A and 3 for item B using patterns that each cost 1:
+//
+//	Min.  x_0 + x_1 (+ x_2)
+//	s.t.  x_0       (+ x_2) >= 2
+//	            x_1 (+ x_2) >= 3
+//	with  x_i >= 0
+//
+// The pricer offers a pattern x_2 containing both items.
+func TestColumnGeneration(t *testing.T) {
+	// Prepare the initial master problem.
+	var model Model
+	model.ColCosts = []float64{1.0, 1.0}
+	model.ColLower = []float64{0.0, 0.0}
+	model.AddDenseRow(2.0, []float64{1.0, 0.0}, math.Inf(1))
+	model.AddDenseRow(3.0, []float64{0.0, 1.0}, math.Inf(1))
+	master, err := model.ToRawModel()
+	checkErr(t, err)
+	checkErr(t, master.SetBoolOption("output_flag", false))
+	cg, err := NewColumnGeneration(master)
+	checkErr(t, err)
+
+	// Run column generation.
+	both := CGColumn{Cost: 1.0, Lower: 0.0, Upper: math.Inf(1), Coeffs: map[int]float64{0: 1.0, 1: 1.0}}
+	rounds := 0
+	soln, err := cg.Run(func(duals []float64) ([]CGColumn, error) {
+		rounds++
+		rc, err := cg.ReducedCost(both)
+		if err != nil || rc >= -1.0e-9 {
+			return nil, err
+		}
+		return []CGColumn{both}, nil
+	}, 0)
+	checkErr(t, err)
+
+	// Ensure that the combined pattern was added once and was beneficial.
+	if rounds != 2 {
+		t.Fatalf("expected 2 pricing rounds but saw %d", rounds)
+	}
+	nc, err := master.NumColumns()
+	checkErr(t, err)
+	if nc != 3 {
+		t.Fatalf("expected 3 columns but saw %d", nc)
+	}
+	if soln.Objective != 3.0 {
+		t.Fatalf("expected an objective value of 3 but saw %v", soln.Objective)
+	}
+}
+
+// TestInfeasibleDiagnostics verifies that solving an infeasible model with
+// presolve disabled returns the final dual values and a dual ray.  It uses
+// the model from TestFullAPIInfeasible.
+func TestInfeasibleDiagnostics(t *testing.T) {
+	// Prepare the model.
+	model := NewRawModel()
+	checkErr(t, model.SetBoolOption("output_flag", false))
+	checkErr(t, model.SetStringOption("presolve", "off"))
+	checkErr(t, model.AddColumnBounds([]float64{0.0, 0.0}, []float64{10.0, 10.0}))
+	checkErr(t, model.AddDenseRow(4.0, []float64{1.0}, 4.0))
+	checkErr(t, model.AddDenseRow(5.0, []float64{1.0}, 5.0))
+
+	// Solve the model, and check the diagnostic data.
+	soln, err := model.Solve()
+	checkErr(t, err)
+	if soln.Status != Infeasible {
+		t.Fatalf("Solve returned %s instead of Infeasible", soln.Status)
+	}
+	if len(soln.ColumnPrimal) != 2 || len(soln.RowDual) != 2 {
+		t.Fatalf("expected 2 primal and dual values but saw %v and %v",
+			soln.ColumnPrimal, soln.RowDual)
+	}
+	if len(soln.DualRay) != 2 || (soln.DualRay[0] == 0.0 && soln.DualRay[1] == 0.0) {
+		t.Fatalf("expected a nonzero dual ray of length 2 but saw %v", soln.DualRay)
+	}
+	if soln.PrimalRay != nil {
+		t.Fatalf("expected no primal ray but saw %v", soln.PrimalRay)
+	}
+
+	// Ensure that the ray methods agree with the solution.
+	dRay, err := soln.GetDualRay()
+	checkErr(t, err)
+	compSlices(t, "GetDualRay", dRay, soln.DualRay)
+	pRay, err := soln.GetPrimalRay()
+	checkErr(t, err)
+	if pRay != nil {
+		t.Fatalf("expected no primal ray but saw %v", pRay)
+	}
+}
+
+// TestAddColumns tests that AddColumns assigns costs, bounds, and types to
+// only the columns it adds.  It constructs the following model one column at
+// a time:
+//
+//	Min    f  =  -2x_0 - x_1
+//	s.t.          x_0 + x_1 <= 3.5
+//	0 <= x_0 <= 2.5; 0 <= x_1 <= 10; x_0 integer
+func TestAddColumns(t *testing.T) {
+	// Prepare the model.
+	model := NewRawModel()
+	defer model.Close()
+	checkErr(t, model.SetBoolOption("output_flag", false))
+	checkErr(t, model.AddColumns([]float64{-2.0}, []float64{0.0}, []float64{2.5},
+		[]VariableType{IntegerType}))
+	checkErr(t, model.AddColumns([]float64{-1.0}, []float64{0.0}, []float64{10.0}, nil))
+	checkErr(t, model.AddRowMap(math.Inf(-1), map[int]float64{0: 1.0, 1: 1.0}, 3.5))
+	if err := model.AddColumns([]float64{1.0, 2.0}, []float64{0.0}, nil, nil); err == nil {
+		t.Fatal("AddColumns accepted inconsistent column counts")
+	}
+	nc, err := model.NumColumns()
+	checkErr(t, err)
+	if nc != 2 {
+		t.Fatalf("expected 2 columns but saw %d", nc)
+	}
+
+	// Solve the model.
+	soln, err := model.Solve()
+	checkErr(t, err)
+	if soln.Status != Optimal {
+		t.Fatalf("Solve returned %s instead of Optimal", soln.Status)
+	}
+	compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{2.0, 1.5})
+	if soln.Objective != -5.5 {
+		t.Fatalf("objective value was %.2f but should have been -5.50", soln.Objective)
+	}
+}
+
+// TestSetColumnCostsRange tests that SetColumnCostsRange, ChangeColCost, and
+// ChangeColsCostBySet replace only the costs of the given columns and accept
+// empty slices.
+func TestSetColumnCostsRange(t *testing.T) {
+	// Prepare the model.
+	model := NewRawModel()
+	defer model.Close()
+	checkErr(t, model.AddColumns([]float64{1.0, 2.0, 3.0, 4.0}, nil, nil, nil))
+
+	// Replace a block of costs, then confirm that all costs are as expected.
+	checkErr(t, model.SetColumnCostsRange(1, []float64{20.0, 30.0}))
+	checkErr(t, model.SetColumnCostsRange(3, nil))
+	checkErr(t, model.SetColumnCosts(nil))
+	m, err := model.ToModel()
+	checkErr(t, err)
+	compSlices(t, "ColCosts", m.ColCosts, []float64{1.0, 20.0, 30.0, 4.0})
+
+	// Replace a single cost and a set of costs.
+	checkErr(t, model.ChangeColCost(0, 10.0))
+	checkErr(t, model.ChangeColsCostBySet([]int{3, 1}, []float64{40.0, 21.0}))
+	checkErr(t, model.ChangeColsCostBySet(nil, nil))
+	m, err = model.ToModel()
+	checkErr(t, err)
+	compSlices(t, "ColCosts", m.ColCosts, []float64{10.0, 21.0, 30.0, 40.0})
+
+	// Ensure that out-of-range columns are rejected.
+	if err := model.SetColumnCostsRange(3, []float64{5.0, 6.0}); err == nil {
+		t.Fatal("SetColumnCostsRange accepted an out-of-range column")
+	}
+	if err := model.SetColumnCostsRange(-1, []float64{5.0}); err == nil {
+		t.Fatal("SetColumnCostsRange accepted a negative column")
+	}
+	if err := model.ChangeColCost(4, 5.0); err == nil {
+		t.Fatal("ChangeColCost accepted an out-of-range column")
+	}
+	if err := model.ChangeColsCostBySet([]int{2, 2}, []float64{5.0, 6.0}); err == nil {
+		t.Fatal("ChangeColsCostBySet accepted a repeated column")
+	}
+}
+
+// TestCallLog tests that a CallLogFunc observes HiGHS C calls along with
+// their arguments' shapes and returned statuses.
+func TestCallLog(t *testing.T) {
+	// Record all calls made while constructing a model.
+	var recs []CallRecord
+	SetCallLogFunc(func(cr CallRecord) { recs = append(recs, cr) })
+	defer SetCallLogFunc(nil)
+	model := NewRawModel()
+	defer model.Close()
+	checkErr(t, model.SetIntOption("threads", 1))
+	checkErr(t, model.AddCompSparseRows([]float64{0.0, 1.0}, []int{0, 1},
+		[]int{0, 0}, []float64{1.0, 2.0}, []float64{5.0, 6.0}))
+	if err := model.SetIntOption("no_such_option", 1); err == nil {
+		t.Fatal("SetIntOption accepted an invalid option")
+	}
+	SetCallLogFunc(nil)
+
+	// Confirm that the calls were recorded.
+	var got []string
+	for _, cr := range recs {
+		got = append(got, cr.String())
+	}
+	exp := []string{
+		"Highs_setIntOptionValue(option=threads, value=1) = 0 [SetIntOption]",
+		"Highs_addRows(num_new_row=2, num_new_nz=2) = 0 [AddCompSparseRows]",
+		"Highs_setIntOptionValue(option=no_such_option, value=1) = -1 [SetIntOption]",
+	}
+	if g, e := strings.Join(got, "\n"), strings.Join(exp, "\n"); g != e {
+		t.Fatalf("expected calls\n%s\nbut saw\n%s", e, g)
+	}
+}
+
+// TestRunBenchmark tests that RunBenchmark solves each model file in a file
+// system and ignores other files.
+func TestRunBenchmark(t *testing.T) {
+	fsys := fstest.MapFS{
+		"feasible.lp": {Data: []byte(`Minimize
+ obj: x + y
+Subject To
+ c1: x + 2 y >= 4
+End
+`)},
+		"sub/infeasible.lp": {Data: []byte(`Minimize
+ obj: x
+Subject To
+ c1: x >= 5
+ c2: x <= 3
+End
+`)},
+		"README": {Data: []byte("not a model")},
+	}
+	results, err := RunBenchmark(context.Background(), fsys,
+		map[string]any{"presolve": "off"}, 2)
+	checkErr(t, err)
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results but saw %d", len(results))
+	}
+	if r := results[0]; r.Name != "feasible.lp" || r.Status != Optimal || r.Objective != 2.0 {
+		t.Fatalf("unexpected result %+v", r)
+	}
+	if r := results[1]; r.Name != "sub/infeasible.lp" || r.Status != Infeasible {
+		t.Fatalf("unexpected result %+v", r)
+	}
+	if results[0].Options["presolve"] != "off" {
+		t.Fatalf("expected presolve=off but saw options %v", results[0].Options)
+	}
+}
+
+// TestSetStatsRecorder tests that a StatsRecorder registered with a model
+// receives one record per solve.  It uses the model from TestFullAPIMin.
+func TestSetStatsRecorder(t *testing.T) {
+	// Prepare the model.
+	model := NewRawModel()
+	defer model.Close()
+	checkErr(t, model.SetBoolOption("output_flag", false))
+	checkErr(t, model.SetStringOption("presolve", "off"))
+	checkErr(t, model.AddColumnBounds([]float64{0.0, 1.0},
+		[]float64{4.0, 1.0e30}))
+	checkErr(t, model.SetColumnCosts([]float64{1.0, 1.0}))
+	checkErr(t, model.AddCompSparseRows([]float64{-1.0e30, 5.0, 6.0},
+		[]int{0, 1, 3}, []int{1, 0, 1, 0, 1}, []float64{1.0, 1.0, 2.0, 3.0, 2.0},
+		[]float64{7.0, 15.0, 1.0e30}))
+
+	// Solve the model twice while recording statistics.
+	var buf bytes.Buffer
+	rec := NewStatsRecorder(&buf, StatsJSON)
+	checkErr(t, model.SetStatsRecorder(rec))
+	for i := 0; i < 2; i++ {
+		_, err := model.Solve()
+		checkErr(t, err)
+	}
+	checkErr(t, model.SetStatsRecorder(nil))
+	_, err := model.Solve()
+	checkErr(t, err)
+	checkErr(t, rec.Err())
+
+	// Check the records.
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 records but saw %d", len(lines))
+	}
+	for _, line := range lines {
+		var obj struct {
+			ModelHash string            `json:"model_hash"`
+			Columns   int               `json:"columns"`
+			Rows      int               `json:"rows"`
+			Options   map[string]string `json:"options"`
+			Status    string            `json:"status"`
+			Objective float64           `json:"objective"`
+		}
+		checkErr(t, json.Unmarshal([]byte(line), &obj))
+		if obj.ModelHash == "" || obj.Columns != 2 || obj.Rows != 3 ||
+			obj.Status != "Optimal" || obj.Objective != 2.75 || obj.Options["presolve"] != "off" {
+			t.Fatalf("unexpected record %s", line)
+		}
+	}
+}
+
+// TestCurrentSolution tests that CurrentSolution retrieves a solution that
+// was produced by a solve or read from a file.  It uses the model from
+// TestFullAPIMin.
+func TestCurrentSolution(t *testing.T) {
+	// Prepare and solve the model.
+	newModel := func() *RawModel {
+		model := NewRawModel()
+		checkErr(t, model.SetBoolOption("output_flag", false))
+		checkErr(t, model.SetOffset(3.0))
+		checkErr(t, model.AddColumnBounds([]float64{0.0, 1.0},
+			[]float64{4.0, 1.0e30}))
+		checkErr(t, model.SetColumnCosts([]float64{1.0, 1.0}))
+		checkErr(t, model.AddCompSparseRows([]float64{-1.0e30, 5.0, 6.0},
+			[]int{0, 1, 3}, []int{1, 0, 1, 0, 1}, []float64{1.0, 1.0, 2.0, 3.0, 2.0},
+			[]float64{7.0, 15.0, 1.0e30}))
+		return model
+	}
+	model := newModel()
+	defer model.Close()
+	soln, err := model.Solve()
+	checkErr(t, err)
+
+	// Retrieve the solution again.
+	cur, err := model.CurrentSolution()
+	checkErr(t, err)
+	if !reflect.DeepEqual(cur.Solution, soln.Solution) {
+		t.Fatalf("expected %+v but saw %+v", soln.Solution, cur.Solution)
+	}
+
+	// Read the solution into a fresh model, and retrieve it.
+	fn := filepath.Join(t.TempDir(), "soln.txt")
+	checkErr(t, soln.WriteSolutionToFile(fn, false))
+	model2 := newModel()
+	defer model2.Close()
+	checkErr(t, model2.ReadSolutionFromFile(fn))
+	cur, err = model2.CurrentSolution()
+	checkErr(t, err)
+	compSlices(t, "ColumnPrimal", cur.ColumnPrimal, []float64{0.5, 2.25})
+	if cur.Objective != 5.75 {
+		t.Fatalf("objective value was %.2f but should have been 5.75", cur.Objective)
+	}
+}
+
+// TestCheckpoint tests that a checkpoint restores a model, its options, and
+// its solution.  It uses the model from TestFullAPIMin.
+func TestCheckpoint(t *testing.T) {
+	// Prepare and solve the model.
+	model := NewRawModel()
+	defer model.Close()
+	checkErr(t, model.SetBoolOption("output_flag", false))
+	checkErr(t, model.SetStringOption("presolve", "off"))
+	checkErr(t, model.SetOffset(3.0))
+	checkErr(t, model.AddColumnBounds([]float64{0.0, 1.0},
+		[]float64{4.0, 1.0e30}))
+	checkErr(t, model.SetColumnCosts([]float64{1.0, 1.0}))
+	checkErr(t, model.AddCompSparseRows([]float64{-1.0e30, 5.0, 6.0},
+		[]int{0, 1, 3}, []int{1, 0, 1, 0, 1}, []float64{1.0, 1.0, 2.0, 3.0, 2.0},
+		[]float64{7.0, 15.0, 1.0e30}))
+	_, err := model.Solve()
+	checkErr(t, err)
+
+	// Checkpoint the model, and restore it into a fresh model.
+	fn := filepath.Join(t.TempDir(), "model.ckpt")
+	checkErr(t, model.WriteCheckpointToFile(fn))
+	model2 := NewRawModel()
+	defer model2.Close()
+	checkErr(t, model2.ReadCheckpointFromFile(fn))
+
+	// Confirm that the model, options, and solution were restored.
+	m1, err := model.ToModel()
+	checkErr(t, err)
+	m2, err := model2.ToModel()
+	checkErr(t, err)
+	m2.ColNames, m2.RowNames = nil, nil // Generated when writing MPS
+	diffs, err := DiffModels(m1, m2)
+	checkErr(t, err)
+	if len(diffs) > 0 {
+		t.Fatalf("restored model differs from the original: %v", diffs)
+	}
+	presolve, err := model2.GetStringOption("presolve")
+	checkErr(t, err)
+	if presolve != "off" {
+		t.Fatalf("expected presolve=off but saw presolve=%s", presolve)
+	}
+	cur, err := model2.CurrentSolution()
+	checkErr(t, err)
+	compSlices(t, "ColumnPrimal", cur.ColumnPrimal, []float64{0.5, 2.25})
+}
+
+// TestChangeCoeff tests that ChangeCoeff modifies, adds, and removes
+// individual entries of the following constraint matrix and that GetCoeff
+// reports the results:
+//
+//	[ 1 0 2 ]
+//	[ 0 3 4 ]
+func TestChangeCoeff(t *testing.T) {
+	// Prepare the model.
+	var model Model
+	model.AddDenseRow(0.0, []float64{1.0, 0.0, 2.0}, 10.0)
+	model.AddDenseRow(0.0, []float64{0.0, 3.0, 4.0}, 10.0)
+	raw, err := model.ToRawModel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer raw.Close()
+
+	// Modify an entry, add an entry, and remove an entry.
+	checkErr(t, raw.ChangeCoeff(0, 2, 5.0))
+	checkErr(t, raw.ChangeCoeff(0, 1, 6.0))
+	checkErr(t, raw.ChangeCoeff(1, 1, 0.0))
+	nzs, err := raw.RowEntries(0)
+	checkErr(t, err)
+	if exp := []Nonzero{{0, 0, 1.0}, {0, 1, 6.0}, {0, 2, 5.0}}; !reflect.DeepEqual(nzs, exp) {
+		t.Fatalf("row 0: expected %v but observed %v", exp, nzs)
+	}
+	nzs, err = raw.RowEntries(1)
+	checkErr(t, err)
+	if exp := []Nonzero{{1, 2, 4.0}}; !reflect.DeepEqual(nzs, exp) {
+		t.Fatalf("row 1: expected %v but observed %v", exp, nzs)
+	}
+
+	// Ensure that GetCoeff observes the changes.
+	for _, nz := range []Nonzero{{0, 1, 6.0}, {0, 2, 5.0}, {1, 1, 0.0}, {1, 2, 4.0}} {
+		v, err := raw.GetCoeff(nz.Row, nz.Col)
+		checkErr(t, err)
+		if v != nz.Val {
+			t.Fatalf("expected A(%d,%d) = %v but observed %v", nz.Row, nz.Col, nz.Val, v)
+		}
+	}
+
+	// Ensure that out-of-range indices are rejected.
+	if _, err = raw.GetCoeff(0, -1); err == nil {
+		t.Fatal("GetCoeff accepted an out-of-range column")
+	}
+	if err = raw.ChangeCoeff(2, 0, 1.0); err == nil {
+		t.Fatal("ChangeCoeff accepted an out-of-range row")
+	}
+	if err = raw.ChangeCoeff(0, 3, 1.0); err == nil {
+		t.Fatal("ChangeCoeff accepted an out-of-range column")
+	}
+}
+
+// TestChangeColBounds tests that ChangeColBounds, ChangeColsBoundsByRange,
+// and ChangeColsBoundsBySet replace only the bounds of the given columns.
+func TestChangeColBounds(t *testing.T) {
+	// Prepare the model.
+	model := NewRawModel()
+	defer model.Close()
+	checkErr(t, model.AddColumnBounds([]float64{0.0, 0.0, 0.0, 0.0}, []float64{1.0, 1.0, 1.0, 1.0}))
+
+	// Replace some of the bounds, then confirm that all bounds are as
+	// expected.
+	checkErr(t, model.ChangeColBounds(0, -1.0, 2.0))
+	checkErr(t, model.ChangeColsBoundsByRange(1, []float64{3.0, 4.0}, nil))
+	checkErr(t, model.ChangeColsBoundsBySet([]int{3, 1}, []float64{-5.0, -6.0}, []float64{5.0, 6.0}))
+	checkErr(t, model.ChangeColsBoundsByRange(2, nil, nil))
+	m, err := model.ToModel()
+	checkErr(t, err)
+	pInf := math.Inf(1)
+	compSlices(t, "ColLower", m.ColLower, []float64{-1.0, -6.0, 4.0, -5.0})
+	compSlices(t, "ColUpper", m.ColUpper, []float64{2.0, 6.0, pInf, 5.0})
+
+	// Ensure that invalid columns are rejected.
+	if err = model.ChangeColBounds(4, 0.0, 1.0); err == nil {
+		t.Fatal("ChangeColBounds accepted an out-of-range column")
+	}
+	if err = model.ChangeColsBoundsByRange(3, []float64{0.0, 0.0}, nil); err == nil {
+		t.Fatal("ChangeColsBoundsByRange accepted an out-of-range column")
+	}
+	if err = model.ChangeColsBoundsBySet([]int{1, 1}, []float64{0.0, 0.0}, nil); err == nil {
+		t.Fatal("ChangeColsBoundsBySet accepted a repeated column")
+	}
+	if err = model.ChangeColsBoundsBySet([]int{1, 2}, []float64{0.0}, nil); err == nil {
+		t.Fatal("ChangeColsBoundsBySet accepted too few bounds")
+	}
+}
+
+// TestChangeRowBounds tests that ChangeRowBounds, ChangeRowsBoundsByRange,
+// ChangeRowsBoundsBySet, and ChangeRowsBoundsByMask replace only the bounds
+// of the given rows.
+func TestChangeRowBounds(t *testing.T) {
+	// Prepare the model.
+	model := NewRawModel()
+	defer model.Close()
+	checkErr(t, model.AddColumnBounds([]float64{0.0}, []float64{1.0}))
+	for i := 0; i < 5; i++ {
+		checkErr(t, model.AddDenseRow(0.0, []float64{1.0}, 1.0))
+	}
+
+	// Replace some of the bounds, then confirm that all bounds are as
+	// expected.
+	checkErr(t, model.ChangeRowBounds(0, -1.0, 2.0))
+	checkErr(t, model.ChangeRowsBoundsByRange(1, []float64{3.0, 4.0}, nil))
+	checkErr(t, model.ChangeRowsBoundsBySet([]int{3, 1}, []float64{-5.0, -6.0}, []float64{5.0, 6.0}))
+	checkErr(t, model.ChangeRowsBoundsByMask([]bool{false, false, false, false, true},
+		[]float64{9.0, 9.0, 9.0, 9.0, 7.0}, []float64{9.0, 9.0, 9.0, 9.0, 8.0}))
+	m, err := model.ToModel()
+	checkErr(t, err)
+	pInf := math.Inf(1)
+	compSlices(t, "RowLower", m.RowLower, []float64{-1.0, -6.0, 4.0, -5.0, 7.0})
+	compSlices(t, "RowUpper", m.RowUpper, []float64{2.0, 6.0, pInf, 5.0, 8.0})
+
+	// Ensure that invalid rows are rejected.
+	if err = model.ChangeRowBounds(5, 0.0, 1.0); err == nil {
+		t.Fatal("ChangeRowBounds accepted an out-of-range row")
+	}
+	if err = model.ChangeRowsBoundsByRange(4, []float64{0.0, 0.0}, nil); err == nil {
+		t.Fatal("ChangeRowsBoundsByRange accepted an out-of-range row")
+	}
+	if err = model.ChangeRowsBoundsBySet([]int{-1}, []float64{0.0}, nil); err == nil {
+		t.Fatal("ChangeRowsBoundsBySet accepted an out-of-range row")
+	}
+	if err = model.ChangeRowsBoundsByMask([]bool{true}, []float64{0.0}, nil); err == nil {
+		t.Fatal("ChangeRowsBoundsByMask accepted a short mask")
+	}
+}
+
+// TestGetCols tests that GetColsByRange and GetColsBySet return the costs,
+// bounds, and nonzeros of selected columns of the following model:
+//
+//	Min    f  =  x_0 + 2x_1 + 3x_2
+//	s.t.   0 <=  x_0        + 2x_2 <= 10
+//	       0 <=        3x_1 + 4x_2 <= 10
+//	0 <= x_0 <= 1; 0 <= x_1 <= 2; 0 <= x_2 <= 3
+func TestGetCols(t *testing.T) {
+	// Prepare the model.
+	var model Model
+	model.ColCosts = []float64{1.0, 2.0, 3.0}
+	model.ColLower = []float64{0.0, 0.0, 0.0}
+	model.ColUpper = []float64{1.0, 2.0, 3.0}
+	model.AddDenseRow(0.0, []float64{1.0, 0.0, 2.0}, 10.0)
+	model.AddDenseRow(0.0, []float64{0.0, 3.0, 4.0}, 10.0)
+	raw, err := model.ToRawModel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer raw.Close()
+
+	// Retrieve a range of columns.
+	cd, err := raw.GetColsByRange(1, 3)
+	checkErr(t, err)
+	exp := ColumnData{
+		Costs:   []float64{2.0, 3.0},
+		Lower:   []float64{0.0, 0.0},
+		Upper:   []float64{2.0, 3.0},
+		Entries: []Nonzero{{1, 1, 3.0}, {0, 2, 2.0}, {1, 2, 4.0}},
+	}
+	if !reflect.DeepEqual(cd, exp) {
+		t.Fatalf("expected %v but observed %v", exp, cd)
+	}
+
+	// Retrieve a set of columns.
+	cd, err = raw.GetColsBySet([]int{2, 0})
+	checkErr(t, err)
+	exp = ColumnData{
+		Costs:   []float64{3.0, 1.0},
+		Lower:   []float64{0.0, 0.0},
+		Upper:   []float64{3.0, 1.0},
+		Entries: []Nonzero{{0, 0, 1.0}, {0, 2, 2.0}, {1, 2, 4.0}},
+	}
+	if !reflect.DeepEqual(cd, exp) {
+		t.Fatalf("expected %v but observed %v", exp, cd)
+	}
+
+	// Ensure that invalid columns are rejected.
+	if _, err = raw.GetColsByRange(2, 4); err == nil {
+		t.Fatal("GetColsByRange accepted an out-of-range column")
+	}
+	if _, err = raw.GetColsBySet([]int{0, 0}); err == nil {
+		t.Fatal("GetColsBySet accepted a repeated column")
+	}
+}
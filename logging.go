@@ -0,0 +1,81 @@
+//go:build cgo
+
+// This file provides support for redirecting HiGHS's log output to Go code.
+
+package highs
+
+import "strings"
+
+// A LogType indicates the severity of a HiGHS log message.
+type LogType int
+
+// These are the values a LogType accepts.  They match HiGHS's HighsLogType
+// values.
+const (
+	LogInfo LogType = iota + 1
+	LogDetailed
+	LogVerbose
+	LogWarning
+	LogError
+)
+
+// SetLogFunc arranges for fn to be invoked with each line HiGHS logs,
+// stripped of its trailing newline.  It replaces any function previously
+// registered with SetLogFunc.  A nil fn stops the redirection.  Log messages
+// are delivered only when the output_flag option is true; set log_to_console
+// to false to deliver them only to fn.
+//
+// fn is invoked from within Solve and other RawModel methods and must not
+// call methods on the same RawModel or its RawSolutions.
+func (m *RawModel) SetLogFunc(fn func(lt LogType, msg string)) error {
+	_, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+
+	// Remove any previous log function.
+	if m.logSet {
+		m.logSet = false
+		err = m.h.removeCallback(m.logID)
+		if err != nil {
+			return err
+		}
+	}
+	if fn == nil {
+		return nil
+	}
+
+	// Register the new log function.  The prefix is read on each call
+	// because SetLogPrefix may change it later.
+	m.logID, err = m.h.addLogCallback(func(logType int, msg string) {
+		for _, line := range strings.Split(strings.TrimRight(msg, "\n"), "\n") {
+			if line != "" {
+				fn(LogType(logType), m.logPrefix+line)
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+	m.logSet = true
+	return nil
+}
+
+// SetLogPrefix arranges for a prefix, such as a job ID, to be prepended to
+// each line passed to the function registered with SetLogFunc or the logger
+// registered with SetLogger.  This makes it possible to attribute the
+// interleaved log output of models being solved concurrently.  The prefix
+// can be set before or after the log function and persists until changed;
+// an empty prefix removes it.  Output that HiGHS writes directly to the
+// console is not prefixed, so set log_to_console to false and route output
+// through SetLogFunc to prefix every line.
+func (m *RawModel) SetLogPrefix(prefix string) error {
+	_, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+	m.logPrefix = prefix
+	return nil
+}
@@ -1,9 +1,14 @@
+//go:build cgo
+
 // This file tests the high package's high-level API with linear-programming
 // models.
 
 package highs
 
 import (
+	"context"
+	"errors"
+	"math"
 	"testing"
 )
 
@@ -197,3 +202,280 @@ func TestLPModelToRawModel(t *testing.T) {
 	compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{3.0, 2.0})
 	compSlices(t, "RowPrimal", soln.RowPrimal, []float64{1.0, 5.0})
 }
+
+// TestSoftRows tests that Solve honors soft rows.  It uses the model from
+// softModel, in which violating the first row costs less than satisfying it.
+func TestSoftRows(t *testing.T) {
+	soln, err := softModel().Solve()
+	checkErr(t, err)
+	if soln.Status != Optimal {
+		t.Fatalf("Solve returned %s instead of Optimal", soln.Status)
+	}
+	compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{0.0, 0.0})
+	compSlices(t, "RowPrimal", soln.RowPrimal, []float64{0.0, 0.0, 0.0})
+	compSlices(t, "RowViolations", soln.RowViolations, []float64{4.0, 0.0, 0.0})
+	if soln.Objective != 2.0 {
+		t.Fatalf("expected an objective value of 2 but saw %v", soln.Objective)
+	}
+}
+
+// TestNormObjectives tests that minimizing the L1 norm of the expressions
+// returned by deviations yields their median, x_0 = 2, and that minimizing
+// their L∞ norm yields the midpoint of their range, x_0 = 3.5.
+func TestNormObjectives(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		add    func(m *Model) (int, error)
+		x, obj float64
+	}{
+		{"L1", func(m *Model) (int, error) { return m.AddL1Objective(deviations(), 1.0) }, 2.0, 5.0},
+		{"LInf", func(m *Model) (int, error) { return m.AddLInfObjective(deviations(), 1.0) }, 3.5, 2.5},
+	} {
+		var m Model
+		m.ColCosts = []float64{0.0}
+		m.ColLower = []float64{math.Inf(-1)}
+		_, err := tc.add(&m)
+		checkErr(t, err)
+		soln, err := m.Solve()
+		checkErr(t, err)
+		if soln.Status != Optimal {
+			t.Fatalf("%s: Solve returned %s instead of Optimal", tc.name, soln.Status)
+		}
+		if soln.ColumnPrimal[0] != tc.x || soln.Objective != tc.obj {
+			t.Fatalf("%s: expected x_0 = %v and objective %v but saw %v and %v",
+				tc.name, tc.x, tc.obj, soln.ColumnPrimal[0], soln.Objective)
+		}
+	}
+}
+
+// TestRollingHorizon solves the production-planning problem described by
+// inventoryModel with a two-period window, committing one period at a time.
+// Each window produces at capacity in cheap periods and carries the surplus
+// into the following, expensive period.
+func TestRollingHorizon(t *testing.T) {
+	rh, err := NewRollingHorizon(inventoryModel, 2, 2)
+	checkErr(t, err)
+	rh.History = 1
+	for i := 0; i < 4; i++ {
+		_, err = rh.Step()
+		checkErr(t, err)
+	}
+	if rh.Next() != 4 {
+		t.Fatalf("expected 4 committed periods but saw %d", rh.Next())
+	}
+	exp := [][]float64{{5.0, 1.0}, {3.0, 0.0}, {5.0, 1.0}, {3.0, 0.0}}
+	for p, vals := range rh.Committed() {
+		compSlices(t, "Committed", vals, exp[p])
+	}
+}
+
+// TestIsolatedSolver solves the model from TestImplicitColumnBounds in a
+// worker process, kills the worker to simulate a crash, and confirms that a
+// new worker is started for the next solve.
+func TestIsolatedSolver(t *testing.T) {
+	// Prepare the model.
+	var model Model
+	model.AddDenseRow(23.0, []float64{1.0, 1.0}, 23.0)
+	model.AddDenseRow(17.0, []float64{1.0, -1.0}, 17.0)
+
+	// Solve the model twice, killing the worker in between.
+	s := NewIsolatedSolver()
+	defer s.Close()
+	for i := 0; i < 2; i++ {
+		soln, err := s.Solve(context.Background(), &model,
+			map[string]any{"presolve": "off", "threads": 1})
+		checkErr(t, err)
+		if soln.Status != Optimal {
+			t.Fatalf("Solve returned %s instead of Optimal", soln.Status)
+		}
+		compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{20.0, 3.0})
+		checkErr(t, s.cmd.Process.Kill())
+		<-s.done
+	}
+
+	// Ensure that errors are passed back from the worker.
+	_, err := s.Solve(context.Background(), &model, map[string]any{"no_such_option": 1})
+	var cs CallStatus
+	if !errors.As(err, &cs) || cs.CName != "Highs_setIntOptionValue" {
+		t.Fatalf("expected a Highs_setIntOptionValue error but saw %v", err)
+	}
+}
+
+// TestSampleOptima tests that SampleOptima finds both vertices of the
+// optimal face of the model returned by segmentModel.
+func TestSampleOptima(t *testing.T) {
+	m := segmentModel()
+	soln, err := m.Solve()
+	checkErr(t, err)
+	if cols, rows := soln.DualDegenerate(1e-9); len(cols)+len(rows) == 0 {
+		t.Fatal("expected the solution to be dual degenerate")
+	}
+	solns, err := m.SampleOptima(soln.Objective, 10, nil)
+	checkErr(t, err)
+	if len(solns) != 2 {
+		t.Fatalf("expected 2 optimal vertices but saw %d", len(solns))
+	}
+	for _, s := range solns {
+		x := s.ColumnPrimal
+		if !(x[0] == 0.0 && x[1] == 2.0 || x[0] == 2.0 && x[1] == 0.0) || s.Objective != 2.0 {
+			t.Fatalf("unexpected solution %v with objective %v", x, s.Objective)
+		}
+	}
+}
+
+// TestSetBasis hot-starts the model from TestMinimalAPIMin from its optimal
+// basis and confirms that the simplex solver then needs no iterations.
+func TestSetBasis(t *testing.T) {
+	// Prepare the model.
+	var model Model
+	model.Offset = 3.0
+	model.ColCosts = []float64{1.0, 1.0}
+	model.ColLower = []float64{0.0, 1.0}
+	model.ColUpper = []float64{4.0, 1.0e30}
+	model.RowLower = []float64{-1.0e30, 5.0, 6.0}
+	model.RowUpper = []float64{7.0, 15.0, 1.0e30}
+	model.ConstMatrix = []Nonzero{
+		{0, 1, 1.0},
+		{1, 0, 1.0},
+		{1, 1, 2.0},
+		{2, 0, 3.0},
+		{2, 1, 2.0},
+	}
+	raw, err := model.ToRawModel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer raw.Close()
+	checkErr(t, raw.SetBoolOption("output_flag", false))
+	checkErr(t, raw.SetStringOption("presolve", "off"))
+
+	// Reject malformed bases.
+	colBasis := []BasisStatus{Basic, Basic}
+	rowBasis := []BasisStatus{Basic, Lower, Lower}
+	if raw.SetBasis(colBasis[:1], rowBasis) == nil {
+		t.Fatal("SetBasis accepted too few column statuses")
+	}
+	if raw.SetBasis(colBasis, []BasisStatus{Basic, Lower, UnknownBasisStatus}) == nil {
+		t.Fatal("SetBasis accepted UnknownBasisStatus")
+	}
+
+	// Solve from the optimal basis.
+	checkErr(t, raw.SetBasis(colBasis, rowBasis))
+	soln, err := raw.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{0.5, 2.25})
+	iters, err := soln.GetIntInfo("simplex_iteration_count")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if iters != 0 {
+		t.Fatalf("expected 0 simplex iterations but saw %d", iters)
+	}
+
+	// Solve from the logical basis.
+	checkErr(t, raw.SetLogicalBasis())
+	soln, err = raw.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{0.5, 2.25})
+}
+
+// TestRanging performs sensitivity analysis of the model from
+// TestMinimalAPIMin.  At the optimum, rows 1 and 2 are active, so the basis
+// remains optimal as long as (c_0, 1) is a nonnegative combination of (1, 2)
+// and (3, 2), that is, for 0.5 <= c_0 <= 1.5.
+func TestRanging(t *testing.T) {
+	// Prepare the model.
+	var model Model
+	model.Offset = 3.0
+	model.ColCosts = []float64{1.0, 1.0}
+	model.ColLower = []float64{0.0, 1.0}
+	model.ColUpper = []float64{4.0, 1.0e30}
+	model.RowLower = []float64{-1.0e30, 5.0, 6.0}
+	model.RowUpper = []float64{7.0, 15.0, 1.0e30}
+	model.ConstMatrix = []Nonzero{
+		{0, 1, 1.0},
+		{1, 0, 1.0},
+		{1, 1, 2.0},
+		{2, 0, 3.0},
+		{2, 1, 2.0},
+	}
+	raw, err := model.ToRawModel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer raw.Close()
+	checkErr(t, raw.SetBoolOption("output_flag", false))
+
+	// Solve the model and analyze its solution.
+	if _, err = raw.Solve(); err != nil {
+		t.Fatal(err)
+	}
+	rng, err := raw.Ranging()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rng.ColCostUp) != 2 || len(rng.RowBoundDown) != 3 {
+		t.Fatalf("expected 2 column and 3 row records but saw %d and %d",
+			len(rng.ColCostUp), len(rng.RowBoundDown))
+	}
+	compSlices(t, "c_0 range", roundFloats(1e-6,
+		[]float64{rng.ColCostDown[0].Value, rng.ColCostUp[0].Value}),
+		[]float64{0.5, 1.5})
+}
+
+// TestCrossover solves the model from TestMinimalAPIMin with the
+// interior-point solver, crosses the result over to a basic solution, and
+// confirms that the basis is the optimal one.
+func TestCrossover(t *testing.T) {
+	// Prepare the model.
+	var model Model
+	model.Offset = 3.0
+	model.ColCosts = []float64{1.0, 1.0}
+	model.ColLower = []float64{0.0, 1.0}
+	model.ColUpper = []float64{4.0, 1.0e30}
+	model.RowLower = []float64{-1.0e30, 5.0, 6.0}
+	model.RowUpper = []float64{7.0, 15.0, 1.0e30}
+	model.ConstMatrix = []Nonzero{
+		{0, 1, 1.0},
+		{1, 0, 1.0},
+		{1, 1, 2.0},
+		{2, 0, 3.0},
+		{2, 1, 2.0},
+	}
+	raw, err := model.ToRawModel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer raw.Close()
+	checkErr(t, raw.SetBoolOption("output_flag", false))
+	checkErr(t, raw.SetStringOption("solver", "ipm"))
+	checkErr(t, raw.SetStringOption("run_crossover", "off"))
+
+	// Solve the model without crossover.
+	ipm, err := raw.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Reject a point of the wrong size.
+	if _, err = raw.Crossover(StartingPoint{ColumnPrimal: []float64{0.5}}); err == nil {
+		t.Fatal("Crossover accepted too few primal column values")
+	}
+
+	// Cross over to a basic solution.
+	soln, err := raw.Crossover(StartingPoint{
+		ColumnPrimal: ipm.ColumnPrimal,
+		ColumnDual:   ipm.ColumnDual,
+		RowDual:      ipm.RowDual,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	compSlices(t, "ColumnPrimal", roundFloats(1e-6, soln.ColumnPrimal), []float64{0.5, 2.25})
+	compSlices(t, "ColumnBasis", soln.ColumnBasis, []BasisStatus{Basic, Basic})
+	compSlices(t, "RowBasis", soln.RowBasis, []BasisStatus{Basic, Lower, Lower})
+}
@@ -1,9 +1,14 @@
+//go:build cgo
+
 // This file tests the high package's high-level API with mixed-integer
 // programming models.
 
 package highs
 
-import "testing"
+import (
+	"math"
+	"testing"
+)
 
 // TestMinimalAPIMaxMIP mimics the third test in HiGHS's minimal_api function
 // from examples/call_highs_from_c.c:
@@ -83,3 +88,306 @@ func TestMIPModelToRawModel(t *testing.T) {
 	compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{3.0, 2.0})
 	compSlices(t, "RowPrimal", soln.RowPrimal, []float64{1.0, 5.0})
 }
+
+// TestCompleteStart tests that a partial start is completed with the
+// in-bounds value closest to zero for each unassigned column.
+func TestCompleteStart(t *testing.T) {
+	lb := []float64{-1.0e30, 2.0, -5.0, -1.0e30}
+	ub := []float64{1.0e30, 10.0, -1.0, 1.0e30}
+	start, err := completeStart(lb, ub, map[int]float64{3: 7.0})
+	checkErr(t, err)
+	compSlices(t, "start", start, []float64{0.0, 2.0, -1.0, 7.0})
+
+	_, err = completeStart(lb, ub, map[int]float64{4: 1.0})
+	if err == nil {
+		t.Fatal("completeStart accepted an out-of-range column")
+	}
+}
+
+// TestPartialMIPStart provides a start for only one column of the model from
+// TestMinimalAPIMaxMIP and confirms that the model still solves to
+// optimality.
+func TestPartialMIPStart(t *testing.T) {
+	// Prepare the model.
+	var model Model
+	model.Maximize = true
+	model.Offset = 3.0
+	model.ColCosts = []float64{1.0, 1.0}
+	model.ColLower = []float64{0.0, 1.0}
+	model.ColUpper = []float64{4.0, 1.0e30}
+	model.RowLower = []float64{-1.0e30, 5.0, 6.0}
+	model.RowUpper = []float64{7.0, 15.0, 1.0e30}
+	model.ConstMatrix = []Nonzero{
+		{0, 1, 1.0},
+		{1, 0, 1.0},
+		{1, 1, 2.0},
+		{2, 0, 3.0},
+		{2, 1, 2.0},
+	}
+	model.VarTypes = []VariableType{IntegerType, IntegerType}
+	raw, err := model.ToRawModel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkErr(t, raw.SetBoolOption("output_flag", false))
+
+	// Specify a start for x_0 only, and reject an invalid column.
+	checkErr(t, raw.SetPartialMIPStart(map[int]float64{0: 4.0}))
+	if raw.SetPartialMIPStart(map[int]float64{2: 1.0}) == nil {
+		t.Fatal("SetPartialMIPStart accepted an out-of-range column")
+	}
+	if raw.SetPartialMIPStartByName(map[string]float64{"no such column": 1.0}) == nil {
+		t.Fatal("SetPartialMIPStartByName accepted an unknown column name")
+	}
+
+	// Solve the model.
+	soln, err := raw.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if soln.Status != Optimal {
+		t.Fatalf("Solve returned %s instead of Optimal", soln.Status)
+	}
+	compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{4.0, 5.0})
+}
+
+// TestSparseMIPStart provides a sparse start for only one column of the model
+// from TestMinimalAPIMaxMIP and confirms that the model still solves to
+// optimality.
+func TestSparseMIPStart(t *testing.T) {
+	// Prepare the model.
+	var model Model
+	model.Maximize = true
+	model.Offset = 3.0
+	model.ColCosts = []float64{1.0, 1.0}
+	model.ColLower = []float64{0.0, 1.0}
+	model.ColUpper = []float64{4.0, 1.0e30}
+	model.RowLower = []float64{-1.0e30, 5.0, 6.0}
+	model.RowUpper = []float64{7.0, 15.0, 1.0e30}
+	model.ConstMatrix = []Nonzero{
+		{0, 1, 1.0},
+		{1, 0, 1.0},
+		{1, 1, 2.0},
+		{2, 0, 3.0},
+		{2, 1, 2.0},
+	}
+	model.VarTypes = []VariableType{IntegerType, IntegerType}
+	raw, err := model.ToRawModel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkErr(t, raw.SetBoolOption("output_flag", false))
+
+	// Specify a start for x_0 only, and reject an invalid column.
+	checkErr(t, raw.SetSparseMIPStart(map[int]float64{0: 4.0}))
+	if raw.SetSparseMIPStart(map[int]float64{2: 1.0}) == nil {
+		t.Fatal("SetSparseMIPStart accepted an out-of-range column")
+	}
+
+	// Solve the model.
+	soln, err := raw.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if soln.Status != Optimal {
+		t.Fatalf("Solve returned %s instead of Optimal", soln.Status)
+	}
+	compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{4.0, 5.0})
+}
+
+// TestSemiContinuous solves the following model, in which x_0 is
+// semi-continuous:
+//
+//	Min    f  =  x_0 + 2x_1
+//	s.t.   1 <=  x_0 +  x_1
+//	x_0 = 0 or 3 <= x_0 <= 5; 0 <= x_1 <= 10
+//
+// If x_0 were merely continuous, the optimum would be x_0 = 1, x_1 = 0.
+func TestSemiContinuous(t *testing.T) {
+	// Prepare the model.
+	var model Model
+	model.ColCosts = []float64{1.0, 2.0}
+	model.AddDenseRow(1.0, []float64{1.0, 1.0}, 1.0e30)
+	model.SetSemiContinuous(0, 3.0, 5.0)
+	if len(model.ColLower) != 2 || len(model.ColUpper) != 2 || len(model.VarTypes) != 2 {
+		t.Fatal("SetSemiContinuous did not pad the column data to every column")
+	}
+	model.ColLower[1] = 0.0
+	model.ColUpper[1] = 10.0
+	checkErr(t, model.Validate())
+
+	// Solve the model.
+	soln, err := model.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if soln.Status != Optimal {
+		t.Fatalf("Solve returned %s instead of Optimal", soln.Status)
+	}
+	compSlices(t, "ColumnPrimal", roundFloats(1e-6, soln.ColumnPrimal), []float64{0.0, 1.0})
+
+	// Require x_0 + x_1 >= 4, which makes x_0 = 4 optimal.  Declare x_0
+	// semi-integer for good measure.
+	model.RowLower[0] = 4.0
+	model.SetSemiInteger(0, 3.0, 5.0)
+	soln, err = model.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	compSlices(t, "ColumnPrimal", roundFloats(1e-6, soln.ColumnPrimal), []float64{4.0, 0.0})
+}
+
+// TestSemiVariableBounds tests that semi-continuous and semi-integer columns
+// with invalid bounds are rejected.
+func TestSemiVariableBounds(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		lb, ub float64
+	}{
+		{"negative lower bound", -1.0, 5.0},
+		{"infinite upper bound", 1.0, math.Inf(1)},
+		{"crossed bounds", 5.0, 1.0},
+		{"NaN lower bound", math.NaN(), 5.0},
+	} {
+		var model Model
+		model.ColCosts = []float64{1.0}
+		model.SetSemiContinuous(0, tc.lb, tc.ub)
+		if err := model.Validate(); err == nil {
+			t.Fatalf("Validate failed to detect a %s", tc.name)
+		}
+		if _, err := model.ToRawModel(); err == nil {
+			t.Fatalf("ToRawModel failed to detect a %s", tc.name)
+		}
+	}
+
+	// Semi-variables must have explicit bounds.
+	model := Model{
+		ColCosts: []float64{1.0, 1.0},
+		VarTypes: []VariableType{ContinuousType, SemiIntegerType},
+	}
+	if err := model.Validate(); err == nil {
+		t.Fatal("Validate failed to detect a semi-integer column without bounds")
+	}
+}
+
+// TestRoundAndFix solves the LP relaxation of the model from
+// TestMinimalAPIMaxMIP, whose optimum is x_0 = 4, x_1 = 5.5, and rounds the
+// result.  Rounding x_1 down yields the MIP optimum, while rounding x_1 to the
+// nearest integer yields an infeasible model.
+func TestRoundAndFix(t *testing.T) {
+	// Prepare the model.
+	var model Model
+	model.Maximize = true
+	model.Offset = 3.0
+	model.ColCosts = []float64{1.0, 1.0}
+	model.ColLower = []float64{0.0, 1.0}
+	model.ColUpper = []float64{4.0, 1.0e30}
+	model.RowLower = []float64{-1.0e30, 5.0, 6.0}
+	model.RowUpper = []float64{7.0, 15.0, 1.0e30}
+	model.ConstMatrix = []Nonzero{
+		{0, 1, 1.0},
+		{1, 0, 1.0},
+		{1, 1, 2.0},
+		{2, 0, 3.0},
+		{2, 1, 2.0},
+	}
+
+	// Solve the LP relaxation.
+	relax, err := model.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	compSlices(t, "ColumnPrimal", roundFloats(1e-6, relax.ColumnPrimal), []float64{4.0, 5.5})
+
+	// Round down, and confirm that the MIP optimum is found.
+	model.VarTypes = []VariableType{IntegerType, IntegerType}
+	soln, err := model.RoundAndFix(relax, RoundDown)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if soln.Status != Optimal {
+		t.Fatalf("RoundAndFix returned %s instead of Optimal", soln.Status)
+	}
+	compSlices(t, "ColumnPrimal", roundFloats(1e-6, soln.ColumnPrimal), []float64{4.0, 5.0})
+	if model.VarTypes[1] != IntegerType || model.ColLower[1] != 1.0 {
+		t.Fatal("RoundAndFix modified its model")
+	}
+
+	// Round to nearest, and confirm that the result is infeasible.
+	soln, err = model.RoundAndFix(relax, RoundNearest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if soln.Status == Optimal {
+		t.Fatal("RoundAndFix unexpectedly found a feasible solution with x_1 = 6")
+	}
+}
+
+// TestSolveWithMIPDuals solves the following MIP and confirms that the duals
+// of the fixed LP are reported:
+//
+//	Max    f  =  2x_0 + x_1
+//	s.t.          x_0 + x_1 <= 4.5
+//	0 <= x_0 <= 3.5; 0 <= x_1; x_0 integer
+func TestSolveWithMIPDuals(t *testing.T) {
+	// Prepare the model.
+	var model Model
+	model.Maximize = true
+	model.ColCosts = []float64{2.0, 1.0}
+	model.ColLower = []float64{0.0, 0.0}
+	model.ColUpper = []float64{3.5, 1.0e30}
+	model.AddDenseRow(-1.0e30, []float64{1.0, 1.0}, 4.5)
+	model.VarTypes = []VariableType{IntegerType, ContinuousType}
+
+	// Solve the model.
+	soln, err := model.SolveWithMIPDuals()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if soln.Status != Optimal {
+		t.Fatalf("SolveWithMIPDuals returned %s instead of Optimal", soln.Status)
+	}
+
+	// Confirm that each field is as expected.
+	compSlices(t, "ColumnPrimal", roundFloats(1e-6, soln.ColumnPrimal), []float64{3.0, 1.5})
+	compSlices(t, "ColumnDual", roundFloats(1e-6, soln.ColumnDual), []float64{1.0, 0.0})
+	compSlices(t, "RowDual", roundFloats(1e-6, soln.RowDual), []float64{1.0})
+	if soln.Objective != 7.5 {
+		t.Fatalf("objective value was %.2f but should have been 7.5", soln.Objective)
+	}
+}
+
+// TestSolveWithMIPDualsWarning confirms that SolveWithMIPDuals returns a
+// solution along with a warning when HiGHS ignores a tiny matrix value:
+//
+//	Max    f  =  2x_0 + x_1
+//	s.t.          x_0 + x_1 + 1e-12 x_2 <= 4.5
+//	0 <= x_0 <= 3.5; 0 <= x_1; 0 <= x_2 <= 1; x_0 integer
+func TestSolveWithMIPDualsWarning(t *testing.T) {
+	// Prepare the model.
+	var model Model
+	model.Maximize = true
+	model.ColCosts = []float64{2.0, 1.0, 0.0}
+	model.ColLower = []float64{0.0, 0.0, 0.0}
+	model.ColUpper = []float64{3.5, 1.0e30, 1.0}
+	model.AddDenseRow(-1.0e30, []float64{1.0, 1.0, 1.0e-12}, 4.5)
+	model.VarTypes = []VariableType{IntegerType, ContinuousType, ContinuousType}
+
+	// Solve the model, expecting a warning rather than a failure.
+	soln, err := model.SolveWithMIPDuals()
+	if err == nil {
+		t.Fatal("SolveWithMIPDuals did not report the ignored matrix value")
+	}
+	if !isWarning(err) {
+		t.Fatal(err)
+	}
+	if soln.Status != Optimal {
+		t.Fatalf("SolveWithMIPDuals returned %s instead of Optimal", soln.Status)
+	}
+
+	// Confirm that the solution is usable.
+	compSlices(t, "RowDual", roundFloats(1e-6, soln.RowDual), []float64{1.0})
+	if soln.Objective != 7.5 {
+		t.Fatalf("objective value was %.2f but should have been 7.5", soln.Objective)
+	}
+}
@@ -0,0 +1,138 @@
+// This file provides support for MIP starts that assign values to only some
+// of a model's columns.
+
+package highs
+
+import (
+	"fmt"
+	"sort"
+	"unsafe"
+)
+
+// #include <stdlib.h>
+// #include "highs-externs.h"
+import "C"
+
+// columnBounds returns the lower and upper bounds of every column in a HiGHS
+// object.  The caller must hold the model's lock.
+func columnBounds(obj unsafe.Pointer) (lb, ub []float64, err error) {
+	nc := int(C.Highs_getNumCol(obj))
+	if nc == 0 {
+		return nil, nil, nil
+	}
+	var numCol, numNz C.HighsInt
+	cost := make([]C.double, nc)
+	lower := make([]C.double, nc)
+	upper := make([]C.double, nc)
+	status := C.Highs_getColsByRange(obj, 0, C.HighsInt(nc-1),
+		&numCol, &cost[0], &lower[0], &upper[0],
+		&numNz, nil, nil, nil)
+	err = newCallStatus(status, "Highs_getColsByRange", "columnBounds")
+	if err != nil {
+		return nil, nil, err
+	}
+	lb = convertSlice[float64, C.double](lower)
+	ub = convertSlice[float64, C.double](upper)
+	return lb, ub, nil
+}
+
+// setPartialStart completes a partial assignment of column values and passes
+// the result to HiGHS as a starting solution.  The caller must hold the
+// model's lock.
+func setPartialStart(obj unsafe.Pointer, values map[int]float64, goName string) error {
+	lb, ub, err := columnBounds(obj)
+	if err != nil {
+		return err
+	}
+	start, err := completeStart(lb, ub, values)
+	if err != nil {
+		return err
+	}
+	if len(start) == 0 {
+		return nil
+	}
+	colValue := convertSlice[C.double, float64](start)
+	status := C.Highs_setSolution(obj, &colValue[0], nil, nil, nil)
+	return newCallStatus(status, "Highs_setSolution", goName,
+		TraceAttr{"col_value", len(colValue)})
+}
+
+// SetPartialMIPStart provides HiGHS with a starting solution for a MIP that
+// assigns values to only a subset of the columns, given as a map from column
+// index to value.  Each column not mentioned in the map is assigned the value
+// closest to zero that lies within its bounds.  Because HiGHS fixes the
+// integer columns of a start and solves for the remaining columns, it is
+// generally sufficient to specify only the integer columns.
+func (m *RawModel) SetPartialMIPStart(values map[int]float64) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+	return setPartialStart(obj, values, "SetPartialMIPStart")
+}
+
+// SetPartialMIPStartByName is like SetPartialMIPStart but identifies columns
+// by name rather than by index.  It returns an error if any name does not
+// correspond to a column in the model.
+func (m *RawModel) SetPartialMIPStartByName(values map[string]float64) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+
+	// Map each column name to a column index.
+	byIndex := make(map[int]float64, len(values))
+	for name, v := range values {
+		cName := C.CString(name)
+		var col C.HighsInt
+		status := C.Highs_getColByName(obj, cName, &col)
+		C.free(unsafe.Pointer(cName))
+		if newCallStatus(status, "Highs_getColByName", "SetPartialMIPStartByName") != nil {
+			return fmt.Errorf("no column is named %q", name)
+		}
+		byIndex[int(col)] = v
+	}
+	return setPartialStart(obj, byIndex, "SetPartialMIPStartByName")
+}
+
+// SetSparseMIPStart provides HiGHS with a starting solution for a MIP that
+// assigns values to only a subset of the columns, given as a map from column
+// index to value.  Unlike SetPartialMIPStart, which assigns a value to every
+// column, SetSparseMIPStart passes only the given values to HiGHS, which
+// solves for the remaining columns itself.  It is therefore the better choice
+// for a model with many columns but few meaningful hint values.
+func (m *RawModel) SetSparseMIPStart(values map[int]float64) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+
+	// Sort the columns so HiGHS sees them in a deterministic order.
+	nc := int(C.Highs_getNumCol(obj))
+	cols := make([]int, 0, len(values))
+	for c := range values {
+		if c < 0 || c >= nc {
+			return fmt.Errorf("column %d is out of range [0, %d)", c, nc)
+		}
+		cols = append(cols, c)
+	}
+	if len(cols) == 0 {
+		return nil
+	}
+	sort.Ints(cols)
+
+	// Pass the values to HiGHS.
+	index := make([]C.HighsInt, len(cols))
+	value := make([]C.double, len(cols))
+	for i, c := range cols {
+		index[i] = C.HighsInt(c)
+		value[i] = C.double(values[c])
+	}
+	status := C.Highs_setSparseSolution(obj, C.HighsInt(len(index)),
+		&index[0], &value[0])
+	return newCallStatus(status, "Highs_setSparseSolution", "SetSparseMIPStart",
+		TraceAttr{"num_entries", len(index)})
+}
@@ -0,0 +1,328 @@
+// This file provides the Model methods that require cgo.
+
+package highs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"unsafe"
+)
+
+// #include <stdlib.h>
+// #include "highs-externs.h"
+import "C"
+
+// RawModelOptions control how ToRawModelWithOptions constructs a RawModel.
+type RawModelOptions struct {
+	Verbose       bool // true=let HiGHS log messages while the model is being passed to it
+	Validate      bool // true=return Model.Validate's error, if any, instead of converting the model
+	Deterministic bool // true=configure the RawModel as by SetDeterministic(true)
+}
+
+// ToRawModel converts a high-level model to a low-level model.  It is
+// equivalent to ToRawModelWithOptions with Verbose set to the model's Verbose
+// field and Validate set to the model's Strict field.  In either case, the
+// returned RawModel has HiGHS's output enabled.  ToRawModel returns
+// ErrSoftRows if the model has soft rows; convert such a model with
+// SoftenedModel first.  As with RawModel.Solve, a warning from HiGHS, such as
+// one reporting that tiny matrix values were ignored, is returned along with
+// a usable RawModel.
+func (m *Model) ToRawModel() (*RawModel, error) {
+	return m.ToRawModelWithOptions(RawModelOptions{Verbose: m.Verbose, Validate: m.Strict})
+}
+
+// ToRawModelWithOptions converts a high-level model to a low-level model.
+// Unless opts.Verbose is true, HiGHS's output is disabled while the model is
+// passed to HiGHS, which otherwise logs a message describing the model.
+func (m *Model) ToRawModelWithOptions(opts RawModelOptions) (*RawModel, error) {
+	var attrs []TraceAttr
+	if tracing() {
+		nr, nc := m.modelSize()
+		attrs = []TraceAttr{
+			{"highs.num_cols", nc},
+			{"highs.num_rows", nr},
+			{"highs.num_nonzeros", len(m.ConstMatrix)},
+		}
+	}
+	end := startTrace(context.Background(), "ToRawModel", attrs...)
+	raw, err := m.toRawModel(opts)
+	end(err)
+	return raw, err
+}
+
+// toRawModel implements ToRawModelWithOptions without tracing.
+func (m *Model) toRawModel(opts RawModelOptions) (*RawModel, error) {
+	// Reject invalid numerical data if requested.
+	if opts.Validate {
+		if err := m.Validate(); err != nil {
+			return &RawModel{}, err
+		}
+	}
+
+	// Reject semi-variables whose bounds HiGHS would misinterpret and
+	// soft rows, which have no HiGHS equivalent.
+	if err := m.checkSemiVariables(); err != nil {
+		return &RawModel{}, err
+	}
+	if m.hasSoftRows() {
+		return &RawModel{}, ErrSoftRows
+	}
+
+	// Construct an empty raw model.  Unless the caller requested verbose
+	// output, turn off output, which is out of place in a method like
+	// ToRawModel.
+	raw := NewRawModel()
+	outFlag, err := raw.GetBoolOption("output_flag") // Presumably "true"
+	if err != nil {
+		return &RawModel{}, err
+	}
+	err = raw.SetBoolOption("output_flag", opts.Verbose && outFlag)
+	if err != nil {
+		return &RawModel{}, err
+	}
+	if opts.Deterministic {
+		err = raw.SetDeterministic(true)
+		if err != nil {
+			return &RawModel{}, err
+		}
+	}
+
+	// Convert ConstMatrix and HessianMatrix to CSR format.
+	aStart, aIndex, aValue, err := nonzerosToCSR(m.ConstMatrix, false)
+	if err != nil {
+		return &RawModel{}, err
+	}
+	qStart, qIndex, qValue, err := nonzerosToCSR(m.HessianMatrix, true)
+	if err != nil {
+		return &RawModel{}, err
+	}
+
+	// Convert Go values to C values.  Ensure that empty trailing rows
+	// and columns are represented in the CSR starts.
+	nr, nc := m.modelSize()
+	aStart = padStarts(aStart, nr, C.HighsInt(len(aValue)))
+	if len(qValue) > 0 {
+		qStart = padStarts(qStart, nc, C.HighsInt(len(qValue)))
+	}
+	numCol := C.HighsInt(nc)
+	numRow := C.HighsInt(nr)
+	numNZ := C.HighsInt(len(aValue))
+	qNumNZ := C.HighsInt(len(qValue))
+	aFormat := C.kHighsMatrixFormatRowwise
+	qFormat := C.kHighsHessianFormatTriangular
+	sense := C.kHighsObjSenseMinimize
+	if m.Maximize {
+		sense = C.kHighsObjSenseMaximize
+	}
+	offset := C.double(m.Offset)
+	colCost := convertSlice[C.double, float64](m.ColCosts)
+	colLower := convertSlice[C.double, float64](m.ColLower)
+	colUpper := convertSlice[C.double, float64](m.ColUpper)
+	rowLower := convertSlice[C.double, float64](m.RowLower)
+	rowUpper := convertSlice[C.double, float64](m.RowUpper)
+	integrality := make([]C.HighsInt, len(m.VarTypes))
+	for i, vt := range m.VarTypes {
+		integrality[i] = variableTypeToHighs[vt]
+	}
+
+	// Ensure that all slices have consistent lengths.
+	var ok bool
+	if colCost, ok = expandToLen(nc, colCost, 1.0); !ok {
+		return &RawModel{}, fmt.Errorf("inconsistent column counts")
+	}
+	mInf, pInf := C.double(math.Inf(-1)), C.double(math.Inf(1))
+	if colLower, ok = expandToLen(nc, colLower, mInf); !ok {
+		return &RawModel{}, fmt.Errorf("inconsistent column counts")
+	}
+	if colUpper, ok = expandToLen(nc, colUpper, pInf); !ok {
+		return &RawModel{}, fmt.Errorf("inconsistent column counts")
+	}
+	if rowLower, ok = expandToLen(nr, rowLower, mInf); !ok {
+		return &RawModel{}, fmt.Errorf("inconsistent row counts")
+	}
+	if rowUpper, ok = expandToLen(nr, rowUpper, pInf); !ok {
+		return &RawModel{}, fmt.Errorf("inconsistent row counts")
+	}
+	if integrality, ok = expandToLen(nc, integrality, C.kHighsVarTypeContinuous); !ok {
+		return &RawModel{}, fmt.Errorf("inconsistent column counts")
+	}
+
+	// Construct a low-level model.
+	obj, err := raw.lock()
+	if err != nil {
+		return &RawModel{}, err
+	}
+	status := C.Highs_passModel(obj, numCol, numRow,
+		numNZ, qNumNZ,
+		aFormat, qFormat, sense,
+		offset, sliceToPointer(colCost),
+		sliceToPointer(colLower), sliceToPointer(colUpper),
+		sliceToPointer(rowLower), sliceToPointer(rowUpper),
+		sliceToPointer(aStart), sliceToPointer(aIndex), sliceToPointer(aValue),
+		sliceToPointer(qStart), sliceToPointer(qIndex), sliceToPointer(qValue),
+		sliceToPointer(integrality))
+	raw.unlock()
+	passErr := newCallStatus(status, "Highs_passModel", "ToRawModel",
+		TraceAttr{"num_col", int(numCol)}, TraceAttr{"num_row", int(numRow)},
+		TraceAttr{"num_nz", int(numNZ)}, TraceAttr{"q_num_nz", int(qNumNZ)},
+		TraceAttr{"integrality", len(m.VarTypes) > 0})
+	if passErr != nil && !isWarning(passErr) {
+		return &RawModel{}, passErr
+	}
+
+	// Assign names to the columns and rows.
+	if err = raw.passNames(m.ColNames, nc, m.RowNames, nr); err != nil {
+		return &RawModel{}, err
+	}
+
+	// Provide HiGHS with the starting point, if any.
+	if m.Start != nil {
+		err = raw.SetStartingPoint(*m.Start)
+		if err != nil {
+			return &RawModel{}, renameCallStatus(err, "ToRawModel")
+		}
+	}
+
+	// Restore the previous value of output_flag.
+	err = raw.SetBoolOption("output_flag", outFlag)
+	if err != nil {
+		return &RawModel{}, err
+	}
+	return raw, passErr
+}
+
+// passNames assigns names to a raw model's columns and rows.  Either slice of
+// names may be empty, in which case the corresponding objects are left
+// unnamed.
+func (m *RawModel) passNames(colNames []string, nc int, rowNames []string, nr int) error {
+	// Ensure that each slice of names is either empty or complete.
+	if len(colNames) != 0 && len(colNames) != nc {
+		return fmt.Errorf("inconsistent column counts")
+	}
+	if len(rowNames) != 0 && len(rowNames) != nr {
+		return fmt.Errorf("inconsistent row counts")
+	}
+
+	// Pass each name to HiGHS.
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+	for c, name := range colNames {
+		cName := C.CString(name)
+		status := C.Highs_passColName(obj, C.HighsInt(c), cName)
+		C.free(unsafe.Pointer(cName))
+		err = newCallStatus(status, "Highs_passColName", "ToRawModel")
+		if err != nil {
+			return err
+		}
+	}
+	for r, name := range rowNames {
+		cName := C.CString(name)
+		status := C.Highs_passRowName(obj, C.HighsInt(r), cName)
+		C.free(unsafe.Pointer(cName))
+		err = newCallStatus(status, "Highs_passRowName", "ToRawModel")
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Solve solves the model as either an LP, MIP, or QP problem, depending on
+// which fields are non-nil.  HiGHS's log output is suppressed unless the
+// model's Verbose field is true.  Solve returns ErrUnsupportedMIQP if the
+// model has both a Hessian matrix and non-continuous columns.  If the model's
+// Strict field is true, Solve returns Validate's error, if any.  As with
+// RawModel.Solve, a warning from HiGHS is returned along with the solution.
+// Rows with nonzero RowPenalties are treated as soft constraints, as
+// described under SoftenedModel.  The solution then omits the slack columns,
+// reports each row's activity without its slack, includes the penalties in
+// the objective value, and reports each row's violation in RowViolations.
+func (m *Model) Solve() (Solution, error) {
+	// Reject models HiGHS cannot solve correctly.
+	if m.isMIQP() {
+		return Solution{}, ErrUnsupportedMIQP
+	}
+
+	// Solve models with soft rows via their softened equivalents.
+	if m.hasSoftRows() {
+		return m.solveSoft((*Model).Solve)
+	}
+
+	// Convert the Model to a RawModel.  Retain any warning so it can be
+	// returned if the solve itself produces none.  Hide the fact that
+	// ToRawModel and SetBoolOption were invoked internally.
+	raw, warn := m.ToRawModel()
+	if warn != nil && !isWarning(warn) {
+		return Solution{}, renameCallStatus(warn, "Solve")
+	}
+	warn = renameCallStatus(warn, "Solve")
+	defer raw.Close()
+
+	// Disable status output unless the caller requested it.
+	err := raw.SetBoolOption("output_flag", m.Verbose)
+	if err != nil {
+		return Solution{}, renameCallStatus(err, "Solve")
+	}
+
+	// Solve the raw model.
+	soln, err := raw.Solve()
+	if err != nil && !isWarning(err) {
+		return Solution{}, err
+	}
+	if err == nil {
+		err = warn
+	}
+	return soln.Solution, err
+}
+
+// ReadModelFormat replaces the model with one read from an io.Reader in a
+// given format.  It is a convenience wrapper for RawModel.ReadModelFormat
+// followed by RawModel.ToModel.  (The method is not named ReadFrom because
+// its signature differs from that of io.ReaderFrom.)
+func (m *Model) ReadModelFormat(r io.Reader, f ModelFormat) error {
+	// Read the model into a quiet raw model.
+	raw := NewRawModel()
+	defer raw.Close()
+	err := raw.SetBoolOption("output_flag", false)
+	if err == nil {
+		err = raw.ReadModelFormat(r, f)
+	}
+	if err != nil {
+		return renameCallStatus(err, "ReadModelFormat")
+	}
+
+	// Convert the raw model to a high-level model.
+	mdl, err := raw.ToModel()
+	if err != nil {
+		return renameCallStatus(err, "ReadModelFormat")
+	}
+	*m = *mdl
+	return nil
+}
+
+// WriteModelFormat writes the model to an io.Writer in a given format.  It is
+// a convenience wrapper for ToRawModel followed by RawModel.WriteModelFormat.
+// Unlike WriteMPS, WriteModelFormat supports all of HiGHS's model formats and
+// all variable types.  (The method is not named WriteTo because its signature
+// differs from that of io.WriterTo.)
+func (m *Model) WriteModelFormat(w io.Writer, f ModelFormat) error {
+	// Convert the model to a quiet raw model.
+	raw, warn := m.ToRawModel()
+	if warn != nil && !isWarning(warn) {
+		return renameCallStatus(warn, "WriteModelFormat")
+	}
+	defer raw.Close()
+	err := raw.SetBoolOption("output_flag", false)
+	if err == nil {
+		err = raw.WriteModelFormat(w, f)
+	}
+	if err == nil {
+		err = warn
+	}
+	return renameCallStatus(err, "WriteModelFormat")
+}
@@ -9,9 +9,6 @@ import (
 	"math"
 )
 
-// #include "highs-externs.h"
-import "C"
-
 // A Model encapsulates all the data needed to express linear-programming
 // models, mixed-integer models, and quadratic-programming models.
 type Model struct {
@@ -25,6 +22,27 @@ type Model struct {
 	ConstMatrix   []Nonzero      // Sparse constraint matrix (per-row variable coefficients)
 	HessianMatrix []Nonzero      // Sparse, upper-triangular matrix of second partial derivatives of quadratic constraints
 	VarTypes      []VariableType // Type of each model variable
+	ColNames      []string       // Name of each column (optional)
+	RowNames      []string       // Name of each row (optional)
+	ColTags       []any          // Arbitrary application data associated with each column (optional)
+	RowTags       []any          // Arbitrary application data associated with each row (optional)
+	Verbose       bool           // true=show HiGHS's log output when solving; false=suppress it
+	Strict        bool           // true=reject the model when converting or solving it if Validate fails
+	Start         *StartingPoint // Initial point for continuous solves (optional)
+	RowPenalties  []float64      // Per-unit penalty for violating each row's bounds, with 0 meaning the row is hard (optional; see SoftenedModel)
+}
+
+// A StartingPoint provides HiGHS with an initial point from which to begin
+// solving a continuous (LP or QP) model, such as the solution of a closely
+// related model.  ColumnPrimal is required; the other fields are optional
+// and may be nil.  A Solution's fields of the same names can be used
+// directly.  See RawModel.SetPartialMIPStart for starting points for
+// mixed-integer models.
+type StartingPoint struct {
+	ColumnPrimal []float64 // Primal column values
+	RowPrimal    []float64 // Primal row values
+	ColumnDual   []float64 // Dual column values
+	RowDual      []float64 // Dual row values
 }
 
 // AddDenseRow is a convenience function that lets the caller add to the model
@@ -47,6 +65,211 @@ func (m *Model) AddDenseRow(lb float64, coeffs []float64, ub float64) {
 	}
 }
 
+// SetDenseHessian assigns the model's HessianMatrix from a dense, symmetric
+// matrix Q, where the objective function's quadratic term is ½ xᵀQx.  Only
+// the nonzero elements of Q's upper triangle are stored.  SetDenseHessian
+// returns an error if Q is not square or not symmetric.
+func (m *Model) SetDenseHessian(q [][]float64) error {
+	// Ensure that the matrix is square and symmetric.
+	n := len(q)
+	for i, row := range q {
+		if len(row) != n {
+			return fmt.Errorf("row %d of the Hessian has %d elements but should have %d",
+				i, len(row), n)
+		}
+	}
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if q[i][j] != q[j][i] {
+				return fmt.Errorf("the Hessian is not symmetric: element (%d, %d) is %v but element (%d, %d) is %v",
+					i, j, q[i][j], j, i, q[j][i])
+			}
+		}
+	}
+
+	// Store the upper triangle sparsely.
+	hess := make([]Nonzero, 0, n)
+	for i, row := range q {
+		for j := i; j < n; j++ {
+			if row[j] == 0.0 {
+				continue
+			}
+			hess = append(hess, Nonzero{Row: i, Col: j, Val: row[j]})
+		}
+	}
+	m.HessianMatrix = hess
+	return nil
+}
+
+// EvalObjective evaluates the model's objective function, cᵀx + o + ½ xᵀQx,
+// at a given point using the same conventions as HiGHS.  Because
+// HessianMatrix stores only the upper triangle of the symmetric matrix Q, each
+// off-diagonal element contributes twice to xᵀQx and therefore once to the
+// objective value.  As in ToRawModel, a missing ColCosts is treated as all
+// ones, and later Hessian entries replace earlier entries with the same
+// coordinates.
+func (m *Model) EvalObjective(x []float64) (float64, error) {
+	// Check the arguments.
+	_, nc := m.modelSize()
+	if len(x) != nc {
+		return 0.0, fmt.Errorf("x has %d elements but the model has %d columns", len(x), nc)
+	}
+	costs, ok := expandToLen(nc, m.ColCosts, 1.0)
+	if !ok {
+		return 0.0, fmt.Errorf("inconsistent column counts")
+	}
+	hess, err := filterNonzeros(m.HessianMatrix, true)
+	if err != nil {
+		return 0.0, err
+	}
+
+	// Evaluate the linear and quadratic terms.
+	obj := m.Offset
+	for c, v := range x {
+		obj += costs[c] * v
+	}
+	for _, nz := range hess {
+		if nz.Row == nz.Col {
+			obj += 0.5 * nz.Val * x[nz.Row] * x[nz.Col]
+		} else {
+			obj += nz.Val * x[nz.Row] * x[nz.Col]
+		}
+	}
+	return obj, nil
+}
+
+// padTo extends a slice to at least a given length by appending copies of a
+// given value.
+func padTo[T any](xs []T, n int, v T) []T {
+	for len(xs) < n {
+		xs = append(xs, v)
+	}
+	return xs
+}
+
+// SetColTag associates an arbitrary value, such as an application-specific
+// identifier, with a column.  ColTags is extended with nil tags as necessary.
+// Tags are never passed to HiGHS; they exist so that callers can interpret
+// a Solution's per-column values without maintaining a parallel data
+// structure.
+func (m *Model) SetColTag(col int, tag any) {
+	m.ColTags = padTo(m.ColTags, col+1, nil)
+	m.ColTags[col] = tag
+}
+
+// SetRowTag associates an arbitrary value, such as an application-specific
+// identifier, with a row.  RowTags is extended with nil tags as necessary.
+// Tags are never passed to HiGHS; they exist so that callers can interpret
+// a Solution's per-row values without maintaining a parallel data structure.
+func (m *Model) SetRowTag(row int, tag any) {
+	m.RowTags = padTo(m.RowTags, row+1, nil)
+	m.RowTags[row] = tag
+}
+
+// ColTag returns the tag associated with a column or nil if the column has no
+// tag.
+func (m *Model) ColTag(col int) any {
+	if col < 0 || col >= len(m.ColTags) {
+		return nil
+	}
+	return m.ColTags[col]
+}
+
+// RowTag returns the tag associated with a row or nil if the row has no tag.
+func (m *Model) RowTag(row int) any {
+	if row < 0 || row >= len(m.RowTags) {
+		return nil
+	}
+	return m.RowTags[row]
+}
+
+// nameIndex maps each non-empty name to its index.  If a name appears more
+// than once, the map holds its last index.
+func nameIndex(names []string) map[string]int {
+	idx := make(map[string]int, len(names))
+	for i, name := range names {
+		if name != "" {
+			idx[name] = i
+		}
+	}
+	return idx
+}
+
+// ColIndices returns a map from column name to column index so that columns
+// can be addressed symbolically.  Unnamed columns are omitted, and a name
+// shared by several columns maps to the last of them.
+func (m *Model) ColIndices() map[string]int {
+	return nameIndex(m.ColNames)
+}
+
+// RowIndices returns a map from row name to row index so that rows can be
+// addressed symbolically.  Unnamed rows are omitted, and a name shared by
+// several rows maps to the last of them.
+func (m *Model) RowIndices() map[string]int {
+	return nameIndex(m.RowNames)
+}
+
+// setSemiVariable is a helper function for SetSemiContinuous and
+// SetSemiInteger that assigns a column's type and bounds.  It pads ColLower,
+// ColUpper, and VarTypes to cover both col and every column the model
+// already has so that all three agree with the model's column count.
+func (m *Model) setSemiVariable(col int, vt VariableType, lb, ub float64) {
+	_, nc := m.modelSize()
+	if col+1 > nc {
+		nc = col + 1
+	}
+	m.ColLower = padTo(m.ColLower, nc, math.Inf(-1))
+	m.ColUpper = padTo(m.ColUpper, nc, math.Inf(1))
+	m.VarTypes = padTo(m.VarTypes, nc, ContinuousType)
+	m.ColLower[col] = lb
+	m.ColUpper[col] = ub
+	m.VarTypes[col] = vt
+}
+
+// SetSemiContinuous declares that a column must be either zero or a real
+// number in [lb, ub].  ColLower, ColUpper, and VarTypes are extended with
+// unbounded, continuous columns to the larger of col+1 and the model's
+// current number of columns, so they can be indexed by any existing column
+// afterward.  ColCosts, if non-empty, must be extended by the caller when col
+// lies beyond the model's current columns.
+func (m *Model) SetSemiContinuous(col int, lb, ub float64) {
+	m.setSemiVariable(col, SemiContinuousType, lb, ub)
+}
+
+// SetSemiInteger declares that a column must be either zero or an integer in
+// [lb, ub].  ColLower, ColUpper, and VarTypes are extended as in
+// SetSemiContinuous.
+func (m *Model) SetSemiInteger(col int, lb, ub float64) {
+	m.setSemiVariable(col, SemiIntegerType, lb, ub)
+}
+
+// checkSemiVariables returns an error if a semi-continuous or semi-integer
+// column lacks the bounds HiGHS requires of such columns: a nonnegative lower
+// bound no greater than a finite upper bound.
+func (m *Model) checkSemiVariables() error {
+	for c, vt := range m.VarTypes {
+		if vt != SemiContinuousType && vt != SemiIntegerType {
+			continue
+		}
+		if c >= len(m.ColLower) || c >= len(m.ColUpper) {
+			return fmt.Errorf("%s column %d requires explicit bounds", vt, c)
+		}
+		lb, ub := m.ColLower[c], m.ColUpper[c]
+		switch {
+		case math.IsNaN(lb) || lb < 0.0:
+			return fmt.Errorf("%s column %d has a lower bound of %v but requires a nonnegative lower bound",
+				vt, c, lb)
+		case math.IsNaN(ub) || math.IsInf(ub, 1):
+			return fmt.Errorf("%s column %d has an upper bound of %v but requires a finite upper bound",
+				vt, c, ub)
+		case lb > ub:
+			return fmt.Errorf("%s column %d has a lower bound (%v) greater than its upper bound (%v)",
+				vt, c, lb, ub)
+		}
+	}
+	return nil
+}
+
 // modelSize returns the number of rows and columns in a model.  It works by
 // taking the maximum encountered in any of the fields representing rows or
 // columns.
@@ -78,6 +301,12 @@ func (m *Model) modelSize() (int, int) {
 	if len(m.ColUpper) > nc {
 		nc = len(m.ColUpper)
 	}
+	if len(m.ColNames) > nc {
+		nc = len(m.ColNames)
+	}
+	if len(m.RowNames) > nr {
+		nr = len(m.RowNames)
+	}
 	if len(m.RowLower) > nr {
 		nr = len(m.RowLower)
 	}
@@ -87,140 +316,183 @@ func (m *Model) modelSize() (int, int) {
 	return nr, nc
 }
 
-// ToRawModel converts a high-level model to a low-level model.
-func (m *Model) ToRawModel() (*RawModel, error) {
-	// Construct an empty raw model.  Turn off output, which is out of
-	// place in a method like ToRawModel.
-	raw := NewRawModel()
-	outFlag, err := raw.GetBoolOption("output_flag") // Presumably "true"
-	if err != nil {
-		return &RawModel{}, err
-	}
-	err = raw.SetBoolOption("output_flag", false)
-	if err != nil {
-		return &RawModel{}, err
+// checkFinite returns an error if a value is NaN or infinite.  The descriptive
+// text identifies the value in the error message.
+func checkFinite(v float64, desc string) error {
+	switch {
+	case math.IsNaN(v):
+		return fmt.Errorf("%s is NaN", desc)
+	case math.IsInf(v, 0):
+		return fmt.Errorf("%s is infinite", desc)
+	default:
+		return nil
 	}
+}
 
-	// Convert ConstMatrix and HessianMatrix to CSR format.
-	aStart, aIndex, aValue, err := nonzerosToCSR(m.ConstMatrix, false)
-	if err != nil {
-		return &RawModel{}, err
+// checkBounds returns an error if a lower or upper bound is NaN, a lower bound
+// is +∞, or an upper bound is −∞.  The kind argument ("column" or "row") is
+// used in the error message.
+func checkBounds(lb, ub []float64, kind string) error {
+	for i, v := range lb {
+		switch {
+		case math.IsNaN(v):
+			return fmt.Errorf("lower bound of %s %d is NaN", kind, i)
+		case math.IsInf(v, 1):
+			return fmt.Errorf("lower bound of %s %d is +Inf", kind, i)
+		}
 	}
-	qStart, qIndex, qValue, err := nonzerosToCSR(m.HessianMatrix, true)
-	if err != nil {
-		return &RawModel{}, err
-	}
-
-	// Convert Go values to C values.
-	nr, nc := m.modelSize()
-	numCol := C.HighsInt(nc)
-	numRow := C.HighsInt(nr)
-	numNZ := C.HighsInt(len(aValue))
-	qNumNZ := C.HighsInt(len(qValue))
-	aFormat := C.kHighsMatrixFormatRowwise
-	qFormat := C.kHighsHessianFormatTriangular
-	sense := C.kHighsObjSenseMinimize
-	if m.Maximize {
-		sense = C.kHighsObjSenseMaximize
-	}
-	offset := C.double(m.Offset)
-	colCost := convertSlice[C.double, float64](m.ColCosts)
-	colLower := convertSlice[C.double, float64](m.ColLower)
-	colUpper := convertSlice[C.double, float64](m.ColUpper)
-	rowLower := convertSlice[C.double, float64](m.RowLower)
-	rowUpper := convertSlice[C.double, float64](m.RowUpper)
-	integrality := make([]C.HighsInt, len(m.VarTypes))
-	for i, vt := range m.VarTypes {
-		integrality[i] = variableTypeToHighs[vt]
-	}
-
-	// Ensure that all slices have consistent lengths.
-	var ok bool
-	if colCost, ok = expandToLen(nc, colCost, 1.0); !ok {
-		return &RawModel{}, fmt.Errorf("inconsistent column counts")
+	for i, v := range ub {
+		switch {
+		case math.IsNaN(v):
+			return fmt.Errorf("upper bound of %s %d is NaN", kind, i)
+		case math.IsInf(v, -1):
+			return fmt.Errorf("upper bound of %s %d is -Inf", kind, i)
+		}
 	}
-	mInf, pInf := C.double(math.Inf(-1)), C.double(math.Inf(1))
-	if colLower, ok = expandToLen(nc, colLower, mInf); !ok {
-		return &RawModel{}, fmt.Errorf("inconsistent column counts")
+	return nil
+}
+
+// Validate scans a model's numerical data for values that HiGHS cannot
+// meaningfully process: NaNs anywhere, infinite objective-function
+// coefficients, offsets, or matrix coefficients, lower bounds of +∞, upper
+// bounds of −∞, and semi-continuous or semi-integer columns that lack a
+// nonnegative lower bound and finite upper bound.  It returns an error that
+// identifies the first offending row or column or nil if no such value was
+// found.  Validate is invoked automatically by ToRawModel, Solve, and
+// SolveExternal only if the model's Strict field is true; callers who
+// construct models from untrusted or computed data may want to set Strict or
+// call Validate before solving.
+func (m *Model) Validate() error {
+	// Check the objective function.
+	if err := checkFinite(m.Offset, "objective offset"); err != nil {
+		return err
 	}
-	if colUpper, ok = expandToLen(nc, colUpper, pInf); !ok {
-		return &RawModel{}, fmt.Errorf("inconsistent column counts")
+	for c, v := range m.ColCosts {
+		if err := checkFinite(v, fmt.Sprintf("cost of column %d", c)); err != nil {
+			return err
+		}
 	}
-	if rowLower, ok = expandToLen(nr, rowLower, mInf); !ok {
-		return &RawModel{}, fmt.Errorf("inconsistent row counts")
+
+	// Check the column and row bounds.
+	if err := checkBounds(m.ColLower, m.ColUpper, "column"); err != nil {
+		return err
 	}
-	if rowUpper, ok = expandToLen(nr, rowUpper, pInf); !ok {
-		return &RawModel{}, fmt.Errorf("inconsistent row counts")
+	if err := checkBounds(m.RowLower, m.RowUpper, "row"); err != nil {
+		return err
 	}
-	if integrality, ok = expandToLen(nc, integrality, C.kHighsVarTypeContinuous); !ok {
-		return &RawModel{}, fmt.Errorf("inconsistent column counts")
+
+	// Check the bounds of semi-continuous and semi-integer columns.
+	if err := m.checkSemiVariables(); err != nil {
+		return err
 	}
 
-	// Construct a low-level model.
-	status := C.Highs_passModel(raw.obj, numCol, numRow,
-		numNZ, qNumNZ,
-		aFormat, qFormat, sense,
-		offset, sliceToPointer(colCost),
-		sliceToPointer(colLower), sliceToPointer(colUpper),
-		sliceToPointer(rowLower), sliceToPointer(rowUpper),
-		sliceToPointer(aStart), sliceToPointer(aIndex), sliceToPointer(aValue),
-		sliceToPointer(qStart), sliceToPointer(qIndex), sliceToPointer(qValue),
-		sliceToPointer(integrality))
-	err = newCallStatus(status, "Highs_passModel", "ToRawModel")
-	if err != nil {
-		return &RawModel{}, err
+	// Check the constraint and Hessian matrices.
+	for _, nz := range m.ConstMatrix {
+		desc := fmt.Sprintf("constraint coefficient at row %d, column %d", nz.Row, nz.Col)
+		if err := checkFinite(nz.Val, desc); err != nil {
+			return err
+		}
+	}
+	for _, nz := range m.HessianMatrix {
+		desc := fmt.Sprintf("Hessian coefficient at row %d, column %d", nz.Row, nz.Col)
+		if err := checkFinite(nz.Val, desc); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	// Restore the previous value of output_flag.
-	err = raw.SetBoolOption("output_flag", outFlag)
-	if err != nil {
-		return &RawModel{}, err
+// ErrUnsupportedMIQP indicates that a model combines a Hessian matrix with
+// integer, semi-continuous, or semi-integer columns.  HiGHS does not solve
+// such mixed-integer quadratic programs.
+var ErrUnsupportedMIQP = errors.New("HiGHS cannot solve mixed-integer quadratic programs")
+
+// isMIQP returns true if a model has both a nonempty Hessian matrix and at
+// least one non-continuous column.
+func (m *Model) isMIQP() bool {
+	if len(m.HessianMatrix) == 0 {
+		return false
+	}
+	for _, vt := range m.VarTypes {
+		if vt != ContinuousType {
+			return true
+		}
 	}
-	return raw, nil
+	return false
 }
 
 // A Solution encapsulates all the values returned by any of HiGHS's solvers.
 // Not all fields will be meaningful when returned by any given solver.
 type Solution struct {
-	Status       ModelStatus   // Status of the LP solve
-	ColumnPrimal []float64     // Primal column solution
-	RowPrimal    []float64     // Primal row solution
-	ColumnDual   []float64     // Dual column solution
-	RowDual      []float64     // Dual row solution
-	ColumnBasis  []BasisStatus // Basis status of each column
-	RowBasis     []BasisStatus // Basis status of each row
-	Objective    float64       // Objective value
-}
-
-// Solve solves the model as either an LP, MIP, or QP problem, depending on
-// which fields are non-nil.
-func (m *Model) Solve() (Solution, error) {
-	// Convert the Model to a RawModel.
-	var cs CallStatus
-	raw, err := m.ToRawModel()
-	if err != nil {
-		if errors.As(err, &cs) {
-			// Hide the fact that ToRawModel was invoked internally.
-			cs.GoName = "Solve"
-		}
-		return Solution{}, err
+	Status        ModelStatus   // Status of the LP solve
+	ColumnPrimal  []float64     // Primal column solution
+	RowPrimal     []float64     // Primal row solution
+	ColumnDual    []float64     // Dual column solution
+	RowDual       []float64     // Dual row solution
+	ColumnBasis   []BasisStatus // Basis status of each column
+	RowBasis      []BasisStatus // Basis status of each row
+	Objective     float64       // Objective value
+	DualRay       []float64     // Farkas certificate proving infeasibility, if available
+	PrimalRay     []float64     // Direction in which the objective is unbounded, if available
+	RowViolations []float64     // Amount by which each soft row's bounds are violated (nil if the model has no soft rows)
+}
+
+// Slack returns the slack of each row of a model in the solution: the
+// distance from the row's activity (RowPrimal) to the nearer of its finite
+// bounds.  Slack is positive when the row is strictly within its bounds, zero
+// when a bound is active, and negative when a bound is violated.  Rows with
+// no finite bound have infinite slack.  As in HiGHS, a bound whose magnitude
+// is at least 1e20 is considered infinite.  Slack returns an error if the
+// solution does not have one row value per row of the model.
+func (s *Solution) Slack(m *Model) ([]float64, error) {
+	// Fill in defaults as ToRawModel does.
+	nr, _ := m.modelSize()
+	if len(s.RowPrimal) != nr {
+		return nil, fmt.Errorf("expected %d row values but the solution has %d",
+			nr, len(s.RowPrimal))
+	}
+	var ok bool
+	var rowLower, rowUpper []float64
+	if rowLower, ok = expandToLen(nr, m.RowLower, math.Inf(-1)); !ok {
+		return nil, fmt.Errorf("inconsistent row counts")
+	}
+	if rowUpper, ok = expandToLen(nr, m.RowUpper, math.Inf(1)); !ok {
+		return nil, fmt.Errorf("inconsistent row counts")
 	}
 
-	// Disable status output.
-	err = raw.SetBoolOption("output_flag", false)
-	if err != nil {
-		if errors.As(err, &cs) {
-			// Hide the fact that SetBoolOption was invoked
-			// internally.
-			cs.GoName = "Solve"
+	// Compute the distance to the nearer finite bound.
+	slack := make([]float64, nr)
+	for r, act := range s.RowPrimal {
+		slack[r] = math.Inf(1)
+		if rowLower[r] > -highsInfinity {
+			slack[r] = act - rowLower[r]
+		}
+		if rowUpper[r] < highsInfinity {
+			slack[r] = math.Min(slack[r], rowUpper[r]-act)
 		}
-		return Solution{}, err
 	}
+	return slack, nil
+}
 
-	// Solve the raw model.
-	soln, err := raw.Solve()
-	if err != nil {
-		return Solution{}, err
+// ReducedCosts returns the reduced cost of each column of a model in the
+// solution, using a convention that does not depend on the objective sense:
+// the reduced cost is the amount by which the objective value worsens per
+// unit increase in the column, with the basic columns adjusting to keep the
+// rows at their current activities.  At an optimal solution, therefore, a
+// column at its lower bound has a nonnegative reduced cost, a column at its
+// upper bound has a nonpositive reduced cost, and a basic column has a zero
+// reduced cost, whether the model is minimized or maximized.
+//
+// HiGHS's ColumnDual is instead the rate at which the objective value
+// increases, cⱼ − aⱼᵀy, so ReducedCosts returns a copy of ColumnDual for a
+// minimization model and its negation for a maximization model.
+func (s *Solution) ReducedCosts(m *Model) []float64 {
+	rc := make([]float64, len(s.ColumnDual))
+	for c, d := range s.ColumnDual {
+		if m.Maximize {
+			d = -d
+		}
+		rc[c] = d
 	}
-	return soln.Solution, nil
+	return rc
 }
@@ -1,3 +1,5 @@
+//go:build cgo
+
 // This file tests the high package's high-level model API.  Although solvers
 // are invoked, the focus of this file is on features other than solving.
 // Other test files stress the solvers.
@@ -6,7 +8,13 @@ package highs
 
 import (
 	"bytes"
+	"errors"
+	"io"
+	"math"
 	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -126,3 +134,603 @@ func TestReadWriteModel(t *testing.T) {
 	checkErr(t, m2.SetBoolOption("output_flag", false))
 	checkErr(t, m2.ReadModel(&buf))
 }
+
+// TestValidate tests that Validate detects NaNs and misplaced infinities.
+func TestValidate(t *testing.T) {
+	// Start from a valid model.
+	var model Model
+	model.ColCosts = []float64{1.0, 2.0}
+	model.ColLower = []float64{math.Inf(-1), 0.0}
+	model.ColUpper = []float64{math.Inf(1), 10.0}
+	model.AddDenseRow(math.Inf(-1), []float64{1.0, 1.0}, 5.0)
+	checkErr(t, model.Validate())
+
+	// Introduce various errors one at a time and ensure that each is
+	// detected.
+	for _, tc := range []struct {
+		name  string
+		spoil func(m *Model)
+	}{
+		{"NaN cost", func(m *Model) { m.ColCosts[1] = math.NaN() }},
+		{"infinite cost", func(m *Model) { m.ColCosts[0] = math.Inf(1) }},
+		{"infinite offset", func(m *Model) { m.Offset = math.Inf(-1) }},
+		{"+Inf lower bound", func(m *Model) { m.ColLower[1] = math.Inf(1) }},
+		{"-Inf upper bound", func(m *Model) { m.ColUpper[0] = math.Inf(-1) }},
+		{"NaN row bound", func(m *Model) { m.RowUpper[0] = math.NaN() }},
+		{"NaN coefficient", func(m *Model) { m.ConstMatrix[1].Val = math.NaN() }},
+		{"infinite Hessian", func(m *Model) {
+			m.HessianMatrix = []Nonzero{{0, 0, math.Inf(1)}}
+		}},
+	} {
+		var bad Model
+		bad.ColCosts = append([]float64(nil), model.ColCosts...)
+		bad.ColLower = append([]float64(nil), model.ColLower...)
+		bad.ColUpper = append([]float64(nil), model.ColUpper...)
+		bad.RowLower = append([]float64(nil), model.RowLower...)
+		bad.RowUpper = append([]float64(nil), model.RowUpper...)
+		bad.ConstMatrix = append([]Nonzero(nil), model.ConstMatrix...)
+		tc.spoil(&bad)
+		if err := bad.Validate(); err == nil {
+			t.Fatalf("Validate failed to detect a %s", tc.name)
+		}
+	}
+
+	// Ensure that a strict model is validated automatically.
+	model.Strict = true
+	model.ColCosts[1] = math.NaN()
+	if _, err := model.ToRawModel(); err == nil {
+		t.Fatal("ToRawModel accepted an invalid strict model")
+	}
+	if _, err := model.Solve(); err == nil {
+		t.Fatal("Solve accepted an invalid strict model")
+	}
+}
+
+// TestReadWriteGzip tests that models can be written to and read from
+// gzip-compressed files and streams.  It uses the same model as
+// TestWriteModelToFile/TestReadModelFromFile.
+func TestReadWriteGzip(t *testing.T) {
+	// Prepare the model.
+	m1 := NewRawModel()
+	checkErr(t, m1.SetBoolOption("output_flag", false))
+	checkErr(t, m1.AddColumnBounds([]float64{1.0, 1.0},
+		[]float64{25.0, 25.0}))
+	checkErr(t, m1.SetColumnCosts([]float64{2.0, 1.0}))
+	checkErr(t, m1.AddDenseRow(10.0, []float64{1.0, 1.0}, 10.0))
+	checkErr(t, m1.AddDenseRow(4.0, []float64{1.0, -1.0}, 4.0))
+
+	// Write the model to a compressed file and confirm that it really is
+	// compressed.
+	fname := filepath.Join(t.TempDir(), "model.mps.gz")
+	err := m1.WriteModelToFile(fname)
+	var cs CallStatus
+	if err != nil && !(errors.As(err, &cs) && cs.IsWarning()) {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) < 2 || data[0] != 0x1f || data[1] != 0x8b {
+		t.Fatalf("%s is not gzip-compressed", fname)
+	}
+
+	// Read the model back from both the file and a stream, and solve
+	// each copy.
+	m2 := NewRawModel()
+	checkErr(t, m2.SetBoolOption("output_flag", false))
+	checkErr(t, m2.ReadModelFromFile(fname))
+	m3 := NewRawModel()
+	checkErr(t, m3.SetBoolOption("output_flag", false))
+	checkErr(t, m3.ReadModel(bytes.NewReader(data)))
+	for _, m := range []*RawModel{m2, m3} {
+		soln, err := m.Solve()
+		if err != nil {
+			t.Fatal(err)
+		}
+		compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{7.0, 3.0})
+	}
+
+	// Ensure that a compressed file with no format extension is rejected
+	// and not created.
+	bare := filepath.Join(t.TempDir(), "model.gz")
+	if m1.WriteModelToFile(bare) == nil {
+		t.Fatalf("WriteModelToFile accepted %s", bare)
+	}
+	if _, err = os.Stat(bare); err == nil {
+		t.Fatalf("WriteModelToFile created %s", bare)
+	}
+	if m2.ReadModelFromFile(bare) == nil {
+		t.Fatalf("ReadModelFromFile accepted %s", bare)
+	}
+}
+
+// TestToModel converts a Model to a RawModel and back and confirms that the
+// result matches the original.  It uses the model from TestMinimalAPIMaxMIP
+// with names added and, separately, the QP from TestMinimalAPIQPMin.
+func TestToModel(t *testing.T) {
+	// Prepare a MIP model.
+	var model Model
+	model.Maximize = true
+	model.Offset = 3.0
+	model.ColCosts = []float64{1.0, 1.0}
+	model.ColLower = []float64{0.0, 1.0}
+	model.ColUpper = []float64{4.0, math.Inf(1)}
+	model.RowLower = []float64{math.Inf(-1), 5.0, 6.0}
+	model.RowUpper = []float64{7.0, 15.0, math.Inf(1)}
+	model.ConstMatrix = []Nonzero{
+		{0, 1, 1.0},
+		{1, 0, 1.0},
+		{1, 1, 2.0},
+		{2, 0, 3.0},
+		{2, 1, 2.0},
+	}
+	model.VarTypes = []VariableType{IntegerType, ContinuousType}
+	model.ColNames = []string{"x", "y"}
+	model.RowNames = []string{"cap", "range", "floor"}
+
+	// Convert the model to a RawModel and back.
+	raw, err := model.ToRawModel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer raw.Close()
+	got, err := raw.ToModel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*got, model) {
+		t.Fatalf("expected %v but observed %v", model, *got)
+	}
+
+	// Prepare a QP model.
+	var qp Model
+	qp.ColCosts = []float64{0.0, -1.0, -3.0}
+	qp.ColLower = []float64{math.Inf(-1), math.Inf(-1), math.Inf(-1)}
+	qp.ColUpper = []float64{math.Inf(1), math.Inf(1), math.Inf(1)}
+	qp.AddDenseRow(math.Inf(-1), []float64{1.0, 0.0, 1.0}, 2.0)
+	qp.HessianMatrix = []Nonzero{
+		{0, 0, 2.0},
+		{0, 2, -1.0},
+		{1, 1, 0.2},
+		{2, 2, 2.0},
+	}
+
+	// Convert the QP model to a RawModel and back.
+	rawQP, err := qp.ToRawModel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rawQP.Close()
+	got, err = rawQP.ToModel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*got, qp) {
+		t.Fatalf("expected %v but observed %v", qp, *got)
+	}
+}
+
+// TestToRawModelWithOptions tests that ToRawModel and ToRawModelWithOptions
+// return a RawModel with output enabled regardless of whether output was
+// suppressed during the conversion.
+func TestToRawModelWithOptions(t *testing.T) {
+	var model Model
+	model.ColCosts = []float64{1.0, 1.0}
+	model.AddDenseRow(1.0, []float64{1.0, 1.0}, 2.0)
+	for _, verbose := range []bool{false, true} {
+		raw, err := model.ToRawModelWithOptions(RawModelOptions{Verbose: verbose})
+		if err != nil {
+			t.Fatal(err)
+		}
+		out, err := raw.GetBoolOption("output_flag")
+		raw.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !out {
+			t.Fatalf("output_flag was false after conversion with Verbose=%v", verbose)
+		}
+	}
+}
+
+// TestTags tests that tags associated with columns and rows survive the
+// addition of rows and solving and can be used to interpret a Solution.  The
+// model is as follows:
+//
+//	Min. x_0 + 2*x_1
+//	s.t. 3 <= x_0 + x_1
+//	     1 <= x_1
+func TestTags(t *testing.T) {
+	// Prepare the model.
+	type sku string
+	var model Model
+	model.ColCosts = []float64{1.0, 2.0}
+	model.SetColTag(1, sku("B-200"))
+	model.SetColTag(0, sku("A-100"))
+	model.AddDenseRow(3.0, []float64{1.0, 1.0}, math.Inf(1))
+	model.SetRowTag(0, "demand")
+	model.AddDenseRow(1.0, []float64{0.0, 1.0}, math.Inf(1))
+	if model.RowTag(1) != nil || model.RowTag(-1) != nil || model.ColTag(2) != nil {
+		t.Fatal("untagged rows and columns should have nil tags")
+	}
+
+	// Solve the model, and use the tags to interpret the solution.
+	soln, err := model.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	qty := make(map[sku]float64)
+	for c, v := range soln.ColumnPrimal {
+		qty[model.ColTag(c).(sku)] = v
+	}
+	exp := map[sku]float64{"A-100": 2.0, "B-200": 1.0}
+	if !reflect.DeepEqual(qty, exp) {
+		t.Fatalf("expected %v but saw %v", exp, qty)
+	}
+	if model.RowTag(0) != "demand" {
+		t.Fatalf("expected row 0 to be tagged %q but saw %v", "demand", model.RowTag(0))
+	}
+}
+
+// TestNameIndices tests that ColIndices and RowIndices map names to indices,
+// omitting empty names and preferring the last of any repeated names.
+func TestNameIndices(t *testing.T) {
+	var model Model
+	model.ColNames = []string{"x", "", "y", "x"}
+	model.RowNames = []string{"supply", "demand"}
+	exp := map[string]int{"x": 3, "y": 2}
+	if idx := model.ColIndices(); !reflect.DeepEqual(idx, exp) {
+		t.Fatalf("expected %v but saw %v", exp, idx)
+	}
+	exp = map[string]int{"supply": 0, "demand": 1}
+	if idx := model.RowIndices(); !reflect.DeepEqual(idx, exp) {
+		t.Fatalf("expected %v but saw %v", exp, idx)
+	}
+}
+
+// TestRawModelCounts tests that RawModel.Counts summarizes a MIP read from
+// MPS, which represents an unbounded row's bound as an infinite value.
+func TestRawModelCounts(t *testing.T) {
+	// Prepare the model.
+	var model Model
+	model.ColCosts = []float64{1.0, 1.0, 1.0}
+	model.AddDenseRow(1.0, []float64{1.0, 1.0, 0.0}, 1.0)
+	model.AddDenseRow(0.0, []float64{0.0, 1.0, 1.0}, 5.0)
+	model.AddDenseRow(math.Inf(-1), []float64{1.0, 0.0, 1.0}, 5.0)
+	model.VarTypes = []VariableType{IntegerType, ContinuousType, SemiContinuousType}
+	model.ColLower = []float64{0.0, 0.0, 1.0}
+	model.ColUpper = []float64{1.0, 10.0, 2.0}
+	var buf bytes.Buffer
+	checkErr(t, model.WriteModelFormat(&buf, MPSFormat))
+	raw := NewRawModel()
+	defer raw.Close()
+	checkErr(t, raw.SetBoolOption("output_flag", false))
+	checkErr(t, raw.ReadModelFormat(&buf, MPSFormat))
+
+	// Compare the counts to the expected values.
+	mc, err := raw.Counts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := ModelCounts{
+		Columns:            3,
+		ContinuousCols:     1,
+		IntegerCols:        1,
+		SemiContinuousCols: 1,
+		Rows:               3,
+		EqualityRows:       1,
+		RangeRows:          1,
+		InequalityRows:     1,
+		Nonzeros:           6,
+	}
+	if mc != exp {
+		t.Fatalf("expected %+v but saw %+v", exp, mc)
+	}
+}
+
+// TestReadWriteModelFormat tests writing a model in each supported format to
+// a buffer and to a file with a misleading extension then reading it back in
+// and solving it.  It uses the same model as
+// TestWriteModelToFile/TestReadModelFromFile.
+func TestReadWriteModelFormat(t *testing.T) {
+	// Prepare the model.
+	m1 := NewRawModel()
+	checkErr(t, m1.SetBoolOption("output_flag", false))
+	checkErr(t, m1.AddColumnBounds([]float64{1.0, 1.0},
+		[]float64{25.0, 25.0}))
+	checkErr(t, m1.SetColumnCosts([]float64{2.0, 1.0}))
+	checkErr(t, m1.AddDenseRow(10.0, []float64{1.0, 1.0}, 10.0))
+	checkErr(t, m1.AddDenseRow(4.0, []float64{1.0, -1.0}, 4.0))
+	checkErr(t, m1.SetIntegrality([]VariableType{IntegerType, IntegerType}))
+
+	// Read and write the model in each format.
+	dir := t.TempDir()
+	for _, f := range []ModelFormat{MPSFormat, LPFormat, EMSFormat} {
+		var buf bytes.Buffer
+		checkErr(t, m1.WriteModelFormat(&buf, f))
+		m2 := NewRawModel()
+		checkErr(t, m2.SetBoolOption("output_flag", false))
+		checkErr(t, m2.ReadModelFormat(&buf, f))
+		soln, err := m2.Solve()
+		if err != nil {
+			t.Fatalf("%s: %v", f, err)
+		}
+		compSlices(t, f.String(), soln.ColumnPrimal, []float64{7.0, 3.0})
+
+		fn := filepath.Join(dir, "model.txt")
+		checkErr(t, m1.WriteModelToFileFormat(fn, f))
+		m3 := NewRawModel()
+		checkErr(t, m3.SetBoolOption("output_flag", false))
+		checkErr(t, m3.ReadModelFromFileFormat(fn, f))
+		soln, err = m3.Solve()
+		if err != nil {
+			t.Fatalf("%s: %v", f, err)
+		}
+		compSlices(t, f.String(), soln.ColumnPrimal, []float64{7.0, 3.0})
+
+		ext, err := f.extension()
+		checkErr(t, err)
+		fn = filepath.Join(dir, "model"+ext+".gz")
+		checkErr(t, m1.WriteModelToFile(fn))
+		m4 := NewRawModel()
+		checkErr(t, m4.SetBoolOption("output_flag", false))
+		checkErr(t, m4.ReadModelFromFile(fn))
+		soln, err = m4.Solve()
+		if err != nil {
+			t.Fatalf("%s: %v", f, err)
+		}
+		compSlices(t, f.String(), soln.ColumnPrimal, []float64{7.0, 3.0})
+	}
+
+	// Ensure that invalid formats are rejected.
+	if m1.WriteModelFormat(io.Discard, ModelFormat(-1)) == nil {
+		t.Fatal("WriteModelFormat accepted an invalid format")
+	}
+}
+
+// TestModelReadWriteFormat tests that a high-level Model can be written and
+// read back in each model format.  It uses the same model as
+// TestReadWriteModelFormat.
+func TestModelReadWriteFormat(t *testing.T) {
+	// Prepare the model.
+	var m1 Model
+	m1.ColCosts = []float64{2.0, 1.0}
+	m1.ColLower = []float64{1.0, 1.0}
+	m1.ColUpper = []float64{25.0, 25.0}
+	m1.AddDenseRow(10.0, []float64{1.0, 1.0}, 10.0)
+	m1.AddDenseRow(4.0, []float64{1.0, -1.0}, 4.0)
+	m1.VarTypes = []VariableType{IntegerType, IntegerType}
+
+	// Write and read the model in each format.
+	for _, f := range []ModelFormat{MPSFormat, LPFormat, EMSFormat} {
+		var buf bytes.Buffer
+		checkErr(t, m1.WriteModelFormat(&buf, f))
+		var m2 Model
+		checkErr(t, m2.ReadModelFormat(&buf, f))
+		compSlices(t, f.String()+" ColCosts", m2.ColCosts, m1.ColCosts)
+		compSlices(t, f.String()+" VarTypes", m2.VarTypes, m1.VarTypes)
+		soln, err := m2.Solve()
+		if err != nil {
+			t.Fatalf("%s: %v", f, err)
+		}
+		compSlices(t, f.String(), soln.ColumnPrimal, []float64{7.0, 3.0})
+	}
+
+	// Ensure that invalid formats are rejected.
+	if m1.WriteModelFormat(io.Discard, ModelFormat(-1)) == nil {
+		t.Fatal("WriteModelFormat accepted an invalid format")
+	}
+	var m3 Model
+	if m3.ReadModelFormat(strings.NewReader(""), ModelFormat(-1)) == nil {
+		t.Fatal("ReadModelFormat accepted an invalid format")
+	}
+}
+
+// TestReadFixedMPS writes a model as fixed-format MPS with a negated
+// objective function, reads it back with the fixed-format parser, and solves
+// it.  It uses the same model as TestWriteModelToFile/TestReadModelFromFile,
+// but maximizes the negated objective function.
+func TestReadFixedMPS(t *testing.T) {
+	// Prepare the model.
+	var model Model
+	model.Maximize = true
+	model.ColCosts = []float64{-2.0, -1.0}
+	model.ColLower = []float64{1.0, 1.0}
+	model.ColUpper = []float64{25.0, 25.0}
+	model.AddDenseRow(10.0, []float64{1.0, 1.0}, 10.0)
+	model.AddDenseRow(4.0, []float64{1.0, -1.0}, 4.0)
+	model.VarTypes = []VariableType{IntegerType, IntegerType}
+
+	// Write the model to a buffer.
+	var buf bytes.Buffer
+	checkErr(t, model.WriteMPSOptions(&buf, MPSOptions{Fixed: true, NegateObjective: true}))
+
+	// Read and solve the model.
+	raw := NewRawModel()
+	checkErr(t, raw.SetBoolOption("output_flag", false))
+	checkErr(t, raw.SetMPSFixedFormat(true))
+	checkErr(t, raw.ReadModel(&buf))
+	soln, err := raw.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{7.0, 3.0})
+	if int(soln.Objective) != 17 {
+		t.Fatalf("objective value was %d but should have been 17", int(soln.Objective))
+	}
+}
+
+// TestSlack tests the slack computed for rows of each kind: a satisfied
+// equality, a range row strictly within its bounds, an inequality at its
+// upper bound, a violated lower bound, and a free row.
+func TestSlack(t *testing.T) {
+	var model Model
+	model.RowLower = []float64{5.0, 0.0, math.Inf(-1), 2.0, math.Inf(-1)}
+	model.RowUpper = []float64{5.0, 10.0, 4.0, math.Inf(1), math.Inf(1)}
+	soln := Solution{RowPrimal: []float64{5.0, 3.0, 4.0, 1.5, 7.0}}
+	slack, err := soln.Slack(&model)
+	checkErr(t, err)
+	compSlices(t, "Slack", slack, []float64{0.0, 3.0, 0.0, -0.5, math.Inf(1)})
+
+	// Ensure that a mismatched solution is rejected.
+	soln.RowPrimal = soln.RowPrimal[:4]
+	if _, err = soln.Slack(&model); err == nil {
+		t.Fatal("expected an error for a solution with too few rows")
+	}
+}
+
+// TestReducedCosts tests that ReducedCosts uses the same sign convention for
+// minimization and maximization models.  It solves the following model in
+// both senses:
+//
+//	Opt.  x_0 + 2*x_1
+//	s.t.  x_0 + x_1 <= 4
+//	with  0 <= x_0 <= 3, 0 <= x_1 <= 3
+func TestReducedCosts(t *testing.T) {
+	// Prepare the model.
+	var model Model
+	model.ColCosts = []float64{1.0, 2.0}
+	model.ColLower = []float64{0.0, 0.0}
+	model.ColUpper = []float64{3.0, 3.0}
+	model.AddDenseRow(math.Inf(-1), []float64{1.0, 1.0}, 4.0)
+
+	// When minimizing, both columns are at their lower bounds, so both
+	// reduced costs are nonnegative.
+	soln, err := model.Solve()
+	checkErr(t, err)
+	compSlices(t, "ReducedCosts", soln.ReducedCosts(&model), []float64{1.0, 2.0})
+
+	// When maximizing, x_1 is at its upper bound, so its reduced cost is
+	// nonpositive, and x_0 is basic.
+	model.Maximize = true
+	soln, err = model.Solve()
+	checkErr(t, err)
+	compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{1.0, 3.0})
+	compSlices(t, "ReducedCosts", soln.ReducedCosts(&model), []float64{0.0, -1.0})
+}
+
+// TestWritePresolvedModel tests that the presolved model is written and is
+// smaller than the original.  The original model contains a fixed column,
+// which presolve always removes:
+//
+//	Min.  x_0 + x_1 + 2*x_2
+//	s.t.  x_0 + x_1 + x_2 >= 5
+//	      x_1 - x_2 <= 1
+//	with  x_0 = 2, x_1 >= 0, x_2 >= 0
+func TestWritePresolvedModel(t *testing.T) {
+	// Prepare the model.
+	var model Model
+	model.ColCosts = []float64{1.0, 1.0, 2.0}
+	model.ColLower = []float64{2.0, 0.0, 0.0}
+	model.ColUpper = []float64{2.0, math.Inf(1), math.Inf(1)}
+	model.AddDenseRow(5.0, []float64{1.0, 1.0, 1.0}, math.Inf(1))
+	model.AddDenseRow(math.Inf(-1), []float64{0.0, 1.0, -1.0}, 1.0)
+	raw, err := model.ToRawModel()
+	checkErr(t, err)
+	checkErr(t, raw.SetBoolOption("output_flag", false))
+
+	// Write the presolved model, and read it back.
+	var buf bytes.Buffer
+	checkErr(t, raw.WritePresolvedModel(&buf, MPSFormat))
+	reduced := NewRawModel()
+	checkErr(t, reduced.SetBoolOption("output_flag", false))
+	checkErr(t, reduced.ReadModelFormat(&buf, MPSFormat))
+	nc, err := reduced.NumColumns()
+	checkErr(t, err)
+	if nc >= 3 {
+		t.Fatalf("expected fewer than 3 columns in the presolved model but saw %d", nc)
+	}
+
+	// Ensure that the original model is unaffected.
+	nc, err = raw.NumColumns()
+	checkErr(t, err)
+	if nc != 3 {
+		t.Fatalf("expected the original model to have 3 columns but saw %d", nc)
+	}
+}
+
+// TestPresolvedModel tests that Presolve and PresolvedModel expose the
+// reduced form of the model from TestWritePresolvedModel.
+func TestPresolvedModel(t *testing.T) {
+	// Prepare the model.
+	var model Model
+	model.ColCosts = []float64{1.0, 1.0, 2.0}
+	model.ColLower = []float64{2.0, 0.0, 0.0}
+	model.ColUpper = []float64{2.0, math.Inf(1), math.Inf(1)}
+	model.AddDenseRow(5.0, []float64{1.0, 1.0, 1.0}, math.Inf(1))
+	model.AddDenseRow(math.Inf(-1), []float64{0.0, 1.0, -1.0}, 1.0)
+	raw, err := model.ToRawModel()
+	checkErr(t, err)
+	defer raw.Close()
+	checkErr(t, raw.SetBoolOption("output_flag", false))
+
+	// Presolve the model, and retrieve the result.
+	status, err := raw.Presolve()
+	checkErr(t, err)
+	if status == Infeasible || status == UnboundedOrInfeasible {
+		t.Fatalf("presolve unexpectedly reported %s", status)
+	}
+	reduced, err := raw.PresolvedModel()
+	checkErr(t, err)
+	nc := len(reduced.ColCosts)
+	if nc >= 3 {
+		t.Fatalf("expected fewer than 3 columns in the presolved model but saw %d", nc)
+	}
+	if len(reduced.ColLower) != nc || len(reduced.ColUpper) != nc {
+		t.Fatalf("inconsistent column counts in the presolved model: %d, %d, and %d",
+			nc, len(reduced.ColLower), len(reduced.ColUpper))
+	}
+
+	// Ensure that the original model is unaffected.
+	nc, err = raw.NumColumns()
+	checkErr(t, err)
+	if nc != 3 {
+		t.Fatalf("expected the original model to have 3 columns but saw %d", nc)
+	}
+}
+
+// TestStartingPoint tests that a QP can be re-solved from the solution of a
+// previous solve and that starting points of the wrong size are rejected.  It
+// uses the model from TestMinimalAPIQPMin.
+func TestStartingPoint(t *testing.T) {
+	// Solve the model from scratch.
+	var model Model
+	model.ColCosts = []float64{0.0, -1.0, -3.0}
+	model.AddDenseRow(-1e30, []float64{1.0, 0.0, 1.0}, 2.0)
+	model.HessianMatrix = []Nonzero{
+		{0, 0, 2.0},
+		{0, 2, -1.0},
+		{1, 1, 0.2},
+		{2, 2, 2.0},
+	}
+	soln, err := model.Solve()
+	checkErr(t, err)
+
+	// Solve the model again from the previous solution.
+	model.Start = &StartingPoint{
+		ColumnPrimal: soln.ColumnPrimal,
+		RowPrimal:    soln.RowPrimal,
+		ColumnDual:   soln.ColumnDual,
+		RowDual:      soln.RowDual,
+	}
+	soln, err = model.Solve()
+	checkErr(t, err)
+	if soln.Status != Optimal {
+		t.Fatalf("Solve returned %s instead of Optimal", soln.Status)
+	}
+	primal := roundFloats(0.001, soln.ColumnPrimal)
+	compSlices(t, "ColumnPrimal", primal, []float64{0.5, 5.0, 1.5})
+
+	// Ensure that malformed starting points are rejected.
+	for _, sp := range []StartingPoint{
+		{ColumnPrimal: []float64{0.0, 0.0}},
+		{ColumnPrimal: []float64{0.0, 0.0, 0.0}, RowDual: []float64{0.0, 0.0}},
+		{RowPrimal: []float64{0.0}},
+	} {
+		model.Start = &sp
+		if _, err = model.ToRawModel(); err == nil {
+			t.Fatalf("ToRawModel accepted starting point %v", sp)
+		}
+	}
+}
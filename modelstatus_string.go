@@ -24,11 +24,13 @@ func _() {
 	_ = x[ObjectiveTarget-13]
 	_ = x[TimeLimit-14]
 	_ = x[IterationLimit-15]
+	_ = x[SolutionLimit-16]
+	_ = x[Interrupt-17]
 }
 
-const _ModelStatus_name = "UnknownModelStatusNotSetLoadErrorModelErrorPresolveErrorSolveErrorPostsolveErrorModelEmptyOptimalInfeasibleUnboundedOrInfeasibleUnboundedObjectiveBoundObjectiveTargetTimeLimitIterationLimit"
+const _ModelStatus_name = "UnknownModelStatusNotSetLoadErrorModelErrorPresolveErrorSolveErrorPostsolveErrorModelEmptyOptimalInfeasibleUnboundedOrInfeasibleUnboundedObjectiveBoundObjectiveTargetTimeLimitIterationLimitSolutionLimitInterrupt"
 
-var _ModelStatus_index = [...]uint8{0, 18, 24, 33, 43, 56, 66, 80, 90, 97, 107, 128, 137, 151, 166, 175, 189}
+var _ModelStatus_index = [...]uint8{0, 18, 24, 33, 43, 56, 66, 80, 90, 97, 107, 128, 137, 151, 166, 175, 189, 202, 211}
 
 func (i ModelStatus) String() string {
 	if i < 0 || i >= ModelStatus(len(_ModelStatus_index)-1) {
@@ -0,0 +1,501 @@
+// This file provides support for querying and modifying a model's
+// coefficients, bounds, and costs in place, without re-passing the entire
+// model to HiGHS.
+
+package highs
+
+import (
+	"fmt"
+	"sort"
+	"unsafe"
+)
+
+// #include "highs-externs.h"
+import "C"
+
+// checkRowCol returns an error if a row or column index lies outside a HiGHS
+// object's constraint matrix.  The caller must hold the object's lock.
+func checkRowCol(obj unsafe.Pointer, row, col int) error {
+	if nr := int(C.Highs_getNumRow(obj)); row < 0 || row >= nr {
+		return fmt.Errorf("row %d is out of range [0, %d)", row, nr)
+	}
+	if nc := int(C.Highs_getNumCol(obj)); col < 0 || col >= nc {
+		return fmt.Errorf("column %d is out of range [0, %d)", col, nc)
+	}
+	return nil
+}
+
+// ChangeCoeff replaces the constraint-matrix entry at a given row and column
+// with a new value.  A zero value removes the entry.  This is far cheaper than
+// re-passing a large model to HiGHS to modify a single coefficient, and
+// HiGHS retains its basis for the next solve.
+func (m *RawModel) ChangeCoeff(row, col int, v float64) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+	if err = checkRowCol(obj, row, col); err != nil {
+		return err
+	}
+	status := C.Highs_changeCoeff(obj, C.HighsInt(row), C.HighsInt(col), C.double(v))
+	return newCallStatus(status, "Highs_changeCoeff", "ChangeCoeff",
+		TraceAttr{"row", row}, TraceAttr{"col", col})
+}
+
+// GetCoeff returns the current value of the constraint-matrix entry at a
+// given row and column, which is zero if the entry is absent.  This makes it
+// possible to verify incremental edits made with ChangeCoeff.
+func (m *RawModel) GetCoeff(row, col int) (float64, error) {
+	obj, err := m.lock()
+	if err != nil {
+		return 0.0, err
+	}
+	defer m.unlock()
+	if err = checkRowCol(obj, row, col); err != nil {
+		return 0.0, err
+	}
+
+	// HiGHS's C API provides no direct way to query a single entry, so
+	// search the entry's row for it.
+	nzs, err := rowEntries(obj, row, "GetCoeff")
+	if err != nil {
+		return 0.0, err
+	}
+	for _, nz := range nzs {
+		if nz.Col == col {
+			return nz.Val, nil
+		}
+	}
+	return 0.0, nil
+}
+
+// checkRange returns an error if the n objects starting at first do not all
+// lie in [0, total).  what names the objects for use in error messages.
+func checkRange(what string, first, n, total int) error {
+	if first < 0 || first+n > total {
+		return fmt.Errorf("%ss [%d, %d) are out of range [0, %d)",
+			what, first, first+n, total)
+	}
+	return nil
+}
+
+// sortSet validates a set of indices into total objects and sorts it into
+// the ascending order HiGHS requires.  It returns the sorted indices and the
+// permutation that maps each sorted position to a position in the original
+// set.  what names the objects for use in error messages.
+func sortSet(what string, set []int, total int) ([]C.HighsInt, []int, error) {
+	perm := make([]int, len(set))
+	for i, idx := range set {
+		if idx < 0 || idx >= total {
+			return nil, nil, fmt.Errorf("%s %d is out of range [0, %d)", what, idx, total)
+		}
+		perm[i] = i
+	}
+	sort.Slice(perm, func(i, j int) bool { return set[perm[i]] < set[perm[j]] })
+	sorted := make([]C.HighsInt, len(set))
+	for i, p := range perm {
+		if i > 0 && set[p] == set[perm[i-1]] {
+			return nil, nil, fmt.Errorf("%s %d appears more than once", what, set[p])
+		}
+		sorted[i] = C.HighsInt(set[p])
+	}
+	return sorted, perm, nil
+}
+
+// permuteValues converts a slice of values from Go to C, reordering it by a
+// permutation returned by sortSet.
+func permuteValues(xs []float64, perm []int) []C.double {
+	cs := make([]C.double, len(perm))
+	for i, p := range perm {
+		cs[i] = C.double(xs[p])
+	}
+	return cs
+}
+
+// ChangeColBounds replaces the lower and upper bounds of a single column.
+// Together with ChangeColsBoundsByRange and ChangeColsBoundsBySet, this lets
+// an application tighten and relax bounds between re-solves, as in an
+// external branch-and-bound loop, without rebuilding the model.
+func (m *RawModel) ChangeColBounds(col int, lb, ub float64) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+	if nc := int(C.Highs_getNumCol(obj)); col < 0 || col >= nc {
+		return fmt.Errorf("column %d is out of range [0, %d)", col, nc)
+	}
+	status := C.Highs_changeColBounds(obj, C.HighsInt(col), C.double(lb), C.double(ub))
+	return newCallStatus(status, "Highs_changeColBounds", "ChangeColBounds",
+		TraceAttr{"col", col})
+}
+
+// ChangeColsBoundsByRange replaces the lower and upper bounds of a
+// contiguous block of columns, starting with column firstCol.  As in
+// AddColumnBounds, a nil lb or ub is replaced with a slice of infinities.
+// Empty bounds are a no-op.
+func (m *RawModel) ChangeColsBoundsByRange(firstCol int, lb, ub []float64) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+
+	// Validate the arguments.
+	lb, ub, err = prepareBounds(lb, ub)
+	if err != nil || len(lb) == 0 {
+		return err
+	}
+	err = checkRange("column", firstCol, len(lb), int(C.Highs_getNumCol(obj)))
+	if err != nil {
+		return err
+	}
+
+	// Replace the bounds.
+	lower := convertSlice[C.double, float64](lb)
+	upper := convertSlice[C.double, float64](ub)
+	last := firstCol + len(lb) - 1
+	status := C.Highs_changeColsBoundsByRange(obj,
+		C.HighsInt(firstCol), C.HighsInt(last), &lower[0], &upper[0])
+	return newCallStatus(status, "Highs_changeColsBoundsByRange", "ChangeColsBoundsByRange",
+		TraceAttr{"from_col", firstCol}, TraceAttr{"to_col", last})
+}
+
+// ChangeColsBoundsBySet replaces the lower and upper bounds of an arbitrary
+// set of columns.  lb[i] and ub[i] are the new bounds of column cols[i].  As
+// in AddColumnBounds, a nil lb or ub is replaced with a slice of infinities.
+// The columns may appear in any order but not more than once.
+func (m *RawModel) ChangeColsBoundsBySet(cols []int, lb, ub []float64) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+
+	// Validate the arguments.
+	if len(cols) == 0 {
+		return nil
+	}
+	lb, ub, err = prepareBounds(lb, ub)
+	if err != nil {
+		return err
+	}
+	if len(lb) != len(cols) {
+		return fmt.Errorf("expected %d bounds but saw %d", len(cols), len(lb))
+	}
+	set, perm, err := sortSet("column", cols, int(C.Highs_getNumCol(obj)))
+	if err != nil {
+		return err
+	}
+
+	// Replace the bounds.
+	lower := permuteValues(lb, perm)
+	upper := permuteValues(ub, perm)
+	status := C.Highs_changeColsBoundsBySet(obj, C.HighsInt(len(set)),
+		&set[0], &lower[0], &upper[0])
+	return newCallStatus(status, "Highs_changeColsBoundsBySet", "ChangeColsBoundsBySet",
+		TraceAttr{"num_set_entries", len(set)})
+}
+
+// ChangeRowBounds replaces the lower and upper bounds of a single row.
+// Together with ChangeRowsBoundsByRange, ChangeRowsBoundsBySet, and
+// ChangeRowsBoundsByMask, this lets an application update a model's
+// right-hand side in place, so HiGHS can hot-start the next solve from its
+// current basis.
+func (m *RawModel) ChangeRowBounds(row int, lb, ub float64) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+	if nr := int(C.Highs_getNumRow(obj)); row < 0 || row >= nr {
+		return fmt.Errorf("row %d is out of range [0, %d)", row, nr)
+	}
+	status := C.Highs_changeRowBounds(obj, C.HighsInt(row), C.double(lb), C.double(ub))
+	return newCallStatus(status, "Highs_changeRowBounds", "ChangeRowBounds",
+		TraceAttr{"row", row})
+}
+
+// ChangeRowsBoundsByRange replaces the lower and upper bounds of a
+// contiguous block of rows, starting with row firstRow.  A nil lb or ub is
+// replaced with a slice of infinities.  Empty bounds are a no-op.
+func (m *RawModel) ChangeRowsBoundsByRange(firstRow int, lb, ub []float64) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+
+	// Validate the arguments.
+	lb, ub, err = prepareBounds(lb, ub)
+	if err != nil || len(lb) == 0 {
+		return err
+	}
+	err = checkRange("row", firstRow, len(lb), int(C.Highs_getNumRow(obj)))
+	if err != nil {
+		return err
+	}
+
+	// Replace the bounds.
+	lower := convertSlice[C.double, float64](lb)
+	upper := convertSlice[C.double, float64](ub)
+	last := firstRow + len(lb) - 1
+	status := C.Highs_changeRowsBoundsByRange(obj,
+		C.HighsInt(firstRow), C.HighsInt(last), &lower[0], &upper[0])
+	return newCallStatus(status, "Highs_changeRowsBoundsByRange", "ChangeRowsBoundsByRange",
+		TraceAttr{"from_row", firstRow}, TraceAttr{"to_row", last})
+}
+
+// ChangeRowsBoundsBySet replaces the lower and upper bounds of an arbitrary
+// set of rows.  lb[i] and ub[i] are the new bounds of row rows[i].  A nil lb
+// or ub is replaced with a slice of infinities.  The rows may appear in any
+// order but not more than once.
+func (m *RawModel) ChangeRowsBoundsBySet(rows []int, lb, ub []float64) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+
+	// Validate the arguments.
+	if len(rows) == 0 {
+		return nil
+	}
+	lb, ub, err = prepareBounds(lb, ub)
+	if err != nil {
+		return err
+	}
+	if len(lb) != len(rows) {
+		return fmt.Errorf("expected %d bounds but saw %d", len(rows), len(lb))
+	}
+	set, perm, err := sortSet("row", rows, int(C.Highs_getNumRow(obj)))
+	if err != nil {
+		return err
+	}
+
+	// Replace the bounds.
+	lower := permuteValues(lb, perm)
+	upper := permuteValues(ub, perm)
+	status := C.Highs_changeRowsBoundsBySet(obj, C.HighsInt(len(set)),
+		&set[0], &lower[0], &upper[0])
+	return newCallStatus(status, "Highs_changeRowsBoundsBySet", "ChangeRowsBoundsBySet",
+		TraceAttr{"num_set_entries", len(set)})
+}
+
+// ChangeRowsBoundsByMask replaces the lower and upper bounds of each row r
+// for which mask[r] is true with lb[r] and ub[r].  mask must have one element
+// per row, as must lb and ub unless they are nil, in which case they are
+// replaced with slices of infinities.
+func (m *RawModel) ChangeRowsBoundsByMask(mask []bool, lb, ub []float64) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+
+	// Validate the arguments.
+	nr := int(C.Highs_getNumRow(obj))
+	if len(mask) != nr {
+		return fmt.Errorf("expected %d mask values but saw %d", nr, len(mask))
+	}
+	if nr == 0 {
+		return nil
+	}
+	lb, ub, err = prepareBounds(lb, ub)
+	if err != nil {
+		return err
+	}
+	if len(lb) != nr {
+		return fmt.Errorf("expected %d bounds but saw %d", nr, len(lb))
+	}
+
+	// Replace the bounds.
+	cMask := make([]C.HighsInt, nr)
+	for r, b := range mask {
+		if b {
+			cMask[r] = 1
+		}
+	}
+	lower := convertSlice[C.double, float64](lb)
+	upper := convertSlice[C.double, float64](ub)
+	status := C.Highs_changeRowsBoundsByMask(obj, &cMask[0], &lower[0], &upper[0])
+	return newCallStatus(status, "Highs_changeRowsBoundsByMask", "ChangeRowsBoundsByMask",
+		TraceAttr{"num_row", nr})
+}
+
+// ChangeColCost replaces the cost of a single column.
+func (m *RawModel) ChangeColCost(col int, cost float64) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+	if nc := int(C.Highs_getNumCol(obj)); col < 0 || col >= nc {
+		return fmt.Errorf("column %d is out of range [0, %d)", col, nc)
+	}
+	status := C.Highs_changeColCost(obj, C.HighsInt(col), C.double(cost))
+	return newCallStatus(status, "Highs_changeColCost", "ChangeColCost",
+		TraceAttr{"col", col})
+}
+
+// ChangeColsCostBySet replaces the costs of an arbitrary set of columns,
+// leaving the other columns' costs unchanged.  costs[i] is the new cost of
+// column cols[i].  The columns may appear in any order but not more than
+// once.  This supports selective objective updates, such as those of a
+// Lagrangian-relaxation subproblem, which SetColumnCostsRange cannot express
+// efficiently.
+func (m *RawModel) ChangeColsCostBySet(cols []int, costs []float64) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+
+	// Validate the arguments.
+	if len(cols) != len(costs) {
+		return fmt.Errorf("expected %d costs but saw %d", len(cols), len(costs))
+	}
+	if len(cols) == 0 {
+		return nil
+	}
+	set, perm, err := sortSet("column", cols, int(C.Highs_getNumCol(obj)))
+	if err != nil {
+		return err
+	}
+
+	// Replace the costs.
+	cost := permuteValues(costs, perm)
+	status := C.Highs_changeColsCostBySet(obj, C.HighsInt(len(set)),
+		&set[0], &cost[0])
+	return newCallStatus(status, "Highs_changeColsCostBySet", "ChangeColsCostBySet",
+		TraceAttr{"num_set_entries", len(set)})
+}
+
+// A ColumnData holds the costs, bounds, and constraint-matrix entries of a
+// selection of a model's columns.  Costs, Lower, and Upper have one element
+// per selected column, in the order in which the columns were requested.
+// Entries lists the selected columns' nonzeros in ascending column order,
+// with Row and Col holding each entry's indices in the model.
+type ColumnData struct {
+	Costs   []float64 // Cost of each column
+	Lower   []float64 // Lower bound of each column
+	Upper   []float64 // Upper bound of each column
+	Entries []Nonzero // Nonzero constraint-matrix entries
+}
+
+// colsGetter abstracts Highs_getColsByRange and Highs_getColsBySet.
+type colsGetter func(numCol *C.HighsInt, costs, lower, upper *C.double,
+	numNz *C.HighsInt, start, index *C.HighsInt, value *C.double) C.HighsInt
+
+// getColumnData uses a colsGetter to retrieve the data for the given
+// columns, which must be in ascending order.  cName and goName name the HiGHS
+// function and the calling method for use in error messages.  The caller
+// must hold the model's lock.
+func getColumnData(cols []C.HighsInt, get colsGetter, cName, goName string) (ColumnData, error) {
+	// Query the costs, bounds, and number of nonzeros.
+	n := len(cols)
+	costs := make([]C.double, n)
+	lower := make([]C.double, n)
+	upper := make([]C.double, n)
+	var numCol, numNz C.HighsInt
+	status := get(&numCol, &costs[0], &lower[0], &upper[0], &numNz, nil, nil, nil)
+	err := newCallStatus(status, cName, goName)
+	if err != nil {
+		return ColumnData{}, err
+	}
+	cd := ColumnData{
+		Costs: convertSlice[float64, C.double](costs),
+		Lower: convertSlice[float64, C.double](lower),
+		Upper: convertSlice[float64, C.double](upper),
+	}
+	if numNz == 0 {
+		return cd, nil
+	}
+
+	// Query the nonzeros.
+	start := make([]C.HighsInt, n)
+	index := make([]C.HighsInt, numNz)
+	value := make([]C.double, numNz)
+	status = get(&numCol, &costs[0], &lower[0], &upper[0], &numNz,
+		&start[0], &index[0], &value[0])
+	err = newCallStatus(status, cName, goName)
+	if err != nil {
+		return ColumnData{}, err
+	}
+	cd.Entries = make([]Nonzero, 0, numNz)
+	for i, c := range cols {
+		end := numNz
+		if i+1 < n {
+			end = start[i+1]
+		}
+		for k := start[i]; k < end; k++ {
+			cd.Entries = append(cd.Entries, Nonzero{int(index[k]), int(c), float64(value[k])})
+		}
+	}
+	return cd, nil
+}
+
+// GetColsByRange returns the costs, bounds, and constraint-matrix entries of
+// columns [first, end).  This makes it possible to audit what HiGHS actually
+// holds, for example after reading a model from an MPS file.
+func (m *RawModel) GetColsByRange(first, end int) (ColumnData, error) {
+	obj, err := m.lock()
+	if err != nil {
+		return ColumnData{}, err
+	}
+	defer m.unlock()
+	if end <= first {
+		return ColumnData{}, nil
+	}
+	err = checkRange("column", first, end-first, int(C.Highs_getNumCol(obj)))
+	if err != nil {
+		return ColumnData{}, err
+	}
+	cols := make([]C.HighsInt, end-first)
+	for i := range cols {
+		cols[i] = C.HighsInt(first + i)
+	}
+	get := func(numCol *C.HighsInt, costs, lower, upper *C.double,
+		numNz *C.HighsInt, start, index *C.HighsInt, value *C.double) C.HighsInt {
+		return C.Highs_getColsByRange(obj, C.HighsInt(first), C.HighsInt(end-1),
+			numCol, costs, lower, upper, numNz, start, index, value)
+	}
+	return getColumnData(cols, get, "Highs_getColsByRange", "GetColsByRange")
+}
+
+// GetColsBySet is like GetColsByRange but returns the data for an arbitrary
+// set of columns.  The columns may appear in any order but not more than
+// once.
+func (m *RawModel) GetColsBySet(cols []int) (ColumnData, error) {
+	obj, err := m.lock()
+	if err != nil {
+		return ColumnData{}, err
+	}
+	defer m.unlock()
+	if len(cols) == 0 {
+		return ColumnData{}, nil
+	}
+	set, perm, err := sortSet("column", cols, int(C.Highs_getNumCol(obj)))
+	if err != nil {
+		return ColumnData{}, err
+	}
+	get := func(numCol *C.HighsInt, costs, lower, upper *C.double,
+		numNz *C.HighsInt, start, index *C.HighsInt, value *C.double) C.HighsInt {
+		return C.Highs_getColsBySet(obj, C.HighsInt(len(set)), &set[0],
+			numCol, costs, lower, upper, numNz, start, index, value)
+	}
+	cd, err := getColumnData(set, get, "Highs_getColsBySet", "GetColsBySet")
+	if err != nil {
+		return ColumnData{}, err
+	}
+
+	// Restore the caller's column order.
+	for _, xs := range [][]float64{cd.Costs, cd.Lower, cd.Upper} {
+		sorted := append([]float64(nil), xs...)
+		for i, p := range perm {
+			xs[p] = sorted[i]
+		}
+	}
+	return cd, nil
+}
@@ -0,0 +1,573 @@
+// This file provides a pure-Go reader for models in MPS format.
+
+package highs
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// MPSReadOptions specifies how ReadMPSOptions treats deviations from the MPS
+// conventions that are common in files written by other tools.
+type MPSReadOptions struct {
+	Strict bool // true=reject dialect quirks with an error; false=accept them with a warning
+}
+
+// An MPSWarning describes a dialect quirk that ReadMPSOptions accepted and
+// how it interpreted it.
+type MPSWarning struct {
+	Line    int    // 1-based line number at which the quirk appears
+	Message string // Description of the quirk
+}
+
+// String returns an MPSWarning as a human-readable string.
+func (w MPSWarning) String() string {
+	return fmt.Sprintf("line %d: %s", w.Line, w.Message)
+}
+
+// An mpsReader accumulates a model from the lines of an MPS file.
+type mpsReader struct {
+	strict   bool            // true=quirks are errors; false=quirks are warnings
+	line     int             // Current line number
+	warnings []MPSWarning    // Quirks accepted so far
+	m        Model           // Model being constructed
+	objName  string          // Name of the objective row
+	freeRows map[string]bool // Names of additional N rows, which are discarded
+	rowIdx   map[string]int  // Map from row name to index
+	rowTypes []string        // Type (E, L, or G) of each row
+	rhs      []float64       // Right-hand side of each row
+	rng      []float64       // Range of each row
+	rhsSeen  []bool          // Whether each row has a right-hand side
+	rngSeen  []bool          // Whether each row has a range
+	colIdx   map[string]int  // Map from column name to index
+	prevCol  string          // Most recently read column in the COLUMNS section
+	inInt    bool            // Whether the COLUMNS section is within integer markers
+	costSeen []bool          // Whether each column has an objective coefficient
+	loSeen   []bool          // Whether each column has an explicit lower bound
+	upSeen   []bool          // Whether each column has an explicit upper bound
+	negUp    map[int]int     // Map from column to the line that gave it a negative upper bound
+	nzIdx    map[[2]int]int  // Map from (row, column) to index in ConstMatrix
+	hessIdx  map[[2]int]int  // Map from (row, column) to index in HessianMatrix
+	objSeen  bool            // Whether the objective row has a right-hand side
+}
+
+// errorf returns an error that reports the current line number.
+func (mr *mpsReader) errorf(format string, args ...any) error {
+	return fmt.Errorf("line %d: %s", mr.line, fmt.Sprintf(format, args...))
+}
+
+// quirk reports a dialect quirk on the current line.  In strict mode, quirk
+// returns an error.  Otherwise, it records a warning and returns nil.
+func (mr *mpsReader) quirk(format string, args ...any) error {
+	if mr.strict {
+		return mr.errorf(format, args...)
+	}
+	mr.warnings = append(mr.warnings, MPSWarning{
+		Line:    mr.line,
+		Message: fmt.Sprintf(format, args...),
+	})
+	return nil
+}
+
+// number parses a numeric field.  Magnitudes HiGHS treats as infinite are
+// converted to infinities.
+func (mr *mpsReader) number(s string) (float64, error) {
+	v, err := strconv.ParseFloat(s, 64)
+	switch {
+	case err != nil:
+		return 0.0, mr.errorf("%q is not a valid number", s)
+	case v >= highsInfinity:
+		return math.Inf(1), nil
+	case v <= -highsInfinity:
+		return math.Inf(-1), nil
+	default:
+		return v, nil
+	}
+}
+
+// objSense processes the objective sense.
+func (mr *mpsReader) objSense(s string) error {
+	switch strings.ToUpper(s) {
+	case "MAX", "MAXIMIZE":
+		mr.m.Maximize = true
+	case "MIN", "MINIMIZE":
+		mr.m.Maximize = false
+	default:
+		return mr.errorf("%q is not a valid objective sense", s)
+	}
+	return nil
+}
+
+// row processes a line of the ROWS section.
+func (mr *mpsReader) row(fields []string) error {
+	if len(fields) != 2 {
+		return mr.errorf("expected a row type and name")
+	}
+	typ, name := strings.ToUpper(fields[0]), fields[1]
+	_, dup := mr.rowIdx[name]
+	if dup || name == mr.objName || mr.freeRows[name] {
+		return mr.quirk("row %s is declared more than once; ignoring the redeclaration", name)
+	}
+	switch typ {
+	case "N":
+		// As HiGHS does by default, treat the first N row as the
+		// objective function and discard all others.
+		if mr.objName == "" {
+			mr.objName = name
+		} else {
+			mr.freeRows[name] = true
+		}
+	case "E", "L", "G":
+		mr.rowIdx[name] = len(mr.rowTypes)
+		mr.rowTypes = append(mr.rowTypes, typ)
+		mr.rhs = append(mr.rhs, 0.0)
+		mr.rng = append(mr.rng, 0.0)
+		mr.rhsSeen = append(mr.rhsSeen, false)
+		mr.rngSeen = append(mr.rngSeen, false)
+		mr.m.RowNames = append(mr.m.RowNames, name)
+	default:
+		return mr.errorf("%q is not a valid row type", fields[0])
+	}
+	return nil
+}
+
+// column processes a line of the COLUMNS section.
+func (mr *mpsReader) column(fields []string) error {
+	// Handle integer markers.
+	if len(fields) >= 3 && strings.ToUpper(fields[1]) == "'MARKER'" {
+		switch strings.ToUpper(fields[2]) {
+		case "'INTORG'":
+			mr.inInt = true
+		case "'INTEND'":
+			mr.inInt = false
+		default:
+			return mr.errorf("%s is not a valid marker", fields[2])
+		}
+		return nil
+	}
+	if len(fields) != 3 && len(fields) != 5 {
+		return mr.errorf("expected a column name followed by one or two row names and values")
+	}
+
+	// Add the column if it is new.
+	name := fields[0]
+	c, ok := mr.colIdx[name]
+	switch {
+	case !ok:
+		c = len(mr.m.ColNames)
+		mr.colIdx[name] = c
+		mr.m.ColNames = append(mr.m.ColNames, name)
+		mr.m.ColCosts = append(mr.m.ColCosts, 0.0)
+		mr.m.ColLower = append(mr.m.ColLower, 0.0)
+		mr.m.ColUpper = append(mr.m.ColUpper, math.Inf(1))
+		vt := ContinuousType
+		if mr.inInt {
+			vt = IntegerType
+		}
+		mr.m.VarTypes = append(mr.m.VarTypes, vt)
+		mr.costSeen = append(mr.costSeen, false)
+		mr.loSeen = append(mr.loSeen, false)
+		mr.upSeen = append(mr.upSeen, false)
+	case name != mr.prevCol:
+		err := mr.quirk("entries for column %s are not contiguous", name)
+		if err != nil {
+			return err
+		}
+	}
+	mr.prevCol = name
+
+	// Store each coefficient.
+	for i := 1; i < len(fields); i += 2 {
+		rName := fields[i]
+		v, err := mr.number(fields[i+1])
+		if err != nil {
+			return err
+		}
+		switch r, ok := mr.rowIdx[rName]; {
+		case rName == mr.objName:
+			if mr.costSeen[c] {
+				err = mr.quirk("column %s has more than one objective coefficient; keeping the later one", name)
+			}
+			mr.m.ColCosts[c] = v
+			mr.costSeen[c] = true
+		case mr.freeRows[rName]:
+		case !ok:
+			err = mr.quirk("column %s refers to undeclared row %s; ignoring the entry", name, rName)
+		default:
+			rc := [2]int{r, c}
+			if k, dup := mr.nzIdx[rc]; dup {
+				err = mr.quirk("column %s has more than one coefficient in row %s; keeping the later one", name, rName)
+				mr.m.ConstMatrix[k].Val = v
+				break
+			}
+			mr.nzIdx[rc] = len(mr.m.ConstMatrix)
+			mr.m.ConstMatrix = append(mr.m.ConstMatrix, Nonzero{Row: r, Col: c, Val: v})
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rowValues processes a line of the RHS or RANGES section, invoking a
+// function on each row and value it specifies.  The set name that begins
+// each line is optional.
+func (mr *mpsReader) rowValues(fields []string, fn func(rName string, v float64) error) error {
+	switch len(fields) {
+	case 3, 5:
+		fields = fields[1:]
+	case 2, 4:
+	default:
+		return mr.errorf("expected an optional set name followed by one or two row names and values")
+	}
+	for i := 0; i < len(fields); i += 2 {
+		v, err := mr.number(fields[i+1])
+		if err != nil {
+			return err
+		}
+		err = fn(fields[i], v)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rhsValue processes a single right-hand side.
+func (mr *mpsReader) rhsValue(rName string, v float64) error {
+	r, ok := mr.rowIdx[rName]
+	switch {
+	case rName == mr.objName:
+		// The objective row's right-hand side is the negated
+		// objective offset.
+		var err error
+		if mr.objSeen {
+			err = mr.quirk("the objective row has more than one right-hand side; keeping the later one")
+		}
+		mr.m.Offset = -v
+		mr.objSeen = true
+		return err
+	case mr.freeRows[rName]:
+		return nil
+	case !ok:
+		return mr.quirk("right-hand side refers to undeclared row %s; ignoring the entry", rName)
+	}
+	var err error
+	if mr.rhsSeen[r] {
+		err = mr.quirk("row %s has more than one right-hand side; keeping the later one", rName)
+	}
+	mr.rhs[r] = v
+	mr.rhsSeen[r] = true
+	return err
+}
+
+// rangeValue processes a single range.
+func (mr *mpsReader) rangeValue(rName string, v float64) error {
+	r, ok := mr.rowIdx[rName]
+	switch {
+	case rName == mr.objName || mr.freeRows[rName]:
+		return mr.quirk("range refers to N row %s; ignoring the entry", rName)
+	case !ok:
+		return mr.quirk("range refers to undeclared row %s; ignoring the entry", rName)
+	}
+	if mr.rngSeen[r] {
+		err := mr.quirk("row %s has more than one range; keeping the later one", rName)
+		if err != nil {
+			return err
+		}
+	}
+	if v < 0.0 && mr.rowTypes[r] != "E" {
+		err := mr.quirk("%s row %s has a negative range; using its absolute value", mr.rowTypes[r], rName)
+		if err != nil {
+			return err
+		}
+	}
+	mr.rng[r] = v
+	mr.rngSeen[r] = true
+	return nil
+}
+
+// bound processes a line of the BOUNDS section.  The set name that follows
+// the bound type is optional.
+func (mr *mpsReader) bound(fields []string) error {
+	// Determine the bound type, column, and value.
+	typ := strings.ToUpper(fields[0])
+	var cName, vStr string
+	switch typ {
+	case "UP", "LO", "FX", "LI", "UI", "SC":
+		switch len(fields) {
+		case 3:
+			cName, vStr = fields[1], fields[2]
+		case 4:
+			cName, vStr = fields[2], fields[3]
+		default:
+			return mr.errorf("expected an optional set name followed by a column name and value")
+		}
+	case "FR", "MI", "PL", "BV":
+		switch len(fields) {
+		case 2:
+			cName = fields[1]
+		case 3, 4:
+			// Some writers include a value, which is ignored.
+			cName = fields[2]
+		default:
+			return mr.errorf("expected an optional set name followed by a column name")
+		}
+	default:
+		return mr.errorf("%q is not a valid bound type", fields[0])
+	}
+	c, ok := mr.colIdx[cName]
+	if !ok {
+		return mr.quirk("bound refers to undeclared column %s; ignoring the entry", cName)
+	}
+	v := 0.0
+	if vStr != "" {
+		var err error
+		v, err = mr.number(vStr)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Apply the bound.
+	m := &mr.m
+	setLo, setUp := false, false
+	switch typ {
+	case "UP", "UI", "SC":
+		m.ColUpper[c] = v
+		setUp = true
+		if v < 0.0 && !mr.loSeen[c] && m.ColLower[c] == 0.0 {
+			mr.negUp[c] = mr.line
+		}
+	case "LO", "LI":
+		m.ColLower[c] = v
+		setLo = true
+	case "FX":
+		m.ColLower[c], m.ColUpper[c] = v, v
+		setLo, setUp = true, true
+	case "FR":
+		m.ColLower[c], m.ColUpper[c] = math.Inf(-1), math.Inf(1)
+		setLo, setUp = true, true
+	case "MI":
+		m.ColLower[c] = math.Inf(-1)
+		setLo = true
+	case "PL":
+		m.ColUpper[c] = math.Inf(1)
+		setUp = true
+	case "BV":
+		m.ColLower[c], m.ColUpper[c] = 0.0, 1.0
+		setLo, setUp = true, true
+	}
+	switch typ {
+	case "LI", "UI", "BV":
+		m.VarTypes[c] = IntegerType
+	case "SC":
+		m.VarTypes[c] = SemiContinuousType
+	}
+
+	// Complain about repeated bounds.
+	var err error
+	switch {
+	case setLo && mr.loSeen[c]:
+		err = mr.quirk("column %s has more than one lower bound; keeping the later one", cName)
+	case setUp && mr.upSeen[c]:
+		err = mr.quirk("column %s has more than one upper bound; keeping the later one", cName)
+	}
+	mr.loSeen[c] = mr.loSeen[c] || setLo
+	mr.upSeen[c] = mr.upSeen[c] || setUp
+	return err
+}
+
+// quadObj processes a line of the QUADOBJ section.
+func (mr *mpsReader) quadObj(fields []string) error {
+	if len(fields) != 3 {
+		return mr.errorf("expected two column names and a value")
+	}
+	v, err := mr.number(fields[2])
+	if err != nil {
+		return err
+	}
+	i, ok1 := mr.colIdx[fields[0]]
+	j, ok2 := mr.colIdx[fields[1]]
+	if !ok1 || !ok2 {
+		return mr.quirk("Hessian entry refers to an undeclared column; ignoring the entry")
+	}
+	if i > j {
+		i, j = j, i
+	}
+	rc := [2]int{i, j}
+	if k, dup := mr.hessIdx[rc]; dup {
+		mr.m.HessianMatrix[k].Val = v
+		return mr.quirk("columns %s and %s have more than one Hessian entry; keeping the later one",
+			fields[0], fields[1])
+	}
+	mr.hessIdx[rc] = len(mr.m.HessianMatrix)
+	mr.m.HessianMatrix = append(mr.m.HessianMatrix, Nonzero{Row: i, Col: j, Val: v})
+	return nil
+}
+
+// finish computes the row bounds from the right-hand sides and ranges and
+// resolves negative upper bounds that lack a lower bound.
+func (mr *mpsReader) finish() error {
+	// Convert right-hand sides and ranges to row bounds.
+	m := &mr.m
+	nr := len(mr.rowTypes)
+	m.RowLower = make([]float64, nr)
+	m.RowUpper = make([]float64, nr)
+	for r, typ := range mr.rowTypes {
+		rhs, rng := mr.rhs[r], mr.rng[r]
+		switch {
+		case typ == "E" && rng >= 0.0:
+			m.RowLower[r], m.RowUpper[r] = rhs, rhs+rng
+		case typ == "E":
+			m.RowLower[r], m.RowUpper[r] = rhs+rng, rhs
+		case typ == "L":
+			m.RowLower[r], m.RowUpper[r] = math.Inf(-1), rhs
+			if mr.rngSeen[r] {
+				m.RowLower[r] = rhs - math.Abs(rng)
+			}
+		default:
+			m.RowLower[r], m.RowUpper[r] = rhs, math.Inf(1)
+			if mr.rngSeen[r] {
+				m.RowUpper[r] = rhs + math.Abs(rng)
+			}
+		}
+	}
+
+	// As HiGHS does, interpret a negative upper bound on a column with no
+	// explicit lower bound as implying a lower bound of -∞.  Report these
+	// in column order.
+	for c, name := range m.ColNames {
+		ln, ok := mr.negUp[c]
+		if !ok || mr.loSeen[c] {
+			continue
+		}
+		mr.line = ln
+		err := mr.quirk("column %s has a negative upper bound but no lower bound; setting the lower bound to -infinity", name)
+		if err != nil {
+			return err
+		}
+		m.ColLower[c] = math.Inf(-1)
+	}
+
+	// Omit the variable types if all columns are continuous.
+	allCont := true
+	for _, vt := range m.VarTypes {
+		allCont = allCont && vt == ContinuousType
+	}
+	if allCont {
+		m.VarTypes = nil
+	}
+	return nil
+}
+
+// ReadMPS replaces the model with one read from an io.Reader in MPS format.
+// Unlike ReadModelFormat, ReadMPS does not require cgo.  It accepts common
+// dialect quirks and returns a warning for each.
+func (m *Model) ReadMPS(r io.Reader) ([]MPSWarning, error) {
+	return m.ReadMPSOptions(r, MPSReadOptions{})
+}
+
+// ReadMPSOptions is like ReadMPS but lets the caller reject dialect quirks.
+// Fields are separated by whitespace, so both free-format files and
+// fixed-format files whose names contain no spaces are accepted.  The
+// supported sections are NAME, OBJSENSE, ROWS, COLUMNS, RHS, RANGES, BOUNDS,
+// QUADOBJ, and ENDATA.  As in HiGHS, the first N row is the objective
+// function, and any other N rows are discarded.
+//
+// The following quirks are errors in strict mode and warnings otherwise:
+// duplicate row declarations, coefficients, right-hand sides, ranges, bounds,
+// and Hessian entries (the later value is kept); entries that refer to
+// undeclared rows or columns (the entry is ignored); ranges on N rows (the
+// range is ignored); negative ranges on L and G rows (the absolute value is
+// used); noncontiguous entries for a column; a negative upper bound on a
+// column with no lower bound (the lower bound becomes −∞, as in HiGHS); and a
+// missing ENDATA line.  In strict mode, no warnings are returned.
+func (m *Model) ReadMPSOptions(r io.Reader, opts MPSReadOptions) ([]MPSWarning, error) {
+	mr := &mpsReader{
+		strict:   opts.Strict,
+		freeRows: make(map[string]bool),
+		rowIdx:   make(map[string]int),
+		colIdx:   make(map[string]int),
+		negUp:    make(map[int]int),
+		nzIdx:    make(map[[2]int]int),
+		hessIdx:  make(map[[2]int]int),
+	}
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 1<<24)
+	section := ""
+	ended := false
+	for !ended && sc.Scan() {
+		// Skip blank lines and comments.
+		mr.line++
+		text := sc.Text()
+		fields := strings.Fields(text)
+		if len(fields) == 0 || text[0] == '*' {
+			continue
+		}
+
+		// Section headers begin in the first column.
+		var err error
+		if text[0] != ' ' && text[0] != '\t' {
+			section = strings.ToUpper(fields[0])
+			switch section {
+			case "NAME", "ROWS", "COLUMNS", "RHS", "RANGES", "BOUNDS", "QUADOBJ":
+			case "OBJSENSE":
+				if len(fields) > 1 {
+					err = mr.objSense(fields[1])
+				}
+			case "ENDATA":
+				ended = true
+			default:
+				err = mr.errorf("section %s is not supported", fields[0])
+			}
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		// Process a data line.
+		switch section {
+		case "OBJSENSE":
+			err = mr.objSense(fields[0])
+		case "ROWS":
+			err = mr.row(fields)
+		case "COLUMNS":
+			err = mr.column(fields)
+		case "RHS":
+			err = mr.rowValues(fields, mr.rhsValue)
+		case "RANGES":
+			err = mr.rowValues(fields, mr.rangeValue)
+		case "BOUNDS":
+			err = mr.bound(fields)
+		case "QUADOBJ":
+			err = mr.quadObj(fields)
+		default:
+			err = mr.errorf("unexpected data line in section %s", section)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	// Finish constructing the model.
+	if !ended {
+		if err := mr.quirk("missing ENDATA"); err != nil {
+			return nil, err
+		}
+	}
+	if err := mr.finish(); err != nil {
+		return nil, err
+	}
+	*m = mr.m
+	return mr.warnings, nil
+}
@@ -0,0 +1,304 @@
+// This file provides a pure-Go writer for models in MPS format.
+
+package highs
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// mpsInfinity is the magnitude written to an MPS file to represent an
+// infinite row bound.  HiGHS treats any bound whose magnitude is at least its
+// infinite_bound option (default 1e20) as infinite.
+const mpsInfinity = 1e30
+
+// fmtMPS formats a floating-point number for inclusion in an MPS file.
+func fmtMPS(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		v = mpsInfinity
+	case math.IsInf(v, -1):
+		v = -mpsInfinity
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// fmtMPSFixed formats a floating-point number to fit within the 12-character
+// numeric fields of a fixed-format MPS file, sacrificing precision if
+// necessary.
+func fmtMPSFixed(v float64) string {
+	str := fmtMPS(v)
+	for prec := 12; len(str) > 12 && prec > 0; prec-- {
+		str = strconv.FormatFloat(v, 'g', prec, 64)
+	}
+	return str
+}
+
+// MPSOptions specifies the dialect of MPS that WriteMPSOptions writes.
+type MPSOptions struct {
+	Fixed           bool // true=fixed format; false=free format
+	NegateObjective bool // true=write maximization problems as minimization problems with a negated objective function
+}
+
+// An mpsWriter writes lines of an MPS file in either fixed or free format.
+type mpsWriter struct {
+	bw    *bufio.Writer
+	fixed bool
+}
+
+// mpsFieldCols lists the 1-based starting column of each of the six fields
+// of a fixed-format MPS line.
+var mpsFieldCols = [6]int{2, 5, 15, 25, 40, 50}
+
+// line writes a single data line comprising a code (e.g., "E" or "UP") and
+// up to five additional fields, the second and fourth of which are numeric.
+// Empty fields are omitted from free-format output.
+func (mw *mpsWriter) line(code string, fields ...string) {
+	if mw.fixed {
+		var sb strings.Builder
+		for i, f := range append([]string{code}, fields...) {
+			if f == "" {
+				continue
+			}
+			for sb.Len() < mpsFieldCols[i]-1 {
+				sb.WriteByte(' ')
+			}
+			sb.WriteString(f)
+		}
+		fmt.Fprintln(mw.bw, sb.String())
+		return
+	}
+	nonempty := make([]string, 0, len(fields))
+	for _, f := range fields[1:] {
+		if f != "" {
+			nonempty = append(nonempty, f)
+		}
+	}
+	fmt.Fprintf(mw.bw, " %-2s %s", code, fields[0])
+	for _, f := range nonempty {
+		fmt.Fprintf(mw.bw, "  %s", f)
+	}
+	fmt.Fprintln(mw.bw)
+}
+
+// num formats a number for the writer's format.
+func (mw *mpsWriter) num(v float64) string {
+	if mw.fixed {
+		return fmtMPSFixed(v)
+	}
+	return fmtMPS(v)
+}
+
+// WriteMPS writes a model to an io.Writer in free-format MPS.  Columns are
+// named C0, C1, …, rows are named R0, R1, …, and the objective row is named
+// Obj.  Unlike RawModel.WriteModel, WriteMPS does not require cgo.  It
+// supports only continuous and integer variables; implicit-integer variables
+// are written as integers.
+func (m *Model) WriteMPS(w io.Writer) error {
+	return m.WriteMPSOptions(w, MPSOptions{})
+}
+
+// WriteMPSOptions is like WriteMPS but lets the caller select the MPS
+// dialect.  Fixed-format output is limited to fewer than 10⁷ rows and
+// columns, and each number is rounded to fit in 12 characters.  Because some
+// older readers do not recognize the OBJSENSE section, maximization problems
+// can instead be written as minimization problems with a negated objective
+// function (and offset), in which case the reader's optimal objective value
+// is the negation of the original model's.
+func (m *Model) WriteMPSOptions(w io.Writer, opts MPSOptions) error {
+	// MPS cannot express soft rows.
+	if m.hasSoftRows() {
+		return ErrSoftRows
+	}
+
+	// Fill in defaults as ToRawModel does.
+	nr, nc := m.modelSize()
+	mInf, pInf := math.Inf(-1), math.Inf(1)
+	var ok bool
+	var colCost, colLower, colUpper, rowLower, rowUpper []float64
+	var varTypes []VariableType
+	if colCost, ok = expandToLen(nc, m.ColCosts, 1.0); !ok {
+		return fmt.Errorf("inconsistent column counts")
+	}
+	if colLower, ok = expandToLen(nc, m.ColLower, mInf); !ok {
+		return fmt.Errorf("inconsistent column counts")
+	}
+	if colUpper, ok = expandToLen(nc, m.ColUpper, pInf); !ok {
+		return fmt.Errorf("inconsistent column counts")
+	}
+	if rowLower, ok = expandToLen(nr, m.RowLower, mInf); !ok {
+		return fmt.Errorf("inconsistent row counts")
+	}
+	if rowUpper, ok = expandToLen(nr, m.RowUpper, pInf); !ok {
+		return fmt.Errorf("inconsistent row counts")
+	}
+	if varTypes, ok = expandToLen(nc, m.VarTypes, ContinuousType); !ok {
+		return fmt.Errorf("inconsistent column counts")
+	}
+	for c, vt := range varTypes {
+		switch vt {
+		case ContinuousType, IntegerType, ImplicitIntegerType:
+		default:
+			return fmt.Errorf("column %d has type %s, which WriteMPS does not support", c, vt)
+		}
+	}
+
+	// Sort the constraint matrix by column then row.
+	nzs, err := filterNonzeros(m.ConstMatrix, false)
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(nzs, func(i, j int) bool {
+		if nzs[i].Col != nzs[j].Col {
+			return nzs[i].Col < nzs[j].Col
+		}
+		return nzs[i].Row < nzs[j].Row
+	})
+	hess, err := filterNonzeros(m.HessianMatrix, true)
+	if err != nil {
+		return err
+	}
+
+	// Fixed-format names are limited to eight characters.
+	if opts.Fixed && (nr >= 10000000 || nc >= 10000000) {
+		return fmt.Errorf("fixed-format MPS supports at most 9999999 rows and columns")
+	}
+
+	// Optionally convert maximization to minimization.
+	maximize, offset := m.Maximize, m.Offset
+	if maximize && opts.NegateObjective {
+		maximize = false
+		offset = -offset
+		negCost := make([]float64, nc)
+		for c, v := range colCost {
+			negCost[c] = -v
+		}
+		colCost = negCost
+		for i := range hess {
+			hess[i].Val = -hess[i].Val
+		}
+	}
+
+	// Write the header and the objective sense.
+	mw := &mpsWriter{bw: bufio.NewWriter(w), fixed: opts.Fixed}
+	bw := mw.bw
+	fmt.Fprintln(bw, "NAME")
+	if maximize {
+		fmt.Fprintln(bw, "OBJSENSE")
+		mw.line("", "MAX")
+	}
+
+	// Write the row types.  A row with two finite, unequal bounds is
+	// written as a G row with a range.  A free row is written as a G row
+	// with an infinite right-hand side so that HiGHS does not discard it.
+	fmt.Fprintln(bw, "ROWS")
+	mw.line("N", "Obj")
+	for r := 0; r < nr; r++ {
+		lb, ub := rowLower[r], rowUpper[r]
+		name := fmt.Sprintf("R%d", r)
+		switch {
+		case lb == ub:
+			mw.line("E", name)
+		case math.IsInf(lb, -1) && !math.IsInf(ub, 1):
+			mw.line("L", name)
+		default:
+			mw.line("G", name)
+		}
+	}
+
+	// Write the objective function and constraint matrix column by
+	// column, bracketing integer columns with markers.
+	fmt.Fprintln(bw, "COLUMNS")
+	inInt := false
+	k := 0
+	for c := 0; c < nc; c++ {
+		isInt := varTypes[c] != ContinuousType
+		if isInt != inInt {
+			if isInt {
+				mw.line("", "MARKER", "'MARKER'", "", "'INTORG'")
+			} else {
+				mw.line("", "MARKER", "'MARKER'", "", "'INTEND'")
+			}
+			inInt = isInt
+		}
+		name := fmt.Sprintf("C%d", c)
+		mw.line("", name, "Obj", mw.num(colCost[c]))
+		for ; k < len(nzs) && nzs[k].Col == c; k++ {
+			mw.line("", name, fmt.Sprintf("R%d", nzs[k].Row), mw.num(nzs[k].Val))
+		}
+	}
+	if inInt {
+		mw.line("", "MARKER", "'MARKER'", "", "'INTEND'")
+	}
+
+	// Write the right-hand sides and ranges.  The objective row's
+	// right-hand side is the negated objective offset.
+	fmt.Fprintln(bw, "RHS")
+	if offset != 0.0 {
+		mw.line("", "RHS", "Obj", mw.num(-offset))
+	}
+	var ranges [][2]string
+	for r := 0; r < nr; r++ {
+		lb, ub := rowLower[r], rowUpper[r]
+		name := fmt.Sprintf("R%d", r)
+		switch {
+		case lb == ub:
+			mw.line("", "RHS", name, mw.num(lb))
+		case math.IsInf(lb, -1) && !math.IsInf(ub, 1):
+			mw.line("", "RHS", name, mw.num(ub))
+		default:
+			mw.line("", "RHS", name, mw.num(lb))
+			if !math.IsInf(lb, -1) && !math.IsInf(ub, 1) {
+				ranges = append(ranges, [2]string{name, mw.num(ub - lb)})
+			}
+		}
+	}
+	if len(ranges) > 0 {
+		fmt.Fprintln(bw, "RANGES")
+		for _, rng := range ranges {
+			mw.line("", "RNG", rng[0], rng[1])
+		}
+	}
+
+	// Write every column's bounds explicitly.  Upper bounds precede lower
+	// bounds because some readers reinterpret a negative upper bound as
+	// implying a lower bound of −∞ unless a lower bound has already been
+	// specified.
+	fmt.Fprintln(bw, "BOUNDS")
+	for c := 0; c < nc; c++ {
+		lb, ub := colLower[c], colUpper[c]
+		name := fmt.Sprintf("C%d", c)
+		switch {
+		case lb == ub:
+			mw.line("FX", "BND", name, mw.num(lb))
+		case math.IsInf(lb, -1) && math.IsInf(ub, 1):
+			mw.line("FR", "BND", name)
+		default:
+			if math.IsInf(ub, 1) {
+				mw.line("PL", "BND", name)
+			} else {
+				mw.line("UP", "BND", name, mw.num(ub))
+			}
+			if math.IsInf(lb, -1) {
+				mw.line("MI", "BND", name)
+			} else {
+				mw.line("LO", "BND", name, mw.num(lb))
+			}
+		}
+	}
+
+	// Write the upper triangle of the Hessian matrix, if any.
+	if len(hess) > 0 {
+		fmt.Fprintln(bw, "QUADOBJ")
+		for _, nz := range hess {
+			mw.line("", fmt.Sprintf("C%d", nz.Row), fmt.Sprintf("C%d", nz.Col), mw.num(nz.Val))
+		}
+	}
+	fmt.Fprintln(bw, "ENDATA")
+	return bw.Flush()
+}
@@ -0,0 +1,228 @@
+// This file tests the pure-Go MPS reader and writer.
+
+package highs
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestWriteMPS writes the following model in MPS format:
+//
+//	Max. 2*x_0 + x_1 + 3
+//	s.t.  x_0 + x_1 = 10
+//	      x_0 - x_1 <= 4
+//	  2 <= x_1      <= 8
+//	with  0 <= x_0 <= 25, x_1 free and integer
+func TestWriteMPS(t *testing.T) {
+	// Prepare the model.
+	var model Model
+	model.Maximize = true
+	model.Offset = 3.0
+	model.ColCosts = []float64{2.0, 1.0}
+	model.ColLower = []float64{0.0, math.Inf(-1)}
+	model.ColUpper = []float64{25.0, math.Inf(1)}
+	model.VarTypes = []VariableType{ContinuousType, IntegerType}
+	model.AddDenseRow(10.0, []float64{1.0, 1.0}, 10.0)
+	model.AddDenseRow(math.Inf(-1), []float64{1.0, -1.0}, 4.0)
+	model.AddDenseRow(2.0, []float64{0.0, 1.0}, 8.0)
+
+	// Write the model to a buffer.
+	var buf bytes.Buffer
+	checkErr(t, model.WriteMPS(&buf))
+
+	// Compare to the expected contents.
+	exp := `NAME
+OBJSENSE
+    MAX
+ROWS
+ N  Obj
+ E  R0
+ L  R1
+ G  R2
+COLUMNS
+    C0  Obj  2
+    C0  R0  1
+    C0  R1  1
+    MARKER  'MARKER'  'INTORG'
+    C1  Obj  1
+    C1  R0  1
+    C1  R1  -1
+    C1  R2  1
+    MARKER  'MARKER'  'INTEND'
+RHS
+    RHS  Obj  -3
+    RHS  R0  10
+    RHS  R1  4
+    RHS  R2  2
+RANGES
+    RNG  R2  6
+BOUNDS
+ UP BND  C0  25
+ LO BND  C0  0
+ FR BND  C1
+ENDATA
+`
+	if buf.String() != exp {
+		t.Logf("Expected: %q", exp)
+		t.Logf("Actual:   %q", buf.String())
+		t.Fatal("MPS output was not as expected")
+	}
+}
+
+// TestWriteMPSFixed writes the model from TestWriteMPS, but with a cost that
+// requires rounding, as a fixed-format MPS minimization problem.
+func TestWriteMPSFixed(t *testing.T) {
+	// Prepare the model.
+	var model Model
+	model.Maximize = true
+	model.Offset = 3.0
+	model.ColCosts = []float64{2.0, 1.0 / 3.0}
+	model.ColLower = []float64{0.0, math.Inf(-1)}
+	model.ColUpper = []float64{25.0, math.Inf(1)}
+	model.VarTypes = []VariableType{ContinuousType, IntegerType}
+	model.AddDenseRow(10.0, []float64{1.0, 1.0}, 10.0)
+	model.AddDenseRow(math.Inf(-1), []float64{1.0, -1.0}, 4.0)
+	model.AddDenseRow(2.0, []float64{0.0, 1.0}, 8.0)
+
+	// Write the model to a buffer.
+	var buf bytes.Buffer
+	opts := MPSOptions{Fixed: true, NegateObjective: true}
+	checkErr(t, model.WriteMPSOptions(&buf, opts))
+
+	// Compare to the expected contents.
+	exp := `NAME
+ROWS
+ N  Obj
+ E  R0
+ L  R1
+ G  R2
+COLUMNS
+    C0        Obj       -2
+    C0        R0        1
+    C0        R1        1
+    MARKER    'MARKER'                 'INTORG'
+    C1        Obj       -0.333333333
+    C1        R0        1
+    C1        R1        -1
+    C1        R2        1
+    MARKER    'MARKER'                 'INTEND'
+RHS
+    RHS       Obj       3
+    RHS       R0        10
+    RHS       R1        4
+    RHS       R2        2
+RANGES
+    RNG       R2        6
+BOUNDS
+ UP BND       C0        25
+ LO BND       C0        0
+ FR BND       C1
+ENDATA
+`
+	if buf.String() != exp {
+		t.Logf("Expected: %q", exp)
+		t.Logf("Actual:   %q", buf.String())
+		t.Fatal("MPS output was not as expected")
+	}
+}
+
+// TestReadMPS writes the model from TestWriteMPS in MPS format and strictly
+// reads it back.
+func TestReadMPS(t *testing.T) {
+	// Prepare the model.
+	var model Model
+	model.Maximize = true
+	model.Offset = 3.0
+	model.ColCosts = []float64{2.0, 1.0}
+	model.ColLower = []float64{0.0, math.Inf(-1)}
+	model.ColUpper = []float64{25.0, math.Inf(1)}
+	model.VarTypes = []VariableType{ContinuousType, IntegerType}
+	model.AddDenseRow(10.0, []float64{1.0, 1.0}, 10.0)
+	model.AddDenseRow(math.Inf(-1), []float64{1.0, -1.0}, 4.0)
+	model.AddDenseRow(2.0, []float64{0.0, 1.0}, 8.0)
+	model.HessianMatrix = []Nonzero{{0, 1, 0.5}}
+
+	// Write the model to a buffer and read it back.
+	var buf bytes.Buffer
+	checkErr(t, model.WriteMPS(&buf))
+	var read Model
+	warns, err := read.ReadMPSOptions(&buf, MPSReadOptions{Strict: true})
+	checkErr(t, err)
+	if len(warns) != 0 {
+		t.Fatalf("expected no warnings but saw %v", warns)
+	}
+
+	// Ensure that the models are equivalent.
+	model.ColNames = []string{"C0", "C1"}
+	model.RowNames = []string{"R0", "R1", "R2"}
+	changes, err := DiffModels(&model, &read)
+	checkErr(t, err)
+	if len(changes) != 0 {
+		t.Fatalf("expected no differences but saw %v", changes)
+	}
+}
+
+// TestReadMPSQuirks reads an MPS file containing a variety of dialect quirks
+// in both lenient and strict mode.
+func TestReadMPSQuirks(t *testing.T) {
+	const mps = `NAME quirky
+* A comment
+ROWS
+ N  cost
+ L  lim
+ G  lim
+ E  eq
+COLUMNS
+    x  cost  1  lim  2
+    x  lim  3
+    y  eq  1  bogus  4
+RHS
+    lim  10
+    RHS  eq  5
+RANGES
+    RNG  lim  -4
+    RNG  eq  -2
+BOUNDS
+ UP BND  y  -1
+`
+	// Read the file leniently.
+	var model Model
+	warns, err := model.ReadMPS(strings.NewReader(mps))
+	checkErr(t, err)
+	var act []string
+	for _, w := range warns {
+		act = append(act, w.String())
+	}
+	exp := []string{
+		"line 6: row lim is declared more than once; ignoring the redeclaration",
+		"line 10: column x has more than one coefficient in row lim; keeping the later one",
+		"line 11: column y refers to undeclared row bogus; ignoring the entry",
+		"line 16: L row lim has a negative range; using its absolute value",
+		"line 19: missing ENDATA",
+		"line 19: column y has a negative upper bound but no lower bound; setting the lower bound to -infinity",
+	}
+	if !reflect.DeepEqual(act, exp) {
+		t.Fatalf("expected\n%q\nbut saw\n%q", exp, act)
+	}
+
+	// Ensure that the quirks were interpreted as documented.
+	compSlices(t, "ColCosts", model.ColCosts, []float64{1.0, 0.0})
+	compSlices(t, "ColLower", model.ColLower, []float64{0.0, math.Inf(-1)})
+	compSlices(t, "ColUpper", model.ColUpper, []float64{math.Inf(1), -1.0})
+	compSlices(t, "RowLower", model.RowLower, []float64{6.0, 3.0})
+	compSlices(t, "RowUpper", model.RowUpper, []float64{10.0, 5.0})
+	expMat := []Nonzero{{0, 0, 3.0}, {1, 1, 1.0}}
+	if !reflect.DeepEqual(model.ConstMatrix, expMat) {
+		t.Fatalf("expected matrix %v but saw %v", expMat, model.ConstMatrix)
+	}
+
+	// Ensure that strict mode rejects the first quirk.
+	_, err = model.ReadMPSOptions(strings.NewReader(mps), MPSReadOptions{Strict: true})
+	if err == nil || !strings.HasPrefix(err.Error(), "line 6: ") {
+		t.Fatalf("expected an error on line 6 but saw %v", err)
+	}
+}
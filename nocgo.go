@@ -0,0 +1,28 @@
+//go:build !cgo
+
+// This file lets the highs package build without cgo.  In this mode, only the
+// high-level Model API is available, and Model.Solve runs an external HiGHS
+// executable.
+
+package highs
+
+import "os"
+
+// These are the kHighsStatus values a CallStatus can represent.  They must
+// match HiGHS's definitions.
+const (
+	statusError   = -1
+	statusWarning = 1
+)
+
+// Solve solves the model by running an external HiGHS executable.  This
+// version of Solve is used only when cgo is unavailable.  The executable is
+// named by the HIGHS_EXECUTABLE environment variable or, if that is unset,
+// is "highs" in the user's PATH.
+func (m *Model) Solve() (Solution, error) {
+	exe := os.Getenv("HIGHS_EXECUTABLE")
+	if exe == "" {
+		exe = "highs"
+	}
+	return m.SolveExternal(exe)
+}
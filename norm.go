@@ -0,0 +1,159 @@
+// This file provides support for objective functions that minimize the L1
+// or L∞ norm of a set of affine expressions.
+
+package highs
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// A LinearExpr represents the affine expression Σ Coeffs[c]·x_c + Constant,
+// where x_c is the value of column c.
+type LinearExpr struct {
+	Coeffs   map[int]float64 // Map from column index to coefficient
+	Constant float64         // Constant term
+}
+
+// addAuxColumns prepares a model for the addition of n auxiliary columns
+// with a given cost and a lower bound of zero by filling in all per-column
+// and per-row defaults, as ToRawModel does, so that later defaults do not
+// shift.  It returns the index of the first new column.
+func (m *Model) addAuxColumns(n int, cost float64) (int, error) {
+	// Fill in defaults.
+	nr, nc := m.modelSize()
+	mInf, pInf := math.Inf(-1), math.Inf(1)
+	var ok [5]bool
+	m.ColCosts, ok[0] = expandToLen(nc, m.ColCosts, 1.0)
+	m.ColLower, ok[1] = expandToLen(nc, m.ColLower, mInf)
+	m.ColUpper, ok[2] = expandToLen(nc, m.ColUpper, pInf)
+	m.RowLower, ok[3] = expandToLen(nr, m.RowLower, mInf)
+	m.RowUpper, ok[4] = expandToLen(nr, m.RowUpper, pInf)
+	if !ok[0] || !ok[1] || !ok[2] {
+		return 0, fmt.Errorf("inconsistent column counts")
+	}
+	if !ok[3] || !ok[4] {
+		return 0, fmt.Errorf("inconsistent row counts")
+	}
+	if len(m.VarTypes) > 0 {
+		if m.VarTypes, ok[0] = expandToLen(nc, m.VarTypes, ContinuousType); !ok[0] {
+			return 0, fmt.Errorf("inconsistent column counts")
+		}
+	}
+
+	// Append the new columns.
+	for i := 0; i < n; i++ {
+		m.ColCosts = append(m.ColCosts, cost)
+		m.ColLower = append(m.ColLower, 0.0)
+		m.ColUpper = append(m.ColUpper, pInf)
+		if len(m.VarTypes) > 0 {
+			m.VarTypes = append(m.VarTypes, ContinuousType)
+		}
+		if len(m.ColNames) > 0 {
+			m.ColNames = append(m.ColNames, fmt.Sprintf("aux%d", nc+i))
+		}
+		if len(m.ColTags) > 0 {
+			m.ColTags = append(m.ColTags, nil)
+		}
+	}
+	return nc, nil
+}
+
+// addAbsRows appends to a model the rows aux ≥ e and aux ≥ −e, which
+// together bound the absolute value of an affine expression e by the value
+// of an auxiliary column.
+func (m *Model) addAbsRows(e LinearExpr, aux int) {
+	// Sort the columns so the rows do not depend on map iteration order.
+	cols := make([]int, 0, len(e.Coeffs))
+	for c := range e.Coeffs {
+		cols = append(cols, c)
+	}
+	sort.Ints(cols)
+
+	// Add aux − Σ a·x ≥ Constant and aux + Σ a·x ≥ −Constant.
+	pInf := math.Inf(1)
+	for _, sign := range []float64{-1.0, 1.0} {
+		r := len(m.RowLower)
+		m.RowLower = append(m.RowLower, -sign*e.Constant)
+		m.RowUpper = append(m.RowUpper, pInf)
+		for _, c := range cols {
+			if v := e.Coeffs[c]; v != 0.0 {
+				m.ConstMatrix = append(m.ConstMatrix, Nonzero{r, c, sign * v})
+			}
+		}
+		m.ConstMatrix = append(m.ConstMatrix, Nonzero{r, aux, 1.0})
+		if len(m.RowNames) > 0 {
+			m.RowNames = append(m.RowNames, fmt.Sprintf("abs%d", r))
+		}
+		if len(m.RowTags) > 0 {
+			m.RowTags = append(m.RowTags, nil)
+		}
+	}
+}
+
+// checkNormArgs validates the arguments to AddL1Objective and
+// AddLInfObjective.
+func (m *Model) checkNormArgs(exprs []LinearExpr, weight float64) error {
+	if m.Maximize {
+		return fmt.Errorf("a norm can be minimized but not maximized")
+	}
+	if weight < 0.0 {
+		return fmt.Errorf("weight %v is negative", weight)
+	}
+	_, nc := m.modelSize()
+	for i, e := range exprs {
+		for c := range e.Coeffs {
+			if c < 0 || c >= nc {
+				return fmt.Errorf("expression %d refers to invalid column %d", i, c)
+			}
+		}
+	}
+	return nil
+}
+
+// AddL1Objective adds weight·Σ|e_i| to a minimization model's objective
+// function, where the e_i are the given affine expressions.  This is the
+// objective of least-absolute-deviations (robust) regression.  The term is
+// modeled by adding one nonnegative auxiliary column t_i per expression, with
+// cost weight, and two rows, t_i ≥ e_i and t_i ≥ −e_i.  At an optimum, t_i =
+// |e_i| if weight is positive.  AddL1Objective returns the index of t_0; the
+// remaining auxiliary columns follow it consecutively.  Because a missing
+// ColCosts is treated as all ones, AddL1Objective first fills in that and
+// every other omitted per-column and per-row slice as ToRawModel would.  If
+// the model has names, the new columns and rows are given generated names.
+func (m *Model) AddL1Objective(exprs []LinearExpr, weight float64) (int, error) {
+	if err := m.checkNormArgs(exprs, weight); err != nil {
+		return 0, err
+	}
+	first, err := m.addAuxColumns(len(exprs), weight)
+	if err != nil {
+		return 0, err
+	}
+	for i, e := range exprs {
+		m.addAbsRows(e, first+i)
+	}
+	return first, nil
+}
+
+// AddLInfObjective adds weight·maxᵢ|e_i| to a minimization model's objective
+// function, where the e_i are the given affine expressions.  This is the
+// objective of minimax (Chebyshev) approximation and of fairness-style
+// formulations that minimize the worst case.  The term is modeled by adding a
+// single nonnegative auxiliary column z, with cost weight, and two rows per
+// expression, z ≥ e_i and z ≥ −e_i.  At an optimum, z = maxᵢ|e_i| if weight is
+// positive.  AddLInfObjective returns the index of z.  Defaults and names are
+// handled as in AddL1Objective.
+func (m *Model) AddLInfObjective(exprs []LinearExpr, weight float64) (int, error) {
+	if err := m.checkNormArgs(exprs, weight); err != nil {
+		return 0, err
+	}
+	z, err := m.addAuxColumns(1, weight)
+	if err != nil {
+		return 0, err
+	}
+	for _, e := range exprs {
+		m.addAbsRows(e, z)
+	}
+	return z, nil
+}
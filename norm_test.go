@@ -0,0 +1,55 @@
+// This file tests the L1 and L∞ objective helpers.
+
+package highs
+
+import (
+	"math"
+	"testing"
+)
+
+// deviations returns expressions for x_0 − 1, x_0 − 2, and x_0 − 6.
+func deviations() []LinearExpr {
+	exprs := make([]LinearExpr, 3)
+	for i, v := range []float64{1.0, 2.0, 6.0} {
+		exprs[i] = LinearExpr{Coeffs: map[int]float64{0: 1.0}, Constant: -v}
+	}
+	return exprs
+}
+
+// TestAddL1Objective tests that AddL1Objective adds one auxiliary column and
+// two rows per expression to a model with a single free column.
+func TestAddL1Objective(t *testing.T) {
+	// Add the objective.
+	var m Model
+	m.ColLower = []float64{math.Inf(-1)}
+	m.ColUpper = []float64{math.Inf(1)}
+	m.ColNames = []string{"x"}
+	first, err := m.AddL1Objective(deviations(), 1.0)
+	checkErr(t, err)
+
+	// Check the auxiliary columns and rows.
+	if first != 1 {
+		t.Fatalf("expected the first auxiliary column to be 1 but saw %d", first)
+	}
+	compSlices(t, "ColCosts", m.ColCosts, []float64{1.0, 1.0, 1.0, 1.0})
+	compSlices(t, "ColLower", m.ColLower[1:], []float64{0.0, 0.0, 0.0})
+	compSlices(t, "RowLower", m.RowLower, []float64{-1.0, 1.0, -2.0, 2.0, -6.0, 6.0})
+	if len(m.ColNames) != 4 || len(m.RowNames) != 0 {
+		t.Fatalf("unexpected names %v and %v", m.ColNames, m.RowNames)
+	}
+	exp := []Nonzero{{0, 0, -1.0}, {0, 1, 1.0}, {1, 0, 1.0}, {1, 1, 1.0}}
+	for i, nz := range exp {
+		if m.ConstMatrix[i] != nz {
+			t.Fatalf("expected %v but saw %v", exp, m.ConstMatrix[:len(exp)])
+		}
+	}
+
+	// Ensure that invalid arguments are rejected.
+	if _, err = m.AddL1Objective([]LinearExpr{{Coeffs: map[int]float64{9: 1.0}}}, 1.0); err == nil {
+		t.Fatal("AddL1Objective accepted an invalid column")
+	}
+	m.Maximize = true
+	if _, err = m.AddLInfObjective(deviations(), 1.0); err == nil {
+		t.Fatal("AddLInfObjective accepted a maximization model")
+	}
+}
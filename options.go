@@ -0,0 +1,263 @@
+//go:build cgo
+
+// This file provides typed, validated setters for commonly used HiGHS
+// options.  Each is a thin wrapper around SetIntOption, SetFloat64Option,
+// etc. that catches misspelled option names at compile time and invalid
+// values before they reach HiGHS.
+
+package highs
+
+import (
+	"fmt"
+	"math"
+)
+
+// #include "highs-externs.h"
+import "C"
+
+// checkNonnegative returns an error if a value is negative or NaN.
+func checkNonnegative(opt string, v float64) error {
+	if v < 0.0 || math.IsNaN(v) {
+		return fmt.Errorf("%s must be nonnegative (not %v)", opt, v)
+	}
+	return nil
+}
+
+// checkAtLeast returns an error if a value is less than a given minimum or is
+// NaN.
+func checkAtLeast(opt string, v, min float64) error {
+	if v < min || math.IsNaN(v) {
+		return fmt.Errorf("%s must be at least %v (not %v)", opt, min, v)
+	}
+	return nil
+}
+
+// SetPrimalFeasibilityTolerance sets the amount by which HiGHS allows a
+// solution to violate a column or row bound (option
+// primal_feasibility_tolerance).  HiGHS requires that the tolerance be at
+// least 1e-10.
+func (m *RawModel) SetPrimalFeasibilityTolerance(tol float64) error {
+	if err := checkAtLeast("primal_feasibility_tolerance", tol, 1e-10); err != nil {
+		return err
+	}
+	return m.SetFloat64Option("primal_feasibility_tolerance", tol)
+}
+
+// SetDualFeasibilityTolerance sets the amount by which HiGHS allows a
+// solution's duals to be infeasible (option dual_feasibility_tolerance).
+// HiGHS requires that the tolerance be at least 1e-10.
+func (m *RawModel) SetDualFeasibilityTolerance(tol float64) error {
+	if err := checkAtLeast("dual_feasibility_tolerance", tol, 1e-10); err != nil {
+		return err
+	}
+	return m.SetFloat64Option("dual_feasibility_tolerance", tol)
+}
+
+// SetIPMOptimalityTolerance sets the relative duality gap at which HiGHS's
+// interior-point solver considers a solution optimal (option
+// ipm_optimality_tolerance).  HiGHS requires that the tolerance be at least
+// 1e-12.
+func (m *RawModel) SetIPMOptimalityTolerance(tol float64) error {
+	if err := checkAtLeast("ipm_optimality_tolerance", tol, 1e-12); err != nil {
+		return err
+	}
+	return m.SetFloat64Option("ipm_optimality_tolerance", tol)
+}
+
+// SetSimplexIterationLimit limits the number of simplex iterations HiGHS
+// performs (option simplex_iteration_limit).  If the limit is reached, Solve
+// returns a solution with Status IterationLimit rather than an error.
+func (m *RawModel) SetSimplexIterationLimit(n int) error {
+	if n < 0 {
+		return fmt.Errorf("simplex_iteration_limit must be nonnegative (not %d)", n)
+	}
+	return m.SetIntOption("simplex_iteration_limit", n)
+}
+
+// SetObjectiveBound tells HiGHS to stop once it proves that no solution has
+// an objective value better than v, that is, less than v when minimizing or
+// greater than v when maximizing (option objective_bound).  In that case,
+// Solve returns a solution with Status ObjectiveBound rather than an error;
+// its primal values, if any, are those of the best solution found before
+// stopping but are not necessarily feasible.  Pass +∞ when minimizing or −∞
+// when maximizing to disable the bound.
+func (m *RawModel) SetObjectiveBound(v float64) error {
+	if math.IsNaN(v) {
+		return fmt.Errorf("objective_bound must not be NaN")
+	}
+	return m.SetFloat64Option("objective_bound", v)
+}
+
+// SetObjectiveTarget tells HiGHS to stop a MIP solve once it finds a feasible
+// solution whose objective value is at least as good as v, that is, no
+// greater than v when minimizing or no less than v when maximizing (option
+// objective_target).  In that case, Solve returns that solution with Status
+// ObjectiveTarget rather than an error.  Pass −∞ when minimizing or +∞ when
+// maximizing to disable the target.
+func (m *RawModel) SetObjectiveTarget(v float64) error {
+	if math.IsNaN(v) {
+		return fmt.Errorf("objective_target must not be NaN")
+	}
+	return m.SetFloat64Option("objective_target", v)
+}
+
+// deterministicOptions returns the option settings SetDeterministic assigns
+// for a given value of det.
+func deterministicOptions(det bool) []solverOption {
+	if det {
+		return []solverOption{
+			{"threads", 1},
+			{"parallel", "off"},
+			{"random_seed", 0},
+		}
+	}
+	return []solverOption{
+		{"threads", 0},
+		{"parallel", "choose"},
+	}
+}
+
+// SetDeterministic configures HiGHS for run-to-run reproducibility when det
+// is true by setting threads to 1, parallel to "off", and random_seed to 0.
+// When det is false, it restores the defaults for threads (0, meaning
+// automatic) and parallel ("choose"), leaving random_seed unchanged.  Solves
+// that stop at a time limit remain nondeterministic regardless.
+//
+// HiGHS sizes a single, process-wide thread pool on the first solve and
+// refuses to solve with a different value of threads thereafter.  A program
+// that changes threads after solving must therefore call ResetThreadPool
+// before solving again.
+func (m *RawModel) SetDeterministic(det bool) error {
+	for _, o := range deterministicOptions(det) {
+		if err := applyOption(m, o.name, o.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ResetThreadPool discards HiGHS's process-wide thread pool so that the next
+// solve creates a new one sized by that solve's threads option.  This is
+// needed only after changing threads, for example with SetDeterministic,
+// once some model has been solved.
+//
+// Warning: ResetThreadPool affects every model in the process.  It must not
+// be called while any model is being solved.
+func ResetThreadPool() error {
+	status := C.Highs_resetGlobalScheduler(1)
+	return newCallStatus(status, "Highs_resetGlobalScheduler", "ResetThreadPool")
+}
+
+// SetMPSFixedFormat selects whether subsequent calls to ReadModel and
+// related methods parse MPS files as fixed-format, in which fields occupy
+// specific columns and names may contain spaces, or as free-format, in which
+// fields are separated by whitespace (option mps_parser_type_free).  HiGHS
+// parses free-format MPS by default.
+func (m *RawModel) SetMPSFixedFormat(fixed bool) error {
+	return m.SetBoolOption("mps_parser_type_free", !fixed)
+}
+
+// SetMIPRelGap sets the relative gap, |primal bound − dual bound|/|primal
+// bound|, at which HiGHS considers a MIP solved (option mip_rel_gap).
+func (m *RawModel) SetMIPRelGap(gap float64) error {
+	if err := checkNonnegative("mip_rel_gap", gap); err != nil {
+		return err
+	}
+	return m.SetFloat64Option("mip_rel_gap", gap)
+}
+
+// SetMIPAbsGap sets the absolute gap, |primal bound − dual bound|, at which
+// HiGHS considers a MIP solved (option mip_abs_gap).
+func (m *RawModel) SetMIPAbsGap(gap float64) error {
+	if err := checkNonnegative("mip_abs_gap", gap); err != nil {
+		return err
+	}
+	return m.SetFloat64Option("mip_abs_gap", gap)
+}
+
+// SetMIPMaxNodes limits the number of branch-and-bound nodes HiGHS explores
+// when solving a MIP (option mip_max_nodes).  If the limit is reached, Solve
+// returns the best solution found with Status SolutionLimit rather than an
+// error.
+func (m *RawModel) SetMIPMaxNodes(n int) error {
+	if n < 0 {
+		return fmt.Errorf("mip_max_nodes must be nonnegative (not %d)", n)
+	}
+	return m.SetIntOption("mip_max_nodes", n)
+}
+
+// SetMIPMaxImprovingSolutions limits the number of improving solutions HiGHS
+// finds before stopping a MIP solve (option mip_max_improving_sols).  If the
+// limit is reached, Solve returns the best solution found with Status
+// SolutionLimit rather than an error.
+func (m *RawModel) SetMIPMaxImprovingSolutions(n int) error {
+	if n < 1 {
+		return fmt.Errorf("mip_max_improving_sols must be positive (not %d)", n)
+	}
+	return m.SetIntOption("mip_max_improving_sols", n)
+}
+
+// A MIPPreset names a coherent collection of MIP-related option settings.
+type MIPPreset int
+
+// These are the values a MIPPreset accepts:
+const (
+	MIPBalanced         MIPPreset = iota // HiGHS's default trade-off between finding and proving solutions
+	MIPFindFeasibleFast                  // Stop at the first feasible solution, favoring heuristics over cuts
+	MIPProveOptimality                   // Close the gap completely, favoring cuts and branching over heuristics
+)
+
+// mipPresets maps each MIPPreset to its option settings.  Every preset assigns
+// every option that any preset assigns so that presets can be applied in
+// succession without one's settings leaking into another's.
+var mipPresets = map[MIPPreset][]solverOption{
+	MIPBalanced: {
+		{"presolve", "choose"},
+		{"mip_heuristic_effort", 0.05},
+		{"mip_rel_gap", 1e-4},
+		{"mip_max_improving_sols", math.MaxInt32},
+		{"mip_detect_symmetry", true},
+		{"mip_allow_restart", true},
+		{"mip_lp_age_limit", 10},
+		{"mip_pool_soft_limit", 10000},
+		{"mip_pscost_minreliable", 8},
+	},
+	MIPFindFeasibleFast: {
+		{"presolve", "on"},
+		{"mip_heuristic_effort", 0.3},
+		{"mip_rel_gap", 1e-4},
+		{"mip_max_improving_sols", 1},
+		{"mip_detect_symmetry", false},
+		{"mip_allow_restart", false},
+		{"mip_lp_age_limit", 5},
+		{"mip_pool_soft_limit", 2000},
+		{"mip_pscost_minreliable", 4},
+	},
+	MIPProveOptimality: {
+		{"presolve", "on"},
+		{"mip_heuristic_effort", 0.01},
+		{"mip_rel_gap", 0.0},
+		{"mip_max_improving_sols", math.MaxInt32},
+		{"mip_detect_symmetry", true},
+		{"mip_allow_restart", true},
+		{"mip_lp_age_limit", 20},
+		{"mip_pool_soft_limit", 20000},
+		{"mip_pscost_minreliable", 16},
+	},
+}
+
+// SetMIPPreset assigns a coherent set of heuristic-effort, presolve,
+// cut-management, and termination options suited to a given goal.  Options
+// can be adjusted individually after a preset has been applied.
+func (m *RawModel) SetMIPPreset(p MIPPreset) error {
+	opts, ok := mipPresets[p]
+	if !ok {
+		return fmt.Errorf("unrecognized MIP preset %d", int(p))
+	}
+	for _, o := range opts {
+		if err := applyOption(m, o.name, o.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,273 @@
+//go:build cgo
+
+// This file tests the typed option setters.
+
+package highs
+
+import (
+	"math"
+	"testing"
+)
+
+// TestMIPOptions tests that the MIP gap and limit setters assign the
+// corresponding HiGHS options and reject invalid values.
+func TestMIPOptions(t *testing.T) {
+	model := NewRawModel()
+	checkErr(t, model.SetBoolOption("output_flag", false))
+
+	// Assign valid values.
+	checkErr(t, model.SetMIPRelGap(0.01))
+	checkErr(t, model.SetMIPAbsGap(0.5))
+	checkErr(t, model.SetMIPMaxNodes(1000))
+	checkErr(t, model.SetMIPMaxImprovingSolutions(3))
+	if v, err := model.GetFloat64Option("mip_rel_gap"); err != nil || v != 0.01 {
+		t.Fatalf("expected mip_rel_gap to be 0.01 but saw %v (%v)", v, err)
+	}
+	if v, err := model.GetIntOption("mip_max_nodes"); err != nil || v != 1000 {
+		t.Fatalf("expected mip_max_nodes to be 1000 but saw %v (%v)", v, err)
+	}
+
+	// Ensure that invalid values are rejected.
+	if model.SetMIPRelGap(-1.0) == nil {
+		t.Fatal("SetMIPRelGap accepted a negative gap")
+	}
+	if model.SetMIPMaxNodes(-1) == nil {
+		t.Fatal("SetMIPMaxNodes accepted a negative limit")
+	}
+	if model.SetMIPMaxImprovingSolutions(0) == nil {
+		t.Fatal("SetMIPMaxImprovingSolutions accepted a zero limit")
+	}
+}
+
+// TestMIPPreset tests that MIP presets can be applied in succession and that
+// each leaves HiGHS's options in the expected state.
+func TestMIPPreset(t *testing.T) {
+	model := NewRawModel()
+	checkErr(t, model.SetBoolOption("output_flag", false))
+	checkErr(t, model.SetMIPPreset(MIPFindFeasibleFast))
+	if v, err := model.GetIntOption("mip_max_improving_sols"); err != nil || v != 1 {
+		t.Fatalf("expected mip_max_improving_sols to be 1 but saw %v (%v)", v, err)
+	}
+	checkErr(t, model.SetMIPPreset(MIPProveOptimality))
+	if v, err := model.GetFloat64Option("mip_rel_gap"); err != nil || v != 0.0 {
+		t.Fatalf("expected mip_rel_gap to be 0 but saw %v (%v)", v, err)
+	}
+	if v, err := model.GetIntOption("mip_max_improving_sols"); err != nil || v == 1 {
+		t.Fatalf("expected mip_max_improving_sols to be reset but saw %v (%v)", v, err)
+	}
+	checkErr(t, model.SetMIPPreset(MIPBalanced))
+	if v, err := model.GetStringOption("presolve"); err != nil || v != "choose" {
+		t.Fatalf("expected presolve to be \"choose\" but saw %q (%v)", v, err)
+	}
+	if model.SetMIPPreset(MIPPreset(-1)) == nil {
+		t.Fatal("SetMIPPreset accepted an invalid preset")
+	}
+}
+
+// TestSimplexIterationLimit tests that reaching the simplex iteration limit
+// produces a solution with Status IterationLimit rather than an error.  It
+// uses the model from TestMinimalAPIMin, which requires at least one simplex
+// iteration when presolve is disabled.
+func TestSimplexIterationLimit(t *testing.T) {
+	// Prepare the model.
+	var model Model
+	model.Offset = 3.0
+	model.ColCosts = []float64{1.0, 1.0}
+	model.ColLower = []float64{0.0, 1.0}
+	model.ColUpper = []float64{4.0, 1.0e30}
+	model.RowLower = []float64{-1.0e30, 5.0, 6.0}
+	model.RowUpper = []float64{7.0, 15.0, 1.0e30}
+	model.ConstMatrix = []Nonzero{
+		{0, 1, 1.0},
+		{1, 0, 1.0},
+		{1, 1, 2.0},
+		{2, 0, 3.0},
+		{2, 1, 2.0},
+	}
+	raw, err := model.ToRawModel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer raw.Close()
+	checkErr(t, raw.SetBoolOption("output_flag", false))
+	checkErr(t, raw.SetStringOption("presolve", "off"))
+	checkErr(t, raw.SetStringOption("solver", "simplex"))
+	checkErr(t, raw.SetSimplexIterationLimit(0))
+	if raw.SetSimplexIterationLimit(-1) == nil {
+		t.Fatal("SetSimplexIterationLimit accepted a negative limit")
+	}
+
+	// Solve the model.
+	soln, err := raw.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if soln.Status != IterationLimit {
+		t.Fatalf("Solve returned %s instead of IterationLimit", soln.Status)
+	}
+	if len(soln.ColumnPrimal) != 2 {
+		t.Fatalf("expected 2 column values but saw %d", len(soln.ColumnPrimal))
+	}
+}
+
+// TestObjectiveTarget tests that SetObjectiveBound and SetObjectiveTarget
+// assign the corresponding HiGHS options and that a MIP solve that reaches
+// its objective target returns the incumbent solution.  It solves the model
+// from modelAndSolve, whose optimal objective value is 23.
+func TestObjectiveTarget(t *testing.T) {
+	// Prepare the model.
+	var model Model
+	model.ColCosts = []float64{3.0, 2.0, 1.0}
+	model.ColLower = []float64{0.0, 0.0, 0.0}
+	model.RowLower = []float64{1.0, 1.0, 10.0}
+	model.ConstMatrix = []Nonzero{
+		{0, 0, 1.0},
+		{0, 1, -1.0},
+		{1, 1, 1.0},
+		{1, 2, -1.0},
+		{2, 0, 1.0},
+		{2, 1, 1.0},
+		{2, 2, 1.0},
+	}
+	model.VarTypes = []VariableType{IntegerType, IntegerType, IntegerType}
+	raw, err := model.ToRawModel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer raw.Close()
+	checkErr(t, raw.SetBoolOption("output_flag", false))
+
+	// Set and check the options.
+	checkErr(t, raw.SetObjectiveBound(100.0))
+	if v, err := raw.GetFloat64Option("objective_bound"); err != nil || v != 100.0 {
+		t.Fatalf("expected objective_bound to be 100 but saw %v (%v)", v, err)
+	}
+	checkErr(t, raw.SetObjectiveBound(math.Inf(1)))
+	checkErr(t, raw.SetObjectiveTarget(30.0))
+	if v, err := raw.GetFloat64Option("objective_target"); err != nil || v != 30.0 {
+		t.Fatalf("expected objective_target to be 30 but saw %v (%v)", v, err)
+	}
+	if raw.SetObjectiveBound(math.NaN()) == nil {
+		t.Fatal("SetObjectiveBound accepted NaN")
+	}
+	if raw.SetObjectiveTarget(math.NaN()) == nil {
+		t.Fatal("SetObjectiveTarget accepted NaN")
+	}
+
+	// Solve the model.  HiGHS may find the optimal solution before
+	// checking the target.
+	soln, err := raw.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if soln.Status != ObjectiveTarget && soln.Status != Optimal {
+		t.Fatalf("Solve returned %s instead of ObjectiveTarget", soln.Status)
+	}
+	if soln.Objective > 30.0 {
+		t.Fatalf("objective value was %v but should have been at most 30", soln.Objective)
+	}
+	if len(soln.ColumnPrimal) != 3 {
+		t.Fatalf("expected 3 column values but saw %d", len(soln.ColumnPrimal))
+	}
+}
+
+// TestSetDeterministic tests that SetDeterministic assigns and restores the
+// options it documents.
+func TestSetDeterministic(t *testing.T) {
+	model := NewRawModel()
+	checkErr(t, model.SetBoolOption("output_flag", false))
+	checkErr(t, model.SetIntOption("random_seed", 42))
+	checkErr(t, model.SetDeterministic(true))
+	if v, err := model.GetIntOption("threads"); err != nil || v != 1 {
+		t.Fatalf("expected threads to be 1 but saw %v (%v)", v, err)
+	}
+	if v, err := model.GetStringOption("parallel"); err != nil || v != "off" {
+		t.Fatalf("expected parallel to be \"off\" but saw %q (%v)", v, err)
+	}
+	if v, err := model.GetIntOption("random_seed"); err != nil || v != 0 {
+		t.Fatalf("expected random_seed to be 0 but saw %v (%v)", v, err)
+	}
+	checkErr(t, model.SetDeterministic(false))
+	if v, err := model.GetIntOption("threads"); err != nil || v != 0 {
+		t.Fatalf("expected threads to be 0 but saw %v (%v)", v, err)
+	}
+	if v, err := model.GetStringOption("parallel"); err != nil || v != "choose" {
+		t.Fatalf("expected parallel to be \"choose\" but saw %q (%v)", v, err)
+	}
+
+	// Ensure that ToRawModelWithOptions can request the same settings.
+	var m Model
+	m.ColCosts = []float64{1.0}
+	raw, err := m.ToRawModelWithOptions(RawModelOptions{Deterministic: true})
+	checkErr(t, err)
+	defer raw.Close()
+	if v, err := raw.GetIntOption("threads"); err != nil || v != 1 {
+		t.Fatalf("expected threads to be 1 but saw %v (%v)", v, err)
+	}
+}
+
+// TestSetDeterministicSolve tests that a model can be solved after switching
+// deterministic mode on and then off again, which changes the thread count,
+// as long as HiGHS's thread pool is reset in between.  It solves the
+// following model:
+//
+//	Min.  x_0 + x_1
+//	s.t.  23 <= x_0 + x_1 <= 23
+//	      17 <= x_0 - x_1 <= 17
+func TestSetDeterministicSolve(t *testing.T) {
+	var m Model
+	m.ColCosts = []float64{1.0, 1.0}
+	m.AddDenseRow(23.0, []float64{1.0, 1.0}, 23.0)
+	m.AddDenseRow(17.0, []float64{1.0, -1.0}, 17.0)
+	model, err := m.ToRawModel()
+	checkErr(t, err)
+	defer model.Close()
+	checkErr(t, model.SetBoolOption("output_flag", false))
+	for _, det := range []bool{true, false} {
+		checkErr(t, model.SetDeterministic(det))
+		checkErr(t, ResetThreadPool())
+		soln, err := model.Solve()
+		if err != nil {
+			t.Fatalf("Solve failed with SetDeterministic(%v) (%s)", det, err)
+		}
+		if soln.Status != Optimal {
+			t.Fatalf("Solve returned %s instead of Optimal with SetDeterministic(%v)", soln.Status, det)
+		}
+		compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{20.0, 3.0})
+	}
+}
+
+// TestToleranceOptions tests that the tolerance setters assign the
+// corresponding HiGHS options and reject out-of-range values.
+func TestToleranceOptions(t *testing.T) {
+	model := NewRawModel()
+	checkErr(t, model.SetBoolOption("output_flag", false))
+
+	// Assign valid values.
+	checkErr(t, model.SetPrimalFeasibilityTolerance(1e-6))
+	checkErr(t, model.SetDualFeasibilityTolerance(1e-8))
+	checkErr(t, model.SetIPMOptimalityTolerance(1e-9))
+	for _, tc := range []struct {
+		opt string
+		exp float64
+	}{
+		{"primal_feasibility_tolerance", 1e-6},
+		{"dual_feasibility_tolerance", 1e-8},
+		{"ipm_optimality_tolerance", 1e-9},
+	} {
+		if v, err := model.GetFloat64Option(tc.opt); err != nil || v != tc.exp {
+			t.Fatalf("expected %s to be %v but saw %v (%v)", tc.opt, tc.exp, v, err)
+		}
+	}
+
+	// Ensure that invalid values are rejected.
+	if model.SetPrimalFeasibilityTolerance(1e-11) == nil {
+		t.Fatal("SetPrimalFeasibilityTolerance accepted a too-small tolerance")
+	}
+	if model.SetDualFeasibilityTolerance(-1.0) == nil {
+		t.Fatal("SetDualFeasibilityTolerance accepted a negative tolerance")
+	}
+	if model.SetIPMOptimalityTolerance(math.NaN()) == nil {
+		t.Fatal("SetIPMOptimalityTolerance accepted a NaN tolerance")
+	}
+}
@@ -0,0 +1,414 @@
+/*
+Package ortools converts between highs.Model and the MPModelProto message used
+by Google OR-Tools' linear solver and between highs.Solution and OR-Tools'
+MPSolutionResponse message.
+
+To avoid a dependency on the protobuf runtime, the package mirrors the
+messages with plain Go structs whose encoding/json representation matches
+OR-Tools' canonical JSON (protojson) encoding.  A model exported from OR-Tools
+with, e.g., protojson.Marshal or MessageToJson can therefore be read with
+json.Unmarshal into an MPModelProto and converted with ModelFromProto, and a
+response produced by ResponseFromSolution can be marshaled with json.Marshal
+and parsed by OR-Tools with protojson.Unmarshal.  The binary protobuf wire
+format is not supported.
+
+General constraints (indicator, SOS, etc.) have no HiGHS equivalent and are
+rejected by ModelFromProto.
+*/
+package ortools
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/lanl/highs"
+)
+
+// A Double is a float64 that is represented in JSON the way protojson
+// represents a double: as a number, or as the string "Infinity",
+// "-Infinity", or "NaN" for non-finite values.
+type Double float64
+
+// MarshalJSON encodes a Double as JSON.
+func (d Double) MarshalJSON() ([]byte, error) {
+	x := float64(d)
+	switch {
+	case math.IsInf(x, 1):
+		return []byte(`"Infinity"`), nil
+	case math.IsInf(x, -1):
+		return []byte(`"-Infinity"`), nil
+	case math.IsNaN(x):
+		return []byte(`"NaN"`), nil
+	default:
+		return json.Marshal(x)
+	}
+}
+
+// UnmarshalJSON decodes a Double from JSON.  Both numbers and strings are
+// accepted.
+func (d *Double) UnmarshalJSON(b []byte) error {
+	var x float64
+	if len(b) > 0 && b[0] == '"' {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		switch s {
+		case "Infinity":
+			x = math.Inf(1)
+		case "-Infinity":
+			x = math.Inf(-1)
+		case "NaN":
+			x = math.NaN()
+		default:
+			var err error
+			x, err = strconv.ParseFloat(s, 64)
+			if err != nil {
+				return fmt.Errorf("invalid double %q", s)
+			}
+		}
+	} else if err := json.Unmarshal(b, &x); err != nil {
+		return err
+	}
+	*d = Double(x)
+	return nil
+}
+
+// An MPVariableProto describes a single decision variable.  Nil bounds take
+// OR-Tools' defaults of −∞ and +∞.
+type MPVariableProto struct {
+	LowerBound           *Double `json:"lowerBound,omitempty"`
+	UpperBound           *Double `json:"upperBound,omitempty"`
+	ObjectiveCoefficient Double  `json:"objectiveCoefficient,omitempty"`
+	IsInteger            bool    `json:"isInteger,omitempty"`
+	Name                 string  `json:"name,omitempty"`
+	BranchingPriority    int32   `json:"branchingPriority,omitempty"`
+}
+
+// An MPConstraintProto describes a single linear constraint.  Nil bounds
+// take OR-Tools' defaults of −∞ and +∞.
+type MPConstraintProto struct {
+	VarIndex    []int32  `json:"varIndex,omitempty"`
+	Coefficient []Double `json:"coefficient,omitempty"`
+	LowerBound  *Double  `json:"lowerBound,omitempty"`
+	UpperBound  *Double  `json:"upperBound,omitempty"`
+	Name        string   `json:"name,omitempty"`
+	IsLazy      bool     `json:"isLazy,omitempty"`
+}
+
+// An MPQuadraticObjective describes the quadratic part of an objective
+// function as a sum of coefficient × x[qvar1Index] × x[qvar2Index] terms.
+type MPQuadraticObjective struct {
+	Qvar1Index  []int32  `json:"qvar1Index,omitempty"`
+	Qvar2Index  []int32  `json:"qvar2Index,omitempty"`
+	Coefficient []Double `json:"coefficient,omitempty"`
+}
+
+// A PartialVariableAssignment assigns values to a subset of the variables.
+type PartialVariableAssignment struct {
+	VarIndex []int32  `json:"varIndex,omitempty"`
+	VarValue []Double `json:"varValue,omitempty"`
+}
+
+// An MPModelProto describes a complete optimization model.
+type MPModelProto struct {
+	Name               string                     `json:"name,omitempty"`
+	Maximize           bool                       `json:"maximize,omitempty"`
+	ObjectiveOffset    Double                     `json:"objectiveOffset,omitempty"`
+	Variable           []MPVariableProto          `json:"variable,omitempty"`
+	Constraint         []MPConstraintProto        `json:"constraint,omitempty"`
+	GeneralConstraint  []json.RawMessage          `json:"generalConstraint,omitempty"`
+	QuadraticObjective *MPQuadraticObjective      `json:"quadraticObjective,omitempty"`
+	SolutionHint       *PartialVariableAssignment `json:"solutionHint,omitempty"`
+}
+
+// An MPSolverResponseStatus summarizes the outcome of a solve.
+type MPSolverResponseStatus string
+
+// These are the values of MPSolverResponseStatus that ResponseFromSolution
+// produces:
+const (
+	MPSolverOptimal      MPSolverResponseStatus = "MPSOLVER_OPTIMAL"
+	MPSolverFeasible     MPSolverResponseStatus = "MPSOLVER_FEASIBLE"
+	MPSolverInfeasible   MPSolverResponseStatus = "MPSOLVER_INFEASIBLE"
+	MPSolverUnbounded    MPSolverResponseStatus = "MPSOLVER_UNBOUNDED"
+	MPSolverAbnormal     MPSolverResponseStatus = "MPSOLVER_ABNORMAL"
+	MPSolverNotSolved    MPSolverResponseStatus = "MPSOLVER_NOT_SOLVED"
+	MPSolverModelInvalid MPSolverResponseStatus = "MPSOLVER_MODEL_INVALID"
+)
+
+// An MPSolutionResponse reports the result of a solve.
+type MPSolutionResponse struct {
+	Status         MPSolverResponseStatus `json:"status,omitempty"`
+	StatusStr      string                 `json:"statusStr,omitempty"`
+	ObjectiveValue Double                 `json:"objectiveValue,omitempty"`
+	VariableValue  []Double               `json:"variableValue,omitempty"`
+	DualValue      []Double               `json:"dualValue,omitempty"`
+	ReducedCost    []Double               `json:"reducedCost,omitempty"`
+}
+
+// boundOr returns the value of a bound or a default value if the bound is
+// nil.
+func boundOr(b *Double, def float64) float64 {
+	if b == nil {
+		return def
+	}
+	return float64(*b)
+}
+
+// ModelFromProto converts an MPModelProto to a highs.Model.  Variable and
+// constraint names, lazy-constraint flags, branching priorities, and solution
+// hints are discarded.
+func ModelFromProto(p *MPModelProto) (*highs.Model, error) {
+	if len(p.GeneralConstraint) > 0 {
+		return nil, errors.New("general constraints are not supported")
+	}
+	mInf, pInf := math.Inf(-1), math.Inf(1)
+	nc := len(p.Variable)
+	nr := len(p.Constraint)
+	m := &highs.Model{
+		Maximize: p.Maximize,
+		Offset:   float64(p.ObjectiveOffset),
+		ColCosts: make([]float64, nc),
+		ColLower: make([]float64, nc),
+		ColUpper: make([]float64, nc),
+		RowLower: make([]float64, nr),
+		RowUpper: make([]float64, nr),
+		VarTypes: make([]highs.VariableType, nc),
+	}
+
+	// Convert the variables.
+	anyInt := false
+	for c, v := range p.Variable {
+		m.ColCosts[c] = float64(v.ObjectiveCoefficient)
+		m.ColLower[c] = boundOr(v.LowerBound, mInf)
+		m.ColUpper[c] = boundOr(v.UpperBound, pInf)
+		if v.IsInteger {
+			m.VarTypes[c] = highs.IntegerType
+			anyInt = true
+		}
+	}
+	if !anyInt {
+		m.VarTypes = nil
+	}
+	m.ColNames = make([]string, nc)
+	for c, v := range p.Variable {
+		m.ColNames[c] = v.Name
+	}
+	m.ColNames = namesOrNil(m.ColNames)
+
+	// Convert the constraints.
+	for r, con := range p.Constraint {
+		if len(con.VarIndex) != len(con.Coefficient) {
+			return nil, fmt.Errorf("constraint %d: index and value must be the same length (%d vs. %d)",
+				r, len(con.VarIndex), len(con.Coefficient))
+		}
+		m.RowLower[r] = boundOr(con.LowerBound, mInf)
+		m.RowUpper[r] = boundOr(con.UpperBound, pInf)
+		for i, c := range con.VarIndex {
+			if c < 0 || int(c) >= nc {
+				return nil, fmt.Errorf("constraint %d: variable index %d is out of range", r, c)
+			}
+			m.ConstMatrix = append(m.ConstMatrix, highs.Nonzero{
+				Row: r,
+				Col: int(c),
+				Val: float64(con.Coefficient[i]),
+			})
+		}
+	}
+
+	m.RowNames = make([]string, nr)
+	for r, con := range p.Constraint {
+		m.RowNames[r] = con.Name
+	}
+	m.RowNames = namesOrNil(m.RowNames)
+
+	// Convert the quadratic objective, if any, from a sum of c*x_i*x_j
+	// terms to the upper triangle of a Hessian matrix, whose objective
+	// contribution is (1/2)x^T Q x.
+	if q := p.QuadraticObjective; q != nil {
+		if len(q.Qvar1Index) != len(q.Coefficient) || len(q.Qvar2Index) != len(q.Coefficient) {
+			return nil, errors.New("quadratic objective has inconsistent lengths")
+		}
+		hess := make(map[[2]int]float64)
+		var order [][2]int
+		for k, v := range q.Coefficient {
+			i, j := int(q.Qvar1Index[k]), int(q.Qvar2Index[k])
+			if i < 0 || i >= nc || j < 0 || j >= nc {
+				return nil, fmt.Errorf("quadratic term %d: variable index is out of range", k)
+			}
+			if i > j {
+				i, j = j, i
+			}
+			val := float64(v)
+			if i == j {
+				val *= 2.0
+			}
+			key := [2]int{i, j}
+			if _, seen := hess[key]; !seen {
+				order = append(order, key)
+			}
+			hess[key] += val
+		}
+		for _, key := range order {
+			m.HessianMatrix = append(m.HessianMatrix, highs.Nonzero{
+				Row: key[0],
+				Col: key[1],
+				Val: hess[key],
+			})
+		}
+	}
+	return m, nil
+}
+
+// valueAt returns xs[i] or a default value if i is out of range.
+func valueAt(xs []float64, i int, def float64) float64 {
+	if i < len(xs) {
+		return xs[i]
+	}
+	return def
+}
+
+// namesOrNil returns a slice of names or nil if all of the names are empty.
+func namesOrNil(names []string) []string {
+	for _, n := range names {
+		if n != "" {
+			return names
+		}
+	}
+	return nil
+}
+
+// ModelToProto converts a highs.Model to an MPModelProto.
+func ModelToProto(m *highs.Model) *MPModelProto {
+	// Determine the model's dimensions.
+	nr := 0
+	for _, n := range []int{len(m.RowLower), len(m.RowUpper), len(m.RowNames)} {
+		if n > nr {
+			nr = n
+		}
+	}
+	nc := 0
+	for _, n := range []int{len(m.ColCosts), len(m.ColLower), len(m.ColUpper), len(m.VarTypes), len(m.ColNames)} {
+		if n > nc {
+			nc = n
+		}
+	}
+	for _, nz := range m.ConstMatrix {
+		if nz.Row >= nr {
+			nr = nz.Row + 1
+		}
+		if nz.Col >= nc {
+			nc = nz.Col + 1
+		}
+	}
+	for _, nz := range m.HessianMatrix {
+		if nz.Col >= nc {
+			nc = nz.Col + 1
+		}
+	}
+
+	// Convert the variables, applying the same defaults as
+	// highs.Model.ToRawModel.
+	mInf, pInf := math.Inf(-1), math.Inf(1)
+	p := &MPModelProto{
+		Maximize:        m.Maximize,
+		ObjectiveOffset: Double(m.Offset),
+		Variable:        make([]MPVariableProto, nc),
+		Constraint:      make([]MPConstraintProto, nr),
+	}
+	for c := range p.Variable {
+		lb := Double(valueAt(m.ColLower, c, mInf))
+		ub := Double(valueAt(m.ColUpper, c, pInf))
+		v := &p.Variable[c]
+		v.ObjectiveCoefficient = Double(valueAt(m.ColCosts, c, 1.0))
+		v.LowerBound = &lb
+		v.UpperBound = &ub
+		if c < len(m.VarTypes) && m.VarTypes[c] == highs.IntegerType {
+			v.IsInteger = true
+		}
+		if c < len(m.ColNames) {
+			v.Name = m.ColNames[c]
+		}
+	}
+
+	// Convert the constraints.
+	for r := range p.Constraint {
+		lb := Double(valueAt(m.RowLower, r, mInf))
+		ub := Double(valueAt(m.RowUpper, r, pInf))
+		p.Constraint[r].LowerBound = &lb
+		p.Constraint[r].UpperBound = &ub
+		if r < len(m.RowNames) {
+			p.Constraint[r].Name = m.RowNames[r]
+		}
+	}
+	for _, nz := range m.ConstMatrix {
+		con := &p.Constraint[nz.Row]
+		con.VarIndex = append(con.VarIndex, int32(nz.Col))
+		con.Coefficient = append(con.Coefficient, Double(nz.Val))
+	}
+
+	// Convert the Hessian matrix to a sum of c*x_i*x_j terms.
+	if len(m.HessianMatrix) > 0 {
+		q := &MPQuadraticObjective{}
+		for _, nz := range m.HessianMatrix {
+			val := nz.Val
+			if nz.Row == nz.Col {
+				val /= 2.0
+			}
+			q.Qvar1Index = append(q.Qvar1Index, int32(nz.Row))
+			q.Qvar2Index = append(q.Qvar2Index, int32(nz.Col))
+			q.Coefficient = append(q.Coefficient, Double(val))
+		}
+		p.QuadraticObjective = q
+	}
+	return p
+}
+
+// toDoubles converts a slice of float64 to a slice of Double.
+func toDoubles(xs []float64) []Double {
+	if xs == nil {
+		return nil
+	}
+	ds := make([]Double, len(xs))
+	for i, x := range xs {
+		ds[i] = Double(x)
+	}
+	return ds
+}
+
+// ResponseFromSolution converts a highs.Solution to an MPSolutionResponse.
+func ResponseFromSolution(s highs.Solution) *MPSolutionResponse {
+	resp := &MPSolutionResponse{
+		StatusStr:      s.Status.String(),
+		ObjectiveValue: Double(s.Objective),
+		VariableValue:  toDoubles(s.ColumnPrimal),
+		DualValue:      toDoubles(s.RowDual),
+		ReducedCost:    toDoubles(s.ColumnDual),
+	}
+	switch s.Status {
+	case highs.Optimal, highs.ModelEmpty:
+		resp.Status = MPSolverOptimal
+	case highs.Infeasible, highs.UnboundedOrInfeasible:
+		resp.Status = MPSolverInfeasible
+	case highs.Unbounded:
+		resp.Status = MPSolverUnbounded
+	case highs.LoadError, highs.ModelError:
+		resp.Status = MPSolverModelInvalid
+	case highs.TimeLimit, highs.IterationLimit, highs.SolutionLimit, highs.Interrupt,
+		highs.ObjectiveBound, highs.ObjectiveTarget:
+		if len(s.ColumnPrimal) > 0 {
+			resp.Status = MPSolverFeasible
+		} else {
+			resp.Status = MPSolverNotSolved
+		}
+	case highs.NotSet:
+		resp.Status = MPSolverNotSolved
+	default:
+		resp.Status = MPSolverAbnormal
+	}
+	return resp
+}
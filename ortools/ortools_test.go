@@ -0,0 +1,125 @@
+// This file tests conversions between highs and OR-Tools data structures.
+
+package ortools
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// modelJSON is an OR-Tools MPModelProto in protojson form representing the
+// following model:
+//
+//	Max  x_0 + 2x_1 + 0.5x_0^2 - 3x_0x_1
+//	s.t. x_0 + x_1 <= 4
+//	     x_0 integer, 0 <= x_0, x_1 unbounded below
+const modelJSON = `{
+  "maximize": true,
+  "variable": [
+    {"lowerBound": 0, "objectiveCoefficient": 1, "isInteger": true, "name": "x0"},
+    {"upperBound": "Infinity", "objectiveCoefficient": 2, "name": "x1"}
+  ],
+  "constraint": [
+    {"varIndex": [0, 1], "coefficient": [1, 1], "upperBound": 4, "name": "c0"}
+  ],
+  "quadraticObjective": {
+    "qvar1Index": [0, 1],
+    "qvar2Index": [0, 0],
+    "coefficient": [0.5, -3]
+  }
+}`
+
+// TestModelFromProto tests conversion from an MPModelProto to a Model and
+// back.
+func TestModelFromProto(t *testing.T) {
+	// Parse the JSON and convert it to a Model.
+	var p MPModelProto
+	if err := json.Unmarshal([]byte(modelJSON), &p); err != nil {
+		t.Fatal(err)
+	}
+	m, err := ModelFromProto(&p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Check the Model's contents.
+	mInf, pInf := math.Inf(-1), math.Inf(1)
+	if !m.Maximize {
+		t.Fatal("expected Maximize to be true")
+	}
+	if !reflect.DeepEqual(m.ColLower, []float64{0.0, mInf}) {
+		t.Fatalf("unexpected ColLower %v", m.ColLower)
+	}
+	if !reflect.DeepEqual(m.ColUpper, []float64{pInf, pInf}) {
+		t.Fatalf("unexpected ColUpper %v", m.ColUpper)
+	}
+	if !reflect.DeepEqual(m.RowLower, []float64{mInf}) || !reflect.DeepEqual(m.RowUpper, []float64{4.0}) {
+		t.Fatalf("unexpected row bounds %v and %v", m.RowLower, m.RowUpper)
+	}
+	if len(m.HessianMatrix) != 2 ||
+		m.HessianMatrix[0].Row != 0 || m.HessianMatrix[0].Col != 0 || m.HessianMatrix[0].Val != 1.0 ||
+		m.HessianMatrix[1].Row != 0 || m.HessianMatrix[1].Col != 1 || m.HessianMatrix[1].Val != -3.0 {
+		t.Fatalf("unexpected HessianMatrix %v", m.HessianMatrix)
+	}
+
+	// Convert the Model back to an MPModelProto and ensure that it
+	// round-trips through JSON.
+	data, err := json.Marshal(ModelToProto(m))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"lowerBound":"-Infinity"`) {
+		t.Fatalf("expected -Infinity in %s", data)
+	}
+	var p2 MPModelProto
+	if err = json.Unmarshal(data, &p2); err != nil {
+		t.Fatal(err)
+	}
+	m2, err := ModelFromProto(&p2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(m, m2) {
+		t.Fatalf("round trip changed the model from %v to %v", m, m2)
+	}
+}
+
+// TestSolveProto tests solving a model converted from an MPModelProto and
+// converting the solution to an MPSolutionResponse.
+func TestSolveProto(t *testing.T) {
+	p := &MPModelProto{
+		Variable: []MPVariableProto{
+			{ObjectiveCoefficient: 1.0},
+			{ObjectiveCoefficient: 1.0},
+		},
+		Constraint: []MPConstraintProto{
+			{VarIndex: []int32{0, 1}, Coefficient: []Double{1, 1}, LowerBound: new(Double), UpperBound: new(Double)},
+		},
+	}
+	*p.Constraint[0].LowerBound = 23.0
+	*p.Constraint[0].UpperBound = 23.0
+	m, err := ModelFromProto(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.AddDenseRow(17.0, []float64{1.0, -1.0}, 17.0)
+	soln, err := m.Solve()
+	if errors.Is(err, exec.ErrNotFound) {
+		t.Skip("no highs executable was found")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp := ResponseFromSolution(soln)
+	if resp.Status != MPSolverOptimal {
+		t.Fatalf("expected %s but saw %s", MPSolverOptimal, resp.Status)
+	}
+	if !reflect.DeepEqual(resp.VariableValue, []Double{20.0, 3.0}) {
+		t.Fatalf("expected variable values [20 3] but saw %v", resp.VariableValue)
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Package otelhighs records the highs package's solves, model conversions, and
+model reads as OpenTelemetry spans.  Call Enable once, typically at program
+start-up:
+
+	otelhighs.Enable(nil) // Use the global TracerProvider.
+
+Spans carry attributes describing model size (highs.num_rows, highs.num_cols,
+highs.num_nonzeros) and, for solves, the model status, objective value, and
+HiGHS's measured run time.  Spans for RawModel.SolveContext are children of
+any span in the context passed to it.
+*/
+package otelhighs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lanl/highs"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies this package as the source of its spans.
+const instrumentationName = "github.com/lanl/highs"
+
+// Enable installs a highs.TraceFunc that records spans using a given
+// TracerProvider.  If tp is nil, the global TracerProvider is consulted on
+// each operation, so Enable can be called before otel.SetTracerProvider.
+func Enable(tp trace.TracerProvider) {
+	highs.SetTraceFunc(TraceFunc(tp))
+}
+
+// Disable stops recording spans.
+func Disable() {
+	highs.SetTraceFunc(nil)
+}
+
+// TraceFunc returns a highs.TraceFunc that records spans using a given
+// TracerProvider or, if tp is nil, the global TracerProvider.
+func TraceFunc(tp trace.TracerProvider) highs.TraceFunc {
+	return func(ctx context.Context, op string, attrs []highs.TraceAttr) func(error, []highs.TraceAttr) {
+		p := tp
+		if p == nil {
+			p = otel.GetTracerProvider()
+		}
+		_, span := p.Tracer(instrumentationName).Start(ctx, "highs."+op,
+			trace.WithAttributes(convertAttrs(attrs)...))
+		return func(err error, attrs []highs.TraceAttr) {
+			span.SetAttributes(convertAttrs(attrs)...)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}
+	}
+}
+
+// convertAttrs converts highs.TraceAttrs to OpenTelemetry attributes.
+func convertAttrs(attrs []highs.TraceAttr) []attribute.KeyValue {
+	kvs := make([]attribute.KeyValue, 0, len(attrs))
+	for _, a := range attrs {
+		switch v := a.Value.(type) {
+		case bool:
+			kvs = append(kvs, attribute.Bool(a.Key, v))
+		case int:
+			kvs = append(kvs, attribute.Int(a.Key, v))
+		case float64:
+			kvs = append(kvs, attribute.Float64(a.Key, v))
+		case string:
+			kvs = append(kvs, attribute.String(a.Key, v))
+		default:
+			kvs = append(kvs, attribute.String(a.Key, fmt.Sprint(v)))
+		}
+	}
+	return kvs
+}
@@ -0,0 +1,51 @@
+//go:build cgo
+
+// This file tests the recording of highs operations as OpenTelemetry spans.
+
+package otelhighs
+
+import (
+	"testing"
+
+	"github.com/lanl/highs"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// TestSpans tests that converting and solving a model produces spans with
+// the expected names and attributes.
+func TestSpans(t *testing.T) {
+	// Record spans in memory.
+	rec := tracetest.NewSpanRecorder()
+	Enable(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)))
+	defer Disable()
+
+	// Convert and solve a model.
+	var model highs.Model
+	model.AddDenseRow(23.0, []float64{1.0, 1.0}, 23.0)
+	model.AddDenseRow(17.0, []float64{1.0, -1.0}, 17.0)
+	if _, err := model.Solve(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Check the spans.
+	spans := rec.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans but saw %d", len(spans))
+	}
+	if spans[0].Name() != "highs.ToRawModel" || spans[1].Name() != "highs.Solve" {
+		t.Fatalf("unexpected span names %q and %q", spans[0].Name(), spans[1].Name())
+	}
+	found := false
+	for _, kv := range spans[1].Attributes() {
+		if kv.Key == "highs.model_status" {
+			found = true
+			if kv.Value.AsString() != "Optimal" {
+				t.Fatalf("expected status Optimal but saw %s", kv.Value.AsString())
+			}
+		}
+	}
+	if !found {
+		t.Fatal("highs.Solve span lacks a highs.model_status attribute")
+	}
+}
@@ -0,0 +1,65 @@
+// This file provides support for formulating mean-variance portfolio
+// optimization problems as QPs.
+
+package highs
+
+import (
+	"fmt"
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// NewPortfolioModel returns a Model of the Markowitz mean-variance portfolio
+// problem
+//
+//	Min.  γ·wᵀΣw − μᵀw
+//	s.t.  Σᵢ wᵢ = 1
+//	with  w ≥ 0
+//
+// where Σ is a covariance matrix, μ is a vector of expected returns, γ is a
+// positive risk-aversion coefficient, and w is the vector of portfolio
+// weights, one column per asset.  Because HiGHS minimizes ½wᵀQw + cᵀw, the
+// Model's HessianMatrix holds the upper triangle of Q = 2γΣ, and its ColCosts
+// hold c = −μ.  A nil returns slice yields the minimum-variance portfolio.
+// The budget row and nonnegativity bounds can be modified before solving,
+// for example to allow short positions.  Covariance matrices estimated from
+// data may be slightly indefinite; CheckHessianPSD can detect this.
+func NewPortfolioModel(cov mat.Symmetric, returns []float64, riskAversion float64) (*Model, error) {
+	// Check the arguments.
+	n := cov.SymmetricDim()
+	if returns != nil && len(returns) != n {
+		return nil, fmt.Errorf("returns has %d elements but the covariance matrix is %d×%d",
+			len(returns), n, n)
+	}
+	if !(riskAversion > 0.0) {
+		return nil, fmt.Errorf("risk aversion must be positive but was %v", riskAversion)
+	}
+
+	// Construct the objective function.
+	m := &Model{
+		ColCosts: make([]float64, n),
+		ColLower: make([]float64, n),
+		ColUpper: make([]float64, n),
+	}
+	pInf := math.Inf(1)
+	for i := 0; i < n; i++ {
+		if returns != nil {
+			m.ColCosts[i] = -returns[i]
+		}
+		m.ColUpper[i] = pInf
+		for j := i; j < n; j++ {
+			if v := cov.At(i, j); v != 0.0 {
+				m.HessianMatrix = append(m.HessianMatrix, Nonzero{i, j, 2.0 * riskAversion * v})
+			}
+		}
+	}
+
+	// Add the budget constraint.
+	ones := make([]float64, n)
+	for i := range ones {
+		ones[i] = 1.0
+	}
+	m.AddDenseRow(1.0, ones, 1.0)
+	return m, nil
+}
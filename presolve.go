@@ -0,0 +1,92 @@
+// This file provides support for presolving a model without solving it and
+// for inspecting the reduced model that presolve produces.
+
+package highs
+
+// #include "highs-externs.h"
+import "C"
+
+// Presolve runs HiGHS's presolver on a model without solving it and returns
+// the resulting model status.  The status is NotSet unless presolve alone
+// determines the outcome, for example by proving the model Infeasible.  Use
+// PresolvedModel to inspect the reduced model or WritePresolvedModel to
+// export it.  The original model is unaffected, but presolve options such as
+// "presolve_reduction_limit" influence the result.  As with Solve, a warning
+// from HiGHS is returned along with the status.
+func (m *RawModel) Presolve() (ModelStatus, error) {
+	obj, err := m.lock()
+	if err != nil {
+		return UnknownModelStatus, err
+	}
+	defer m.unlock()
+	status := C.Highs_presolve(obj)
+	err = newCallStatus(status, "Highs_presolve", "Presolve", sizeAttrs(obj)...)
+	if err != nil && !isWarning(err) {
+		return UnknownModelStatus, err
+	}
+	return convertHighsModelStatus(C.Highs_getModelStatus(obj)), err
+}
+
+// PresolvedModel returns the reduced model produced by the most recent call
+// to Presolve, which makes it possible to inspect the reduced problem's
+// dimensions (e.g., with Model.Counts) or to pass it to other tools.  The
+// presolved model has no names or Hessian.  VarTypes is left nil if all
+// columns are continuous.
+func (m *RawModel) PresolvedModel() (*Model, error) {
+	obj, err := m.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer m.unlock()
+
+	// Allocate memory for all of the presolved model's data.  The start
+	// slice receives an additional entry to hold the number of nonzeros.
+	nc := int(C.Highs_getPresolvedNumCol(obj))
+	nr := int(C.Highs_getPresolvedNumRow(obj))
+	nnz := int(C.Highs_getPresolvedNumNz(obj))
+	var numCol, numRow, numNz, sense C.HighsInt
+	var offset C.double
+	colCost := make([]C.double, nc)
+	colLower := make([]C.double, nc)
+	colUpper := make([]C.double, nc)
+	rowLower := make([]C.double, nr)
+	rowUpper := make([]C.double, nr)
+	aStart := make([]C.HighsInt, nr+1)
+	aIndex := make([]C.HighsInt, nnz)
+	aValue := make([]C.double, nnz)
+	integrality := make([]C.HighsInt, nc)
+
+	// Extract the presolved model from HiGHS.
+	status := C.Highs_getPresolvedLp(obj, C.kHighsMatrixFormatRowwise,
+		&numCol, &numRow, &numNz, &sense, &offset,
+		sliceToPointer(colCost), sliceToPointer(colLower), sliceToPointer(colUpper),
+		sliceToPointer(rowLower), sliceToPointer(rowUpper),
+		&aStart[0], sliceToPointer(aIndex), sliceToPointer(aValue),
+		sliceToPointer(integrality))
+	err = newCallStatus(status, "Highs_getPresolvedLp", "PresolvedModel",
+		TraceAttr{"num_col", nc}, TraceAttr{"num_row", nr}, TraceAttr{"num_nz", nnz})
+	if err != nil {
+		return nil, err
+	}
+	aStart[nr] = C.HighsInt(nnz)
+
+	// Convert C values to Go values.
+	model := &Model{
+		Maximize: sense == C.kHighsObjSenseMaximize,
+		Offset:   float64(offset),
+		ColCosts: convertSlice[float64, C.double](colCost),
+		ColLower: convertSlice[float64, C.double](colLower),
+		ColUpper: convertSlice[float64, C.double](colUpper),
+		RowLower: convertSlice[float64, C.double](rowLower),
+		RowUpper: convertSlice[float64, C.double](rowUpper),
+		VarTypes: convertIntegrality(integrality),
+	}
+	model.ConstMatrix = make([]Nonzero, 0, nnz)
+	for r := 0; r < nr; r++ {
+		for k := aStart[r]; k < aStart[r+1]; k++ {
+			model.ConstMatrix = append(model.ConstMatrix,
+				Nonzero{r, int(aIndex[k]), float64(aValue[k])})
+		}
+	}
+	return model, nil
+}
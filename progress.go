@@ -0,0 +1,146 @@
+// This file provides periodic progress reports from a running solve.
+
+package highs
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// #include "highs-externs.h"
+import "C"
+
+// A ProgressUpdate reports the progress of a solve that is still running.
+// Fields that do not apply to the solver in use, such as the MIP fields
+// during an LP solve, are left zero, and objective values that are not yet
+// known are NaN.
+type ProgressUpdate struct {
+	Elapsed           time.Duration // Wall-clock time since the solve began
+	SimplexIterations int           // Number of simplex iterations performed so far
+	IPMIterations     int           // Number of interior-point iterations performed so far
+	MIPNodes          int64         // Number of branch-and-bound nodes explored so far
+	Objective         float64       // Objective value of the best solution found so far
+	DualBound         float64       // Best proven bound on the objective value
+	Gap               float64       // Relative gap between Objective and DualBound
+}
+
+// A progressMonitor records the most recent progress HiGHS reported through
+// its interrupt callbacks.
+type progressMonitor struct {
+	mu     sync.Mutex     // Protects latest
+	latest ProgressUpdate // Most recent progress reported by HiGHS
+}
+
+// record is a callbackFunc that updates a progressMonitor with the data HiGHS
+// passes to an interrupt callback of a given type.
+func (pm *progressMonitor) record(cbType int) callbackFunc {
+	return func(_ string, out *C.HighsCallbackDataOut, _ *C.HighsCallbackDataIn) {
+		pm.mu.Lock()
+		defer pm.mu.Unlock()
+		switch cbType {
+		case cbSimplexInterrupt:
+			pm.latest.SimplexIterations = int(out.simplex_iteration_count)
+		case cbIpmInterrupt:
+			pm.latest.IPMIterations = int(out.ipm_iteration_count)
+		case cbMipInterrupt:
+			pm.latest.MIPNodes = int64(out.mip_node_count)
+			pm.latest.Objective = float64(out.mip_primal_bound)
+			pm.latest.DualBound = float64(out.mip_dual_bound)
+			pm.latest.Gap = float64(out.mip_gap)
+		}
+	}
+}
+
+// snapshot returns the most recent progress, stamped with the time elapsed
+// since a given start time.  HiGHS reports infinite bounds until a bound is
+// known; snapshot replaces these with NaN.
+func (pm *progressMonitor) snapshot(start time.Time) ProgressUpdate {
+	pm.mu.Lock()
+	pu := pm.latest
+	pm.mu.Unlock()
+	pu.Elapsed = time.Since(start)
+	for _, v := range []*float64{&pu.Objective, &pu.DualBound, &pu.Gap} {
+		if math.IsInf(*v, 0) {
+			*v = math.NaN()
+		}
+	}
+	return pu
+}
+
+// SolveWithProgress is like Solve but additionally sends a ProgressUpdate to
+// a channel at a given interval while the solve runs and once more when it
+// completes.  Periodic updates never block the solver: if the receiver is not
+// ready, the update is skipped.  The final update, however, is always
+// delivered, so the caller must keep receiving until the channel is closed.
+// SolveWithProgress closes the channel before returning, so the caller can
+// receive updates in a separate goroutine with a simple range loop.
+func (m *RawModel) SolveWithProgress(interval time.Duration, ch chan<- ProgressUpdate) (*RawSolution, error) {
+	defer close(ch)
+	if interval <= 0 {
+		return &RawSolution{}, fmt.Errorf("progress interval must be positive, not %v", interval)
+	}
+	obj, err := m.lock()
+	if err != nil {
+		return &RawSolution{}, err
+	}
+	defer m.unlock()
+
+	// Register an interrupt handler with each solver that accepts one.
+	// HiGHS invokes these periodically during the solve.
+	pm := &progressMonitor{
+		latest: ProgressUpdate{
+			Objective: math.NaN(),
+			DualBound: math.NaN(),
+			Gap:       math.NaN(),
+		},
+	}
+	ids := make([]int, 0, 3)
+	defer func() {
+		for _, id := range ids {
+			_ = m.h.removeCallback(id)
+		}
+	}()
+	for _, cbType := range []int{cbSimplexInterrupt, cbIpmInterrupt, cbMipInterrupt} {
+		id, err := m.h.addCallback(cbType, pm.record(cbType))
+		if err != nil {
+			return &RawSolution{}, err
+		}
+		ids = append(ids, id)
+	}
+
+	// Send progress updates from a separate goroutine until the solve
+	// completes.
+	start := time.Now()
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	send := func() {
+		select {
+		case ch <- pm.snapshot(start):
+		default:
+		}
+	}
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				send()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	// Solve the model, then stop the goroutine and send a final update.
+	soln, err := m.solve(context.Background(), obj, "SolveWithProgress")
+	close(done)
+	wg.Wait()
+	ch <- pm.snapshot(start)
+	return soln, err
+}
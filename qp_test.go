@@ -4,8 +4,11 @@
 package highs
 
 import (
+	"errors"
 	"math"
 	"testing"
+
+	"gonum.org/v1/gonum/mat"
 )
 
 // TestMinimalAPIQPMin mimics the first test in HiGHS's minimal_api_qp function
@@ -30,6 +33,7 @@ func TestMinimalAPIQPMin(t *testing.T) {
 
 	// Solve the model.
 	soln, err := model.Solve()
+	skipIfNoSolver(t, err)
 	if err != nil {
 		t.Fatalf("Solve failed (%s)", err)
 	}
@@ -46,3 +50,145 @@ func TestMinimalAPIQPMin(t *testing.T) {
 		t.Fatalf("objective value was %.2f but should have been -5.25", soln.Objective)
 	}
 }
+
+// TestSetDenseHessian repeats TestMinimalAPIQPMin but specifies the Hessian
+// as a dense, symmetric matrix.
+func TestSetDenseHessian(t *testing.T) {
+	// Prepare the model.
+	var model Model
+	model.ColCosts = []float64{0.0, -1.0, -3.0}
+	model.AddDenseRow(-1e30, []float64{1.0, 0.0, 1.0}, 2.0)
+	err := model.SetDenseHessian([][]float64{
+		{2.0, 0.0, -1.0},
+		{0.0, 0.2, 0.0},
+		{-1.0, 0.0, 2.0},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := []Nonzero{
+		{0, 0, 2.0},
+		{0, 2, -1.0},
+		{1, 1, 0.2},
+		{2, 2, 2.0},
+	}
+	if len(model.HessianMatrix) != len(exp) {
+		t.Fatalf("expected %v but observed %v", exp, model.HessianMatrix)
+	}
+	for i, nz := range exp {
+		if model.HessianMatrix[i] != nz {
+			t.Fatalf("expected %v but observed %v", exp, model.HessianMatrix)
+		}
+	}
+
+	// Solve the model.
+	soln, err := model.Solve()
+	skipIfNoSolver(t, err)
+	if err != nil {
+		t.Fatalf("Solve failed (%s)", err)
+	}
+	primal := roundFloats(0.001, soln.ColumnPrimal)
+	compSlices(t, "ColumnPrimal", primal, []float64{0.5, 5.0, 1.5})
+
+	// Ensure that non-square and asymmetric matrices are rejected.
+	if model.SetDenseHessian([][]float64{{1.0, 2.0}}) == nil {
+		t.Fatal("SetDenseHessian accepted a non-square matrix")
+	}
+	if model.SetDenseHessian([][]float64{{1.0, 2.0}, {3.0, 1.0}}) == nil {
+		t.Fatal("SetDenseHessian accepted an asymmetric matrix")
+	}
+}
+
+// TestEvalObjective evaluates the objective function of the model from
+// TestMinimalAPIQPMin at its optimal point.
+func TestEvalObjective(t *testing.T) {
+	// Prepare the model.
+	var model Model
+	model.ColCosts = []float64{0.0, -1.0, -3.0}
+	model.AddDenseRow(-1e30, []float64{1.0, 0.0, 1.0}, 2.0)
+	model.HessianMatrix = []Nonzero{
+		{0, 0, 2.0},
+		{0, 2, -1.0},
+		{1, 1, 0.2},
+		{2, 2, 2.0},
+	}
+
+	// Evaluate the objective function.
+	obj, err := model.EvalObjective([]float64{0.5, 5.0, 1.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Round(obj/0.001)*0.001 != -5.25 {
+		t.Fatalf("objective value was %.2f but should have been -5.25", obj)
+	}
+
+	// Ensure that a point of the wrong dimension is rejected.
+	if _, err = model.EvalObjective([]float64{0.5, 5.0}); err == nil {
+		t.Fatal("EvalObjective accepted a point of the wrong dimension")
+	}
+}
+
+// TestMIQP tests that a model with both a Hessian matrix and integer columns
+// is rejected with ErrUnsupportedMIQP.
+func TestMIQP(t *testing.T) {
+	var model Model
+	model.ColCosts = []float64{0.0, -1.0, -3.0}
+	model.AddDenseRow(-1e30, []float64{1.0, 0.0, 1.0}, 2.0)
+	model.HessianMatrix = []Nonzero{
+		{0, 0, 2.0},
+		{0, 2, -1.0},
+		{1, 1, 0.2},
+		{2, 2, 2.0},
+	}
+	model.VarTypes = []VariableType{IntegerType, ContinuousType, ContinuousType}
+	if _, err := model.Solve(); !errors.Is(err, ErrUnsupportedMIQP) {
+		t.Fatalf("expected ErrUnsupportedMIQP but saw %v", err)
+	}
+}
+
+// TestSolveLeastSquares tests that least-squares problems are solved
+// correctly with and without a bound.  It uses the data from
+// TestNewLeastSquaresModel, whose unconstrained solution is x = (2/3, 5/3).
+// Bounding x_1 above by 1 moves the solution to x = (1, 1).
+func TestSolveLeastSquares(t *testing.T) {
+	// Solve the unconstrained problem.
+	a := mat.NewDense(3, 2, []float64{1.0, 0.0, 0.0, 1.0, 1.0, 1.0})
+	b := []float64{1.0, 2.0, 2.0}
+	soln, err := SolveLeastSquares(a, b)
+	skipIfNoSolver(t, err)
+	checkErr(t, err)
+	if soln.Status != Optimal {
+		t.Fatalf("Solve returned %s instead of Optimal", soln.Status)
+	}
+	compSlices(t, "ColumnPrimal", roundFloats(0.001, soln.ColumnPrimal), []float64{0.667, 1.667})
+	if math.Abs(soln.Objective-1.0/3.0) > 1e-6 {
+		t.Fatalf("expected a squared residual of 1/3 but saw %v", soln.Objective)
+	}
+
+	// Solve the bounded problem.
+	m, err := NewLeastSquaresModel(a, b)
+	checkErr(t, err)
+	m.ColUpper[1] = 1.0
+	soln, err = m.Solve()
+	checkErr(t, err)
+	compSlices(t, "ColumnPrimal", roundFloats(0.001, soln.ColumnPrimal), []float64{1.0, 1.0})
+}
+
+// TestSolvePortfolio solves a minimum-variance portfolio problem with two
+// uncorrelated assets whose variances are 1 and 3.  The optimal weights are
+// inversely proportional to the variances.
+func TestSolvePortfolio(t *testing.T) {
+	cov := mat.NewSymDense(2, []float64{1.0, 0.0, 0.0, 3.0})
+	m, err := NewPortfolioModel(cov, nil, 1.0)
+	checkErr(t, err)
+	soln, err := m.Solve()
+	skipIfNoSolver(t, err)
+	checkErr(t, err)
+	if soln.Status != Optimal {
+		t.Fatalf("Solve returned %s instead of Optimal", soln.Status)
+	}
+	compSlices(t, "ColumnPrimal", roundFloats(0.001, soln.ColumnPrimal), []float64{0.75, 0.25})
+	if math.Abs(soln.Objective-0.75) > 1e-6 {
+		t.Fatalf("expected a variance of 0.75 but saw %v", soln.Objective)
+	}
+}
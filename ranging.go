@@ -0,0 +1,122 @@
+// This file provides support for sensitivity analysis (ranging) of an LP's
+// optimal solution.
+
+package highs
+
+// #include "highs-externs.h"
+import "C"
+
+// A RangingPoint describes how far a single cost or bound can move in one
+// direction before the optimal basis changes.
+type RangingPoint struct {
+	Value     float64       // Value of the cost or bound at which the basis changes
+	Objective float64       // Objective value when the cost or bound equals Value
+	Entering  BasicVariable // Variable that enters the basis at Value (Index -1 if none)
+	Leaving   BasicVariable // Variable that leaves the basis at Value (Index -1 if none)
+}
+
+// A Ranging holds the results of sensitivity analysis of an LP's optimal
+// solution.  Each slice has one element per column or row, as appropriate.
+type Ranging struct {
+	ColCostUp    []RangingPoint // Effect of increasing each column's cost
+	ColCostDown  []RangingPoint // Effect of decreasing each column's cost
+	ColBoundUp   []RangingPoint // Effect of increasing each column's value
+	ColBoundDown []RangingPoint // Effect of decreasing each column's value
+	RowBoundUp   []RangingPoint // Effect of increasing each row's activity
+	RowBoundDown []RangingPoint // Effect of decreasing each row's activity
+}
+
+// rangingBuffers holds the C arrays into which HiGHS writes one direction of
+// one kind of ranging data.
+type rangingBuffers struct {
+	value     []C.double
+	objective []C.double
+	inVar     []C.HighsInt
+	outVar    []C.HighsInt
+}
+
+// newRangingBuffers allocates rangingBuffers for n columns or rows.
+func newRangingBuffers(n int) *rangingBuffers {
+	return &rangingBuffers{
+		value:     make([]C.double, n),
+		objective: make([]C.double, n),
+		inVar:     make([]C.HighsInt, n),
+		outVar:    make([]C.HighsInt, n),
+	}
+}
+
+// points converts rangingBuffers from C to Go.  HiGHS numbers variables with
+// the columns first, followed by the rows, and uses a negative number to
+// indicate no variable.
+func (rb *rangingBuffers) points(nc int) []RangingPoint {
+	variable := func(v C.HighsInt) BasicVariable {
+		switch {
+		case v < 0:
+			return BasicVariable{Index: -1}
+		case int(v) < nc:
+			return BasicVariable{Index: int(v)}
+		default:
+			return BasicVariable{Index: int(v) - nc, Row: true}
+		}
+	}
+	pts := make([]RangingPoint, len(rb.value))
+	for i := range pts {
+		pts[i] = RangingPoint{
+			Value:     float64(rb.value[i]),
+			Objective: float64(rb.objective[i]),
+			Entering:  variable(rb.inVar[i]),
+			Leaving:   variable(rb.outVar[i]),
+		}
+	}
+	return pts
+}
+
+// Ranging performs sensitivity analysis of the optimal solution produced by
+// the most recent solve.  It reports, for each column's cost and for each
+// column's and row's bounds, the range over which the value can vary without
+// changing the optimal basis, the resulting objective value, and the
+// variables that would enter and leave the basis.  This provides classic LP
+// sensitivity analysis without re-solving perturbed models.  Like
+// BasicVariables, Ranging requires an LP that was solved to an optimal
+// basis; HiGHS reports an error otherwise.
+func (m *RawModel) Ranging() (Ranging, error) {
+	obj, err := m.lock()
+	if err != nil {
+		return Ranging{}, err
+	}
+	defer m.unlock()
+
+	// Ask HiGHS for the ranging data.
+	nc := int(C.Highs_getNumCol(obj))
+	nr := int(C.Highs_getNumRow(obj))
+	ccUp, ccDn := newRangingBuffers(nc), newRangingBuffers(nc)
+	cbUp, cbDn := newRangingBuffers(nc), newRangingBuffers(nc)
+	rbUp, rbDn := newRangingBuffers(nr), newRangingBuffers(nr)
+	status := C.Highs_getRanging(obj,
+		sliceToPointer(ccUp.value), sliceToPointer(ccUp.objective),
+		sliceToPointer(ccUp.inVar), sliceToPointer(ccUp.outVar),
+		sliceToPointer(ccDn.value), sliceToPointer(ccDn.objective),
+		sliceToPointer(ccDn.inVar), sliceToPointer(ccDn.outVar),
+		sliceToPointer(cbUp.value), sliceToPointer(cbUp.objective),
+		sliceToPointer(cbUp.inVar), sliceToPointer(cbUp.outVar),
+		sliceToPointer(cbDn.value), sliceToPointer(cbDn.objective),
+		sliceToPointer(cbDn.inVar), sliceToPointer(cbDn.outVar),
+		sliceToPointer(rbUp.value), sliceToPointer(rbUp.objective),
+		sliceToPointer(rbUp.inVar), sliceToPointer(rbUp.outVar),
+		sliceToPointer(rbDn.value), sliceToPointer(rbDn.objective),
+		sliceToPointer(rbDn.inVar), sliceToPointer(rbDn.outVar))
+	err = newCallStatus(status, "Highs_getRanging", "Ranging")
+	if err != nil {
+		return Ranging{}, err
+	}
+
+	// Convert the ranging data from C to Go.
+	return Ranging{
+		ColCostUp:    ccUp.points(nc),
+		ColCostDown:  ccDn.points(nc),
+		ColBoundUp:   cbUp.points(nc),
+		ColBoundDown: cbDn.points(nc),
+		RowBoundUp:   rbUp.points(nc),
+		RowBoundDown: rbDn.points(nc),
+	}, nil
+}
@@ -0,0 +1,176 @@
+// This file provides support for rescaling rows whose coefficients are
+// nearly rational to have integer coefficients.
+
+package highs
+
+import (
+	"fmt"
+	"math"
+)
+
+// RationalizeOptions control how RationalizeRows detects nearly rational
+// coefficients.  The zero value selects the defaults listed below.
+type RationalizeOptions struct {
+	Tolerance      float64 // Largest relative difference between a coefficient and its rational approximation (default 1e-6)
+	MaxDenominator int     // Largest denominator, and largest row scale factor, considered (default 100)
+}
+
+// A RowScaling describes how RationalizeRows transformed a single row.
+type RowScaling struct {
+	Row       int     // Index of the row
+	Factor    float64 // Positive factor by which the row's coefficients and bounds were multiplied
+	MaxChange float64 // Largest absolute change to a scaled coefficient due to rounding
+}
+
+// rationalApprox returns the simplest fraction p/q with q ≤ maxDen that lies
+// within a relative tolerance of v, using the convergents of v's continued
+// fraction.  It returns false if there is no such fraction.
+func rationalApprox(v float64, maxDen int64, tol float64) (p, q int64, ok bool) {
+	x := math.Abs(v)
+	limit := tol * math.Max(1.0, x)
+	h0, h1 := int64(0), int64(1) // Numerators of the previous two convergents
+	k0, k1 := int64(1), int64(0) // Denominators of the previous two convergents
+	for i := 0; i < 64; i++ {
+		a := math.Floor(x)
+		if a > 1e15 {
+			break // Avoid integer overflow.
+		}
+		h2 := int64(a)*h1 + h0
+		k2 := int64(a)*k1 + k0
+		if k2 > maxDen {
+			break
+		}
+		h0, h1, k0, k1 = h1, h2, k1, k2
+		if math.Abs(math.Abs(v)-float64(h1)/float64(k1)) <= limit {
+			if v < 0 {
+				h1 = -h1
+			}
+			return h1, k1, true
+		}
+		frac := x - a
+		if frac == 0.0 {
+			break
+		}
+		x = 1.0 / frac
+	}
+	return 0, 0, false
+}
+
+// gcd returns the greatest common divisor of two positive integers.
+func gcd(a, b int64) int64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// RationalizeRows returns a copy of the model in which each row whose
+// coefficients are all within a tolerance of fractions with small
+// denominators is multiplied by the least common multiple of those
+// denominators and its coefficients rounded to the resulting integers.  This
+// is a common remedy for MIPs whose data were written with limited
+// precision, such as 0.3333334 in place of ⅓.  Rows whose coefficients are
+// already integers, rows that would need a factor larger than
+// opts.MaxDenominator, and rows with any coefficient that is not nearly
+// rational are left unchanged.  The result's constraint matrix is sorted in
+// row-major order with duplicate entries removed.
+//
+// RationalizeRows also returns a description of each row it changed.  Because
+// each row is multiplied by a positive factor, the set of feasible solutions
+// changes only by the rounding of coefficients, which is reported in
+// MaxChange, and the dual value of a changed row is the original row's dual
+// value divided by Factor.
+func (m *Model) RationalizeRows(opts RationalizeOptions) (Model, []RowScaling, error) {
+	// Apply default options.
+	if opts.Tolerance == 0.0 {
+		opts.Tolerance = 1e-6
+	}
+	if opts.MaxDenominator == 0 {
+		opts.MaxDenominator = 100
+	}
+	if opts.Tolerance < 0.0 || opts.MaxDenominator < 0 {
+		return Model{}, nil, fmt.Errorf("tolerance and maximum denominator must be nonnegative")
+	}
+	maxDen := int64(opts.MaxDenominator)
+
+	// Group the nonzeros by row.
+	nr, _ := m.modelSize()
+	nzs, err := filterNonzeros(m.ConstMatrix, false)
+	if err != nil {
+		return Model{}, nil, err
+	}
+	byRow := make([][]Nonzero, nr)
+	for _, nz := range nzs {
+		byRow[nz.Row] = append(byRow[nz.Row], nz)
+	}
+
+	// Determine the factor for each row.
+	factor := make([]int64, nr)
+	for r, row := range byRow {
+		f := int64(1)
+		for _, nz := range row {
+			if nz.Val == 0.0 {
+				continue
+			}
+			_, q, ok := rationalApprox(nz.Val, maxDen, opts.Tolerance)
+			if !ok {
+				f = 0
+				break
+			}
+			f = f / gcd(f, q) * q
+			if f > maxDen {
+				f = 0
+				break
+			}
+		}
+		factor[r] = f
+	}
+
+	// Scale and round each row that is nearly but not exactly rational.
+	scaled := *m
+	scaled.ColCosts = append([]float64(nil), m.ColCosts...)
+	scaled.ColLower = append([]float64(nil), m.ColLower...)
+	scaled.ColUpper = append([]float64(nil), m.ColUpper...)
+	scaled.RowLower = append([]float64(nil), m.RowLower...)
+	scaled.RowUpper = append([]float64(nil), m.RowUpper...)
+	scaled.ConstMatrix = make([]Nonzero, len(nzs))
+	scaled.HessianMatrix = append([]Nonzero(nil), m.HessianMatrix...)
+	scaled.VarTypes = append([]VariableType(nil), m.VarTypes...)
+	scaled.ColNames = append([]string(nil), m.ColNames...)
+	scaled.RowNames = append([]string(nil), m.RowNames...)
+	scaled.ColTags = append([]any(nil), m.ColTags...)
+	scaled.RowTags = append([]any(nil), m.RowTags...)
+	var changes []RowScaling
+	for r, row := range byRow {
+		if factor[r] == 0 {
+			continue
+		}
+		f := float64(factor[r])
+		maxChange := 0.0
+		for _, nz := range row {
+			v := nz.Val * f
+			maxChange = math.Max(maxChange, math.Abs(math.Round(v)-v))
+		}
+		if f == 1.0 && maxChange == 0.0 {
+			continue
+		}
+		changes = append(changes, RowScaling{Row: r, Factor: f, MaxChange: maxChange})
+	}
+	changed := make(map[int]float64, len(changes))
+	for _, rs := range changes {
+		changed[rs.Row] = rs.Factor
+		if rs.Row < len(scaled.RowLower) {
+			scaled.RowLower[rs.Row] *= rs.Factor
+		}
+		if rs.Row < len(scaled.RowUpper) {
+			scaled.RowUpper[rs.Row] *= rs.Factor
+		}
+	}
+	for k, nz := range nzs {
+		if f, ok := changed[nz.Row]; ok {
+			nz.Val = math.Round(nz.Val * f)
+		}
+		scaled.ConstMatrix[k] = nz
+	}
+	return scaled, changes, nil
+}
@@ -0,0 +1,75 @@
+// This file tests the rescaling of nearly rational rows.
+
+package highs
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// TestRationalApprox tests the rational approximation of a few values.
+func TestRationalApprox(t *testing.T) {
+	for _, tc := range []struct {
+		v      float64
+		p, q   int64
+		approx bool
+	}{
+		{0.3333334, 1, 3, true},
+		{-0.6666666, -2, 3, true},
+		{2.0000001, 2, 1, true},
+		{0.142857, 1, 7, true},
+		{math.Pi, 0, 0, false},
+	} {
+		p, q, ok := rationalApprox(tc.v, 100, 1e-6)
+		if p != tc.p || q != tc.q || ok != tc.approx {
+			t.Fatalf("approximating %v: expected (%d, %d, %v) but saw (%d, %d, %v)",
+				tc.v, tc.p, tc.q, tc.approx, p, q, ok)
+		}
+	}
+}
+
+// TestRationalizeRows rationalizes the following model:
+//
+//	0.3333334*x_0 + 0.5*x_1   <= 2
+//	2.0000001*x_0 + x_1       >= 1
+//	x_0       + x_1           == 3
+//	3.14159265*x_0 + 0.25*x_1 <= 4
+//
+// The first row should be scaled by 6, the second snapped to integers, and
+// the third and fourth left alone.
+func TestRationalizeRows(t *testing.T) {
+	// Prepare the model.
+	var model Model
+	model.AddDenseRow(math.Inf(-1), []float64{0.3333334, 0.5}, 2.0)
+	model.AddDenseRow(1.0, []float64{2.0000001, 1.0}, math.Inf(1))
+	model.AddDenseRow(3.0, []float64{1.0, 1.0}, 3.0)
+	model.AddDenseRow(math.Inf(-1), []float64{3.14159265, 0.25}, 4.0)
+
+	// Rationalize the rows.
+	scaled, changes, err := model.RationalizeRows(RationalizeOptions{})
+	checkErr(t, err)
+	if len(changes) != 2 || changes[0].Row != 0 || changes[0].Factor != 6.0 ||
+		changes[1].Row != 1 || changes[1].Factor != 1.0 {
+		t.Fatalf("unexpected changes %+v", changes)
+	}
+	if changes[0].MaxChange > 1e-6 || changes[1].MaxChange > 1e-6 {
+		t.Fatalf("unexpectedly large changes %+v", changes)
+	}
+	expMat := []Nonzero{
+		{0, 0, 2.0}, {0, 1, 3.0},
+		{1, 0, 2.0}, {1, 1, 1.0},
+		{2, 0, 1.0}, {2, 1, 1.0},
+		{3, 0, 3.14159265}, {3, 1, 0.25},
+	}
+	if !reflect.DeepEqual(scaled.ConstMatrix, expMat) {
+		t.Fatalf("expected matrix %v but saw %v", expMat, scaled.ConstMatrix)
+	}
+	compSlices(t, "RowLower", scaled.RowLower, []float64{math.Inf(-1), 1.0, 3.0, math.Inf(-1)})
+	compSlices(t, "RowUpper", scaled.RowUpper, []float64{12.0, math.Inf(1), 3.0, 4.0})
+
+	// Ensure that the original model was not modified.
+	if model.ConstMatrix[0].Val != 0.3333334 || model.RowUpper[0] != 2.0 {
+		t.Fatal("RationalizeRows modified the original model")
+	}
+}
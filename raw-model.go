@@ -4,12 +4,21 @@
 package highs
 
 import (
+	"bufio"
+	"compress/gzip"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"math"
 	"os"
+	"path/filepath"
 	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
 	"unsafe"
 )
 
@@ -18,38 +27,95 @@ import (
 // #include <interfaces/highs_c_api.h>
 import "C"
 
-// A RawModel represents a HiGHS low-level model.
+// A RawModel represents a HiGHS low-level model.  A RawModel's methods must
+// not be called concurrently with each other, with the exception of RunTime,
+// which can be used to monitor a Solve in progress in another goroutine.
 type RawModel struct {
-	obj unsafe.Pointer
+	h         *handle        // Reference-counted HiGHS object
+	closed    atomic.Bool    // true once Close has been called
+	logSet    bool           // true=a log function has been registered
+	logID     int            // Callback ID of the function registered by SetLogFunc
+	logPrefix string         // Text prepended to each line passed to the log function
+	stats     *StatsRecorder // Recorder of per-solve statistics, if any
+	cbIDs     []int          // Callback IDs of the functions registered by SetCallback
 }
 
 // NewRawModel allocates and returns an empty raw model.
 func NewRawModel() *RawModel {
-	model := &RawModel{}
-	model.obj = C.Highs_create()
-	runtime.SetFinalizer(model, func(m *RawModel) {
-		C.Highs_destroy(m.obj)
-	})
+	model := &RawModel{h: newHandle()}
+	runtime.SetFinalizer(model, (*RawModel).Close)
 	return model
 }
 
-// ReadModelFromFile overwrites the model with a model read in MPS format from
-// a named file.
-func (m *RawModel) ReadModelFromFile(fn string) error {
-	// Convert the filename argument from Go to C.
-	fName := C.CString(fn)
-	defer C.free(unsafe.Pointer(fName))
+// Close releases a model's reference to the underlying HiGHS object.  The
+// object itself is destroyed once every RawSolution produced by the model has
+// also been released.  Calling Close is optional—a RawModel that is no longer
+// reachable is closed automatically—but doing so frees memory promptly.
+// Calling Close more than once is harmless.  All other RawModel methods
+// return ErrClosed after Close has been called.
+func (m *RawModel) Close() error {
+	if m.h == nil || m.closed.Swap(true) {
+		return nil
+	}
+	runtime.SetFinalizer(m, nil)
+	m.h.release()
+	return nil
+}
 
-	// Read into the model.
-	status := C.Highs_readModel(m.obj, fName)
-	return newCallStatus(status, "Highs_readModel", "ReadModelFromFile")
+// RunTime returns the amount of time HiGHS has spent solving the model.  It
+// is safe to call RunTime while another goroutine is executing Solve; it is
+// the only RawModel method for which this is true.  Other methods that
+// report on a model wait for the solve to finish.
+func (m *RawModel) RunTime() (time.Duration, error) {
+	if m.closed.Load() {
+		return 0, ErrClosed
+	}
+	return m.h.runTime()
 }
 
-// ReadModel overwrites the model with a model read in MPS format from an
-// io.Reader.
-func (m *RawModel) ReadModel(r io.Reader) error {
+// lock locks a model's handle and returns the underlying HiGHS object.  It
+// returns ErrClosed, leaving the handle unlocked, if the model has been
+// closed.
+func (m *RawModel) lock() (unsafe.Pointer, error) {
+	if m.closed.Load() {
+		return nil, ErrClosed
+	}
+	return m.h.lock()
+}
+
+// unlock unlocks a model's handle.
+func (m *RawModel) unlock() {
+	m.h.unlock()
+}
+
+// gzipInnerExt reports whether a filename ends in ".gz" and, if so, returns
+// the extension that precedes it (e.g., ".mps" for "model.mps.gz").
+func gzipInnerExt(fn string) (string, bool) {
+	if !strings.HasSuffix(strings.ToLower(fn), ".gz") {
+		return "", false
+	}
+	return filepath.Ext(fn[:len(fn)-3]), true
+}
+
+// modelFileExt returns the extension that indicates the format of a named
+// model file, looking past a trailing ".gz".  It returns an error if the
+// name provides no such extension, as in "model.gz".
+func modelFileExt(fn string) (string, error) {
+	ext, ok := gzipInnerExt(fn)
+	if !ok {
+		return filepath.Ext(fn), nil
+	}
+	if ext == "" {
+		return "", fmt.Errorf("no format extension precedes .gz in %s", fn)
+	}
+	return ext, nil
+}
+
+// readModelVia copies an io.Reader to a throwaway file with a given extension
+// and reads the model from that file.  The caller must hold the model's lock.
+func readModelVia(obj unsafe.Pointer, r io.Reader, ext, goName string) error {
 	// Copy from the reader to a throwaway file.
-	tFile, err := os.CreateTemp("", "highs-*.mps")
+	tFile, err := os.CreateTemp("", "highs-*"+ext)
 	if err != nil {
 		return err
 	}
@@ -57,6 +123,7 @@ func (m *RawModel) ReadModel(r io.Reader) error {
 	defer os.Remove(fName)
 	_, err = io.Copy(tFile, r)
 	if err != nil {
+		tFile.Close()
 		return err
 	}
 	err = tFile.Close()
@@ -69,25 +136,17 @@ func (m *RawModel) ReadModel(r io.Reader) error {
 	defer C.free(unsafe.Pointer(cFName))
 
 	// Read into the model.
-	status := C.Highs_readModel(m.obj, cFName)
-	return newCallStatus(status, "Highs_readModel", "ReadModel")
-}
-
-// WriteModelToFile writes a model in MPS format to a named file.
-func (m *RawModel) WriteModelToFile(fn string) error {
-	// Convert the filename argument from Go to C.
-	cFName := C.CString(fn)
-	defer C.free(unsafe.Pointer(cFName))
-
-	// Write the model.
-	status := C.Highs_writeModel(m.obj, cFName)
-	return newCallStatus(status, "Highs_writeModel", "WriteModelToFile")
+	status := C.Highs_readModel(obj, cFName)
+	return newCallStatus(status, "Highs_readModel", goName,
+		TraceAttr{"filename", fName})
 }
 
-// WriteModel writes a model in MPS format to an io.Writer.
-func (m *RawModel) WriteModel(w io.Writer) error {
+// writeModelVia writes a model to a throwaway file with a given extension and
+// copies that file to an io.Writer.  Warnings from HiGHS are returned only if
+// no other error occurs.  The caller must hold the model's lock.
+func writeModelVia(obj unsafe.Pointer, w io.Writer, ext, goName string) error {
 	// Create a throwaway file to use as a staging area.
-	tFile, err := os.CreateTemp("", "highs-*.mps")
+	tFile, err := os.CreateTemp("", "highs-*"+ext)
 	if err != nil {
 		return err
 	}
@@ -103,17 +162,13 @@ func (m *RawModel) WriteModel(w io.Writer) error {
 	defer C.free(unsafe.Pointer(cFName))
 
 	// Write the model to the throwaway file.
-	status := C.Highs_writeModel(m.obj, cFName)
-	err = newCallStatus(status, "Highs_writeModel", "WriteModel")
+	status := C.Highs_writeModel(obj, cFName)
+	hErr := newCallStatus(status, "Highs_writeModel", goName)
 
 	// Ignore warnings (common for Highs_writeModel).
 	var cs CallStatus
-	if errors.As(err, &cs) {
-		if !cs.IsWarning() {
-			return err
-		}
-	} else if err != nil {
-		return err
+	if errors.As(hErr, &cs) && !cs.IsWarning() {
+		return hErr
 	}
 
 	// Copy the contents of the throwaway file to the io.Writer.
@@ -123,17 +178,284 @@ func (m *RawModel) WriteModel(w io.Writer) error {
 	}
 	_, err = io.Copy(w, tFile)
 	if err != nil {
+		tFile.Close()
 		return err
 	}
 	err = tFile.Close()
 	if err != nil {
 		return err
 	}
-	return cs // Propagate any warnings.
+	return hErr // Propagate any warnings.
+}
+
+// ReadModelFromFile overwrites the model with a model read from a named file.
+// The file's format is determined by its extension (".mps", ".lp", or
+// ".ems").  Files whose names end in ".gz" (e.g., "model.mps.gz") are
+// decompressed transparently.
+func (m *RawModel) ReadModelFromFile(fn string) error {
+	ext, err := modelFileExt(fn)
+	if err != nil {
+		return err
+	}
+	return m.readModelFromFile(fn, ext, "ReadModelFromFile")
+}
+
+// ReadModelFromFileFormat is like ReadModelFromFile but reads the file in a
+// given format regardless of its extension.
+func (m *RawModel) ReadModelFromFileFormat(fn string, f ModelFormat) error {
+	ext, err := f.extension()
+	if err != nil {
+		return err
+	}
+	return m.readModelFromFile(fn, ext, "ReadModelFromFileFormat")
+}
+
+// readModelFromFile implements ReadModelFromFile and ReadModelFromFileFormat.
+// ext is the extension that indicates the file's format to HiGHS.
+func (m *RawModel) readModelFromFile(fn, ext, goName string) (err error) {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+	end := startTrace(context.Background(), goName, TraceAttr{"highs.file", fn})
+	defer func() { end(err, sizeAttrs(obj)...) }()
+
+	// Decompress gzipped files in Go rather than rely on HiGHS having
+	// been built with zlib support.
+	if _, ok := gzipInnerExt(fn); ok {
+		f, err := os.Open(fn)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer zr.Close()
+		return readModelVia(obj, zr, ext, goName)
+	}
+
+	// HiGHS determines the format from the extension, so copy files
+	// whose extension does not indicate the desired format.
+	if !strings.EqualFold(filepath.Ext(fn), ext) {
+		f, err := os.Open(fn)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		return readModelVia(obj, f, ext, goName)
+	}
+
+	// Convert the filename argument from Go to C.
+	fName := C.CString(fn)
+	defer C.free(unsafe.Pointer(fName))
+
+	// Read into the model.
+	status := C.Highs_readModel(obj, fName)
+	return newCallStatus(status, "Highs_readModel", goName,
+		TraceAttr{"filename", fn})
+}
+
+// ReadModel overwrites the model with a model read in MPS format from an
+// io.Reader.  gzip-compressed data are detected and decompressed
+// transparently.
+func (m *RawModel) ReadModel(r io.Reader) error {
+	return m.readModel(r, ".mps", "ReadModel")
+}
+
+// ReadModelFormat is like ReadModel but reads the model in a given format.
+func (m *RawModel) ReadModelFormat(r io.Reader, f ModelFormat) error {
+	ext, err := f.extension()
+	if err != nil {
+		return err
+	}
+	return m.readModel(r, ext, "ReadModelFormat")
+}
+
+// readModel implements ReadModel and ReadModelFormat.  ext is the extension
+// that indicates the model's format to HiGHS.
+func (m *RawModel) readModel(r io.Reader, ext, goName string) (err error) {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+	end := startTrace(context.Background(), goName)
+	defer func() { end(err, sizeAttrs(obj)...) }()
+
+	// Check for the gzip magic number.
+	br := bufio.NewReader(r)
+	var src io.Reader = br
+	magic, err := br.Peek(2)
+	if err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return err
+		}
+		defer zr.Close()
+		src = zr
+	}
+	return readModelVia(obj, src, ext, goName)
+}
+
+// WriteModelToFile writes a model to a named file.  The file's format is
+// determined by its extension (".mps", ".lp", or ".ems").  Files whose names
+// end in ".gz" (e.g., "model.mps.gz") are compressed transparently.
+func (m *RawModel) WriteModelToFile(fn string) error {
+	ext, err := modelFileExt(fn)
+	if err != nil {
+		return err
+	}
+	return m.writeModelToFile(fn, ext, "WriteModelToFile")
+}
+
+// WriteModelToFileFormat is like WriteModelToFile but writes the file in a
+// given format regardless of its extension.
+func (m *RawModel) WriteModelToFileFormat(fn string, f ModelFormat) error {
+	ext, err := f.extension()
+	if err != nil {
+		return err
+	}
+	return m.writeModelToFile(fn, ext, "WriteModelToFileFormat")
+}
+
+// writeModelToFile implements WriteModelToFile and WriteModelToFileFormat.
+// ext is the extension that indicates the desired format to HiGHS.
+func (m *RawModel) writeModelToFile(fn, ext, goName string) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+
+	// Compress gzipped files in Go rather than rely on HiGHS having been
+	// built with zlib support.  Likewise, stage files whose extension
+	// does not indicate the desired format through a throwaway file.
+	_, gz := gzipInnerExt(fn)
+	if gz || !strings.EqualFold(filepath.Ext(fn), ext) {
+		f, err := os.Create(fn)
+		if err != nil {
+			return err
+		}
+		fail := func(err error) error {
+			f.Close()
+			os.Remove(fn)
+			return err
+		}
+		var w io.Writer = f
+		var zw *gzip.Writer
+		if gz {
+			zw = gzip.NewWriter(f)
+			w = zw
+		}
+		wErr := writeModelVia(obj, w, ext, goName)
+		if wErr != nil && !isWarning(wErr) {
+			return fail(wErr)
+		}
+		if zw != nil {
+			err = zw.Close()
+			if err != nil {
+				return fail(err)
+			}
+		}
+		err = f.Close()
+		if err != nil {
+			os.Remove(fn)
+			return err
+		}
+		return wErr // Propagate any warnings.
+	}
+
+	// Convert the filename argument from Go to C.
+	cFName := C.CString(fn)
+	defer C.free(unsafe.Pointer(cFName))
+
+	// Write the model.
+	status := C.Highs_writeModel(obj, cFName)
+	return newCallStatus(status, "Highs_writeModel", goName)
+}
+
+// WriteModel writes a model in MPS format to an io.Writer.
+func (m *RawModel) WriteModel(w io.Writer) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+	return writeModelVia(obj, w, ".mps", "WriteModel")
+}
+
+// WriteModelFormat is like WriteModel but writes the model in a given
+// format.
+func (m *RawModel) WriteModelFormat(w io.Writer, f ModelFormat) error {
+	ext, err := f.extension()
+	if err != nil {
+		return err
+	}
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+	return writeModelVia(obj, w, ext, "WriteModelFormat")
+}
+
+// WritePresolvedModel presolves a model and writes the reduced model that
+// presolve produces to an io.Writer in a given format.  Inspecting the
+// presolved model can help explain why a model solves slowly or why HiGHS
+// reports it as infeasible.  The original model is unaffected, but presolve
+// options such as "presolve_reduction_limit" influence the result.
+// Warnings from HiGHS are returned only if no other error occurs.
+func (m *RawModel) WritePresolvedModel(w io.Writer, f ModelFormat) error {
+	ext, err := f.extension()
+	if err != nil {
+		return err
+	}
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+
+	// Presolve the model.
+	status := C.Highs_presolve(obj)
+	pErr := newCallStatus(status, "Highs_presolve", "WritePresolvedModel")
+	if pErr != nil && !isWarning(pErr) {
+		return pErr
+	}
+
+	// Write the presolved model via a throwaway file.
+	var wErr error
+	err = writeViaTempFile(w, ext, func(fn string) error {
+		cFName := C.CString(fn)
+		defer C.free(unsafe.Pointer(cFName))
+		status := C.Highs_writePresolvedModel(obj, cFName)
+		wErr = newCallStatus(status, "Highs_writePresolvedModel", "WritePresolvedModel")
+		if wErr != nil && !isWarning(wErr) {
+			return wErr
+		}
+		return nil
+	})
+	switch {
+	case err != nil:
+		return err
+	case wErr != nil:
+		return wErr
+	default:
+		return pErr
+	}
 }
 
 // SetBoolOption assigns a Boolean value to a named option.
 func (m *RawModel) SetBoolOption(opt string, v bool) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+
 	// Convert arguments from Go to C.
 	str := C.CString(opt)
 	defer C.free(unsafe.Pointer(str))
@@ -143,36 +465,57 @@ func (m *RawModel) SetBoolOption(opt string, v bool) error {
 	}
 
 	// Set the option.
-	status := C.Highs_setBoolOptionValue(m.obj, str, val)
-	return newCallStatus(status, "Highs_setBoolOptionValue", "SetBoolOption")
+	status := C.Highs_setBoolOptionValue(obj, str, val)
+	return newCallStatus(status, "Highs_setBoolOptionValue", "SetBoolOption",
+		TraceAttr{"option", opt}, TraceAttr{"value", v})
 }
 
 // SetIntOption assigns an integer value to a named option.
 func (m *RawModel) SetIntOption(opt string, v int) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+
 	// Convert arguments from Go to C.
 	str := C.CString(opt)
 	defer C.free(unsafe.Pointer(str))
 	val := C.HighsInt(v)
 
 	// Set the option.
-	status := C.Highs_setIntOptionValue(m.obj, str, val)
-	return newCallStatus(status, "Highs_setIntOptionValue", "SetIntOption")
+	status := C.Highs_setIntOptionValue(obj, str, val)
+	return newCallStatus(status, "Highs_setIntOptionValue", "SetIntOption",
+		TraceAttr{"option", opt}, TraceAttr{"value", v})
 }
 
 // SetFloat64Option assigns a floating-point value to a named option.
 func (m *RawModel) SetFloat64Option(opt string, v float64) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+
 	// Convert arguments from Go to C.
 	str := C.CString(opt)
 	defer C.free(unsafe.Pointer(str))
 	val := C.double(v)
 
 	// Set the option.
-	status := C.Highs_setDoubleOptionValue(m.obj, str, val)
-	return newCallStatus(status, "Highs_setDoubleOptionValue", "SetFloat64Option")
+	status := C.Highs_setDoubleOptionValue(obj, str, val)
+	return newCallStatus(status, "Highs_setDoubleOptionValue", "SetFloat64Option",
+		TraceAttr{"option", opt}, TraceAttr{"value", v})
 }
 
 // SetStringOption assigns a string value to a named option.
 func (m *RawModel) SetStringOption(opt string, v string) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+
 	// Convert arguments from Go to C.
 	str := C.CString(opt)
 	defer C.free(unsafe.Pointer(str))
@@ -180,20 +523,91 @@ func (m *RawModel) SetStringOption(opt string, v string) error {
 	defer C.free(unsafe.Pointer(val))
 
 	// Set the option.
-	status := C.Highs_setStringOptionValue(m.obj, str, val)
-	return newCallStatus(status, "Highs_setStringOptionValue", "SetStringOption")
+	status := C.Highs_setStringOptionValue(obj, str, val)
+	return newCallStatus(status, "Highs_setStringOptionValue", "SetStringOption",
+		TraceAttr{"option", opt}, TraceAttr{"value", v})
+}
+
+// SetOptionFromString assigns a value expressed as a string to a named option
+// of any type.  The string is parsed according to the option's type, which
+// makes SetOptionFromString convenient for options read from command lines or
+// configuration files.
+func (m *RawModel) SetOptionFromString(opt string, v string) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+
+	// Determine the option's type.
+	str := C.CString(opt)
+	var oType C.HighsInt
+	status := C.Highs_getOptionType(obj, str, &oType)
+	C.free(unsafe.Pointer(str))
+	m.unlock()
+	err = newCallStatus(status, "Highs_getOptionType", "SetOptionFromString")
+	if err != nil {
+		return err
+	}
+
+	// Parse the value and assign it to the option.
+	switch oType {
+	case C.kHighsOptionTypeBool:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("option %s requires a Boolean value (%w)", opt, err)
+		}
+		return m.SetBoolOption(opt, b)
+	case C.kHighsOptionTypeInt:
+		i, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("option %s requires an integer value (%w)", opt, err)
+		}
+		return m.SetIntOption(opt, i)
+	case C.kHighsOptionTypeDouble:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fmt.Errorf("option %s requires a floating-point value (%w)", opt, err)
+		}
+		return m.SetFloat64Option(opt, f)
+	default:
+		return m.SetStringOption(opt, v)
+	}
+}
+
+// ReadOptionsFromFile assigns options from a named file in HiGHS's options
+// format, which contains one "name = value" line per option.
+func (m *RawModel) ReadOptionsFromFile(fn string) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+
+	// Convert the filename argument from Go to C.
+	cFName := C.CString(fn)
+	defer C.free(unsafe.Pointer(cFName))
+
+	// Read the options.
+	status := C.Highs_readOptions(obj, cFName)
+	return newCallStatus(status, "Highs_readOptions", "ReadOptionsFromFile")
 }
 
 // GetBoolOption returns the Boolean value of a named option.
 func (m *RawModel) GetBoolOption(opt string) (bool, error) {
+	obj, err := m.lock()
+	if err != nil {
+		return false, err
+	}
+	defer m.unlock()
+
 	// Convert the option argument from Go to C.
 	str := C.CString(opt)
 	defer C.free(unsafe.Pointer(str))
 
 	// Get the value.
 	var val C.HighsInt
-	status := C.Highs_getBoolOptionValue(m.obj, str, &val)
-	err := newCallStatus(status, "Highs_getBoolOptionValue", "GetBoolOption")
+	status := C.Highs_getBoolOptionValue(obj, str, &val)
+	err = newCallStatus(status, "Highs_getBoolOptionValue", "GetBoolOption")
 	if err != nil {
 		return false, err
 	}
@@ -206,14 +620,20 @@ func (m *RawModel) GetBoolOption(opt string) (bool, error) {
 
 // GetIntOption returns the Integer value of a named option.
 func (m *RawModel) GetIntOption(opt string) (int, error) {
+	obj, err := m.lock()
+	if err != nil {
+		return 0, err
+	}
+	defer m.unlock()
+
 	// Convert the option argument from Go to C.
 	str := C.CString(opt)
 	defer C.free(unsafe.Pointer(str))
 
 	// Get the value.
 	var val C.HighsInt
-	status := C.Highs_getIntOptionValue(m.obj, str, &val)
-	err := newCallStatus(status, "Highs_getIntOptionValue", "GetIntOption")
+	status := C.Highs_getIntOptionValue(obj, str, &val)
+	err = newCallStatus(status, "Highs_getIntOptionValue", "GetIntOption")
 	if err != nil {
 		return 0, err
 	}
@@ -222,14 +642,20 @@ func (m *RawModel) GetIntOption(opt string) (int, error) {
 
 // GetFloat64Option returns the floating-point value of a named option.
 func (m *RawModel) GetFloat64Option(opt string) (float64, error) {
+	obj, err := m.lock()
+	if err != nil {
+		return 0.0, err
+	}
+	defer m.unlock()
+
 	// Convert the option argument from Go to C.
 	str := C.CString(opt)
 	defer C.free(unsafe.Pointer(str))
 
 	// Get the value.
 	var val C.double
-	status := C.Highs_getDoubleOptionValue(m.obj, str, &val)
-	err := newCallStatus(status, "Highs_getDoubleOptionValue", "GetFloat64Option")
+	status := C.Highs_getDoubleOptionValue(obj, str, &val)
+	err = newCallStatus(status, "Highs_getDoubleOptionValue", "GetFloat64Option")
 	if err != nil {
 		return 0.0, err
 	}
@@ -240,6 +666,12 @@ func (m *RawModel) GetFloat64Option(opt string) (float64, error) {
 // this method in security-sensitive applications because it runs a risk of
 // buffer overflow.
 func (m *RawModel) GetStringOption(opt string) (string, error) {
+	obj, err := m.lock()
+	if err != nil {
+		return "", err
+	}
+	defer m.unlock()
+
 	// Convert the option argument from Go to C.
 	str := C.CString(opt)
 	defer C.free(unsafe.Pointer(str))
@@ -250,8 +682,8 @@ func (m *RawModel) GetStringOption(opt string) (string, error) {
 	defer C.free(unsafe.Pointer(val))
 
 	// Get the value.
-	status := C.Highs_getStringOptionValue(m.obj, str, val)
-	err := newCallStatus(status, "Highs_getStringOptionValue", "GetStringOption")
+	status := C.Highs_getStringOptionValue(obj, str, val)
+	err = newCallStatus(status, "Highs_getStringOptionValue", "GetStringOption")
 	if err != nil {
 		return "", err
 	}
@@ -261,27 +693,111 @@ func (m *RawModel) GetStringOption(opt string) (string, error) {
 // SetMaximization tells a model to maximize (true) or minimize (false) its
 // objective function.
 func (m *RawModel) SetMaximization(max bool) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+
 	var sense C.HighsInt = C.kHighsObjSenseMinimize
 	if max {
 		sense = C.kHighsObjSenseMaximize
 	}
-	status := C.Highs_changeObjectiveSense(m.obj, sense)
+	status := C.Highs_changeObjectiveSense(obj, sense)
 	return newCallStatus(status, "Highs_changeObjectiveSense", "SetMaximization")
 }
 
+// NumColumns returns the number of columns in a model.
+func (m *RawModel) NumColumns() (int, error) {
+	obj, err := m.lock()
+	if err != nil {
+		return 0, err
+	}
+	defer m.unlock()
+	return int(C.Highs_getNumCol(obj)), nil
+}
+
+// NumRows returns the number of rows in a model.
+func (m *RawModel) NumRows() (int, error) {
+	obj, err := m.lock()
+	if err != nil {
+		return 0, err
+	}
+	defer m.unlock()
+	return int(C.Highs_getNumRow(obj)), nil
+}
+
+// Maximization reports whether a model maximizes (true) or minimizes (false)
+// its objective function.
+func (m *RawModel) Maximization() (bool, error) {
+	obj, err := m.lock()
+	if err != nil {
+		return false, err
+	}
+	defer m.unlock()
+
+	var sense C.HighsInt
+	status := C.Highs_getObjectiveSense(obj, &sense)
+	err = newCallStatus(status, "Highs_getObjectiveSense", "Maximization")
+	return sense == C.kHighsObjSenseMaximize, err
+}
+
 // SetColumnCosts specifies a model's column costs (i.e., its objective
 // function).
 func (m *RawModel) SetColumnCosts(cs []float64) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+
+	return setColumnCostsRange(obj, 0, cs, "SetColumnCosts")
+}
+
+// SetColumnCostsRange replaces the costs of a contiguous block of columns,
+// starting with column firstCol, leaving the other columns' costs unchanged.
+// An empty costs slice is a no-op.
+func (m *RawModel) SetColumnCostsRange(firstCol int, costs []float64) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+	return setColumnCostsRange(obj, firstCol, costs, "SetColumnCostsRange")
+}
+
+// setColumnCostsRange is the lock-free implementation of
+// SetColumnCostsRange.  goName is the name of the calling method, for use
+// in error messages.
+func setColumnCostsRange(obj unsafe.Pointer, firstCol int, cs []float64, goName string) error {
+	// Ensure the range is valid.
+	if len(cs) == 0 {
+		return nil
+	}
+	nc := int(C.Highs_getNumCol(obj))
+	if firstCol < 0 || firstCol+len(cs) > nc {
+		return fmt.Errorf("columns [%d, %d) are out of range [0, %d)",
+			firstCol, firstCol+len(cs), nc)
+	}
+
+	// Replace the costs.
 	cost := convertSlice[C.double, float64](cs)
-	status := C.Highs_changeColsCostByRange(m.obj,
-		0, C.HighsInt(len(cs)-1),
-		&cost[0])
-	return newCallStatus(status, "Highs_changeColsCostByRange", "SetColumnCosts")
+	status := C.Highs_changeColsCostByRange(obj,
+		C.HighsInt(firstCol), C.HighsInt(firstCol+len(cs)-1),
+		sliceToPointer(cost))
+	return newCallStatus(status, "Highs_changeColsCostByRange", goName,
+		TraceAttr{"from_col", firstCol}, TraceAttr{"to_col", firstCol + len(cs) - 1})
 }
 
 // SetOffset specifies a constant offset for the objective function.
 func (m *RawModel) SetOffset(o float64) error {
-	status := C.Highs_changeObjectiveOffset(m.obj, C.double(o))
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+
+	status := C.Highs_changeObjectiveOffset(obj, C.double(o))
 	return newCallStatus(status, "Highs_changeObjectiveOffset", "SetOffset")
 }
 
@@ -315,19 +831,32 @@ func prepareBounds(lb, ub []float64) ([]float64, []float64, error) {
 // infinities.  If the upper-bound argument is nil, it is replaced with a slice
 // of positive infinities.
 func (m *RawModel) AddColumnBounds(lb, ub []float64) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+
 	colLower, colUpper, err := prepareBounds(lb, ub)
 	if err != nil {
 		return err
 	}
 	lower := convertSlice[C.double, float64](colLower)
 	upper := convertSlice[C.double, float64](colUpper)
-	status := C.Highs_addVars(m.obj, C.HighsInt(len(lower)),
+	status := C.Highs_addVars(obj, C.HighsInt(len(lower)),
 		&lower[0], &upper[0])
-	return newCallStatus(status, "Highs_addVars", "SetColumnBounds")
+	return newCallStatus(status, "Highs_addVars", "SetColumnBounds",
+		TraceAttr{"num_new_col", len(lower)})
 }
 
 // AddCompSparseRows appends compressed sparse rows to the model.
 func (m *RawModel) AddCompSparseRows(lb []float64, start []int, index []int, value []float64, ub []float64) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+
 	// Check for simple errors.
 	if len(lb) != len(ub) {
 		return fmt.Errorf("lb and ub must be the same length (%d vs. %d)",
@@ -344,16 +873,23 @@ func (m *RawModel) AddCompSparseRows(lb []float64, start []int, index []int, val
 	hStart := convertSlice[C.HighsInt, int](start)
 	hIndex := convertSlice[C.HighsInt, int](index)
 	hValue := convertSlice[C.double, float64](value)
-	status := C.Highs_addRows(m.obj, C.HighsInt(len(lb)),
+	status := C.Highs_addRows(obj, C.HighsInt(len(lb)),
 		&hLower[0], &hUpper[0],
 		C.HighsInt(len(value)), &hStart[0], &hIndex[0], &hValue[0])
-	return newCallStatus(status, "Highs_addRows", "AddCompSparseRows")
+	return newCallStatus(status, "Highs_addRows", "AddCompSparseRows",
+		TraceAttr{"num_new_row", len(lb)}, TraceAttr{"num_new_nz", len(value)})
 }
 
 // AddDenseRow is a convenience function that lets the caller add to the model
 // a single row's lower bound, matrix coefficients (specified densely, but
 // stored sparsely), and upper bound.
 func (m *RawModel) AddDenseRow(lb float64, coeffs []float64, ub float64) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+
 	// Convert dense to sparse.
 	var numNewNz C.HighsInt
 	index := make([]C.HighsInt, 0, len(coeffs))
@@ -368,27 +904,296 @@ func (m *RawModel) AddDenseRow(lb float64, coeffs []float64, ub float64) error {
 	}
 
 	// Add the row.
-	status := C.Highs_addRow(m.obj, C.double(lb), C.double(ub),
-		numNewNz, &index[0], &value[0])
-	return newCallStatus(status, "Highs_addRow", "AddDenseRow")
+	status := C.Highs_addRow(obj, C.double(lb), C.double(ub),
+		numNewNz, sliceToPointer(index), sliceToPointer(value))
+	return newCallStatus(status, "Highs_addRow", "AddDenseRow",
+		TraceAttr{"num_new_nz", int(numNewNz)})
+}
+
+// AddRowMap is a convenience function that lets the caller add to the model
+// a single row's lower bound, matrix coefficients (specified as a map from
+// column index to coefficient), and upper bound.  Zero coefficients are
+// omitted.
+func (m *RawModel) AddRowMap(lb float64, coeffs map[int]float64, ub float64) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+
+	// Sort the column indexes so HiGHS receives the same row regardless
+	// of map iteration order.
+	cols := make([]int, 0, len(coeffs))
+	for c, v := range coeffs {
+		if c < 0 {
+			return fmt.Errorf("column %d is not a valid column index", c)
+		}
+		if v != 0.0 {
+			cols = append(cols, c)
+		}
+	}
+	sort.Ints(cols)
+
+	// Convert the map to sparse form.
+	index := make([]C.HighsInt, len(cols))
+	value := make([]C.double, len(cols))
+	for i, c := range cols {
+		index[i] = C.HighsInt(c)
+		value[i] = C.double(coeffs[c])
+	}
+
+	// Add the row.
+	status := C.Highs_addRow(obj, C.double(lb), C.double(ub),
+		C.HighsInt(len(cols)), sliceToPointer(index), sliceToPointer(value))
+	return newCallStatus(status, "Highs_addRow", "AddRowMap",
+		TraceAttr{"num_new_nz", len(cols)})
+}
+
+// AddColumnMap is a convenience function that lets the caller add to the
+// model a single column's cost, lower bound, upper bound, and matrix
+// coefficients (specified as a map from row index to coefficient).  Zero
+// coefficients are omitted.
+func (m *RawModel) AddColumnMap(cost, lb, ub float64, coeffs map[int]float64) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+
+	// Sort the row indexes so HiGHS receives the same column regardless
+	// of map iteration order.
+	rows := make([]int, 0, len(coeffs))
+	for r, v := range coeffs {
+		if r < 0 {
+			return fmt.Errorf("row %d is not a valid row index", r)
+		}
+		if v != 0.0 {
+			rows = append(rows, r)
+		}
+	}
+	sort.Ints(rows)
+
+	// Convert the map to sparse form.
+	index := make([]C.HighsInt, len(rows))
+	value := make([]C.double, len(rows))
+	for i, r := range rows {
+		index[i] = C.HighsInt(r)
+		value[i] = C.double(coeffs[r])
+	}
+
+	// Add the column.
+	status := C.Highs_addCol(obj, C.double(cost), C.double(lb), C.double(ub),
+		C.HighsInt(len(rows)), sliceToPointer(index), sliceToPointer(value))
+	return newCallStatus(status, "Highs_addCol", "AddColumnMap",
+		TraceAttr{"num_new_nz", len(rows)})
+}
+
+// AddColumns appends columns to the model with the given costs, bounds, and
+// types.  It is equivalent to AddColumnBounds followed by assigning costs and
+// integrality to only the new columns, except that if either assignment
+// fails, the new columns are removed again.  A nil costs slice leaves the new
+// columns' costs at zero, nil bounds are replaced with infinities, and a nil
+// types slice leaves the new columns continuous.  All non-nil slices must
+// have the same length.
+func (m *RawModel) AddColumns(costs, lb, ub []float64, types []VariableType) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+
+	// Fill in defaults for nil slices.
+	n := len(costs)
+	for _, l := range []int{len(lb), len(ub), len(types)} {
+		if l > n {
+			n = l
+		}
+	}
+	if n == 0 {
+		return nil
+	}
+	var ok [4]bool
+	costs, ok[0] = expandToLen(n, costs, 0.0)
+	lb, ok[1] = expandToLen(n, lb, math.Inf(-1))
+	ub, ok[2] = expandToLen(n, ub, math.Inf(1))
+	types, ok[3] = expandToLen(n, types, ContinuousType)
+	if ok != [4]bool{true, true, true, true} {
+		return fmt.Errorf("inconsistent column counts")
+	}
+
+	// Add the columns.
+	first := C.Highs_getNumCol(obj)
+	last := first + C.HighsInt(n) - 1
+	lower := convertSlice[C.double, float64](lb)
+	upper := convertSlice[C.double, float64](ub)
+	status := C.Highs_addVars(obj, C.HighsInt(n), &lower[0], &upper[0])
+	err = newCallStatus(status, "Highs_addVars", "AddColumns",
+		TraceAttr{"num_new_col", n})
+	if err != nil && !isWarning(err) {
+		return err
+	}
+
+	// Assign costs and types to the new columns, removing the columns if
+	// either step fails.
+	cost := convertSlice[C.double, float64](costs)
+	status = C.Highs_changeColsCostByRange(obj, first, last, &cost[0])
+	cErr := newCallStatus(status, "Highs_changeColsCostByRange", "AddColumns")
+	if cErr == nil || isWarning(cErr) {
+		integrality := make([]C.HighsInt, n)
+		for i, t := range types {
+			integrality[i] = variableTypeToHighs[t]
+		}
+		status = C.Highs_changeColsIntegralityByRange(obj, first, last, &integrality[0])
+		cErr = newCallStatus(status, "Highs_changeColsIntegralityByRange", "AddColumns")
+	}
+	if cErr != nil && !isWarning(cErr) {
+		C.Highs_deleteColsByRange(obj, first, last)
+		return cErr
+	}
+	return err
+}
+
+// SetColumnName assigns a name to a column.  Column names are written by
+// WriteModel and used in place of generated identifiers by
+// RawSolution.WriteSolution.
+func (m *RawModel) SetColumnName(c int, name string) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	status := C.Highs_passColName(obj, C.HighsInt(c), cName)
+	return newCallStatus(status, "Highs_passColName", "SetColumnName")
+}
+
+// SetRowName assigns a name to a row.  Row names are written by WriteModel
+// and used in place of generated identifiers by RawSolution.WriteSolution.
+func (m *RawModel) SetRowName(r int, name string) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	status := C.Highs_passRowName(obj, C.HighsInt(r), cName)
+	return newCallStatus(status, "Highs_passRowName", "SetRowName")
+}
+
+// ColumnName returns the name of a column, which is empty if the column is
+// unnamed.
+func (m *RawModel) ColumnName(c int) (string, error) {
+	obj, err := m.lock()
+	if err != nil {
+		return "", err
+	}
+	defer m.unlock()
+	if nc := int(C.Highs_getNumCol(obj)); c < 0 || c >= nc {
+		return "", fmt.Errorf("column %d is out of range [0, %d)", c, nc)
+	}
+	return getName(func(buf *C.char) C.HighsInt {
+		return C.Highs_getColName(obj, C.HighsInt(c), buf)
+	}, "Highs_getColName", "ColumnName")
+}
+
+// RowName returns the name of a row, which is empty if the row is unnamed.
+func (m *RawModel) RowName(r int) (string, error) {
+	obj, err := m.lock()
+	if err != nil {
+		return "", err
+	}
+	defer m.unlock()
+	if nr := int(C.Highs_getNumRow(obj)); r < 0 || r >= nr {
+		return "", fmt.Errorf("row %d is out of range [0, %d)", r, nr)
+	}
+	return getName(func(buf *C.char) C.HighsInt {
+		return C.Highs_getRowName(obj, C.HighsInt(r), buf)
+	}, "Highs_getRowName", "RowName")
+}
+
+// ColumnByName returns the index of the column with a given name.  It returns
+// an error if no column has that name.
+func (m *RawModel) ColumnByName(name string) (int, error) {
+	obj, err := m.lock()
+	if err != nil {
+		return 0, err
+	}
+	defer m.unlock()
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	var col C.HighsInt
+	status := C.Highs_getColByName(obj, cName, &col)
+	if newCallStatus(status, "Highs_getColByName", "ColumnByName") != nil {
+		return 0, fmt.Errorf("no column is named %q", name)
+	}
+	return int(col), nil
+}
+
+// RowByName returns the index of the row with a given name.  It returns an
+// error if no row has that name.
+func (m *RawModel) RowByName(name string) (int, error) {
+	obj, err := m.lock()
+	if err != nil {
+		return 0, err
+	}
+	defer m.unlock()
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	var row C.HighsInt
+	status := C.Highs_getRowByName(obj, cName, &row)
+	if newCallStatus(status, "Highs_getRowByName", "RowByName") != nil {
+		return 0, fmt.Errorf("no row is named %q", name)
+	}
+	return int(row), nil
+}
+
+// getName uses a given function to retrieve a single column or row name.
+// cName and goName name the HiGHS function and the calling method for use in
+// error messages.  The caller must hold the model's lock.
+func getName(get func(*C.char) C.HighsInt, cName, goName string) (string, error) {
+	buf := (*C.char)(C.malloc(C.size_t(C.kHighsMaximumStringLength)))
+	defer C.free(unsafe.Pointer(buf))
+	*buf = 0
+	err := newCallStatus(get(buf), cName, goName)
+	if err != nil {
+		return "", err
+	}
+	return C.GoString(buf), nil
 }
 
 // SetIntegrality specifies the type of each column (variable) in the model.
 func (m *RawModel) SetIntegrality(ts []VariableType) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+
 	integrality := make([]C.HighsInt, len(ts))
 	for i, t := range ts {
 		integrality[i] = variableTypeToHighs[t]
 	}
-	status := C.Highs_changeColsIntegralityByRange(m.obj,
+	status := C.Highs_changeColsIntegralityByRange(obj,
 		0, C.HighsInt(len(integrality)-1),
 		&integrality[0])
-	return newCallStatus(status, "Highs_changeColsIntegralityByRange", "SetIntegrality")
+	return newCallStatus(status, "Highs_changeColsIntegralityByRange", "SetIntegrality",
+		TraceAttr{"from_col", 0}, TraceAttr{"to_col", len(integrality) - 1})
 }
 
 // AddCompSparseHessian assigns a Hessian in compressed sparse row form to the
 // model.  This is used to formulate quadratic constraints in a
 // quadratic-programming model.
 func (m *RawModel) AddCompSparseHessian(start []int, index []int, value []float64) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+
 	// Check for simple errors.
 	if len(index) != len(value) {
 		return fmt.Errorf("index and value must be the same length (%d vs. %d)",
@@ -399,63 +1204,505 @@ func (m *RawModel) AddCompSparseHessian(start []int, index []int, value []float6
 	hStart := convertSlice[C.HighsInt, int](start)
 	hIndex := convertSlice[C.HighsInt, int](index)
 	hValue := convertSlice[C.double, float64](value)
-	status := C.Highs_passHessian(m.obj, C.HighsInt(len(start)),
+	status := C.Highs_passHessian(obj, C.HighsInt(len(start)),
 		C.HighsInt(len(value)), C.kHighsHessianFormatTriangular,
 		&hStart[0], &hIndex[0], &hValue[0])
-	return newCallStatus(status, "Highs_passHessian", "AddCompSparseHessian")
+	return newCallStatus(status, "Highs_passHessian", "AddCompSparseHessian",
+		TraceAttr{"dim", len(start)}, TraceAttr{"num_nz", len(value)})
+}
+
+// ToModel extracts a raw model's objective function, bounds, constraint
+// matrix, Hessian matrix, variable types, and column and row names into a
+// high-level Model.  This makes it possible, for example, to read a model
+// from an MPS file, edit it with ordinary Go code, and solve the result.
+// VarTypes is left nil if all columns are continuous, and ColNames and
+// RowNames are left nil if HiGHS has no names for the model's columns or
+// rows.
+func (m *RawModel) ToModel() (*Model, error) {
+	obj, err := m.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer m.unlock()
+	return toModel(obj)
+}
+
+// toModel implements ToModel.  The caller must hold the model's lock.
+func toModel(obj unsafe.Pointer) (*Model, error) {
+	// Allocate memory for all of the model's data.  Each start slice
+	// receives an additional entry to hold the number of nonzeros.
+	nc := int(C.Highs_getNumCol(obj))
+	nr := int(C.Highs_getNumRow(obj))
+	nnz := int(C.Highs_getNumNz(obj))
+	qnnz := int(C.Highs_getHessianNumNz(obj))
+	var numCol, numRow, numNz, qNumNz, sense C.HighsInt
+	var offset C.double
+	colCost := make([]C.double, nc)
+	colLower := make([]C.double, nc)
+	colUpper := make([]C.double, nc)
+	rowLower := make([]C.double, nr)
+	rowUpper := make([]C.double, nr)
+	aStart := make([]C.HighsInt, nr+1)
+	aIndex := make([]C.HighsInt, nnz)
+	aValue := make([]C.double, nnz)
+	qStart := make([]C.HighsInt, nc+1)
+	qIndex := make([]C.HighsInt, qnnz)
+	qValue := make([]C.double, qnnz)
+	integrality := make([]C.HighsInt, nc)
+
+	// Extract the model from HiGHS.
+	status := C.Highs_getModel(obj,
+		C.kHighsMatrixFormatRowwise, C.kHighsHessianFormatTriangular,
+		&numCol, &numRow, &numNz, &qNumNz, &sense, &offset,
+		sliceToPointer(colCost), sliceToPointer(colLower), sliceToPointer(colUpper),
+		sliceToPointer(rowLower), sliceToPointer(rowUpper),
+		&aStart[0], sliceToPointer(aIndex), sliceToPointer(aValue),
+		&qStart[0], sliceToPointer(qIndex), sliceToPointer(qValue),
+		sliceToPointer(integrality))
+	err := newCallStatus(status, "Highs_getModel", "ToModel")
+	if err != nil {
+		return nil, err
+	}
+	aStart[nr] = C.HighsInt(nnz)
+	qStart[nc] = C.HighsInt(qnnz)
+
+	// Convert C values to Go values.
+	model := &Model{
+		Maximize: sense == C.kHighsObjSenseMaximize,
+		Offset:   float64(offset),
+		ColCosts: convertSlice[float64, C.double](colCost),
+		ColLower: convertSlice[float64, C.double](colLower),
+		ColUpper: convertSlice[float64, C.double](colUpper),
+		RowLower: convertSlice[float64, C.double](rowLower),
+		RowUpper: convertSlice[float64, C.double](rowUpper),
+	}
+	model.ConstMatrix = make([]Nonzero, 0, nnz)
+	for r := 0; r < nr; r++ {
+		for k := aStart[r]; k < aStart[r+1]; k++ {
+			model.ConstMatrix = append(model.ConstMatrix,
+				Nonzero{r, int(aIndex[k]), float64(aValue[k])})
+		}
+	}
+
+	// HiGHS stores the lower triangle of the Hessian column-wise, which is
+	// equivalent to storing the upper triangle row-wise.
+	if qnnz > 0 {
+		model.HessianMatrix = make([]Nonzero, 0, qnnz)
+		for r := 0; r < nc; r++ {
+			for k := qStart[r]; k < qStart[r+1]; k++ {
+				model.HessianMatrix = append(model.HessianMatrix,
+					Nonzero{r, int(qIndex[k]), float64(qValue[k])})
+			}
+		}
+	}
+
+	// Convert the variable types, omitting them if all are continuous.
+	model.VarTypes = convertIntegrality(integrality)
+
+	// Extract the column and row names, if any.
+	model.ColNames = getNames(nc, func(i C.HighsInt, buf *C.char) C.HighsInt {
+		return C.Highs_getColName(obj, i, buf)
+	})
+	model.RowNames = getNames(nr, func(i C.HighsInt, buf *C.char) C.HighsInt {
+		return C.Highs_getRowName(obj, i, buf)
+	})
+	return model, nil
+}
+
+// convertIntegrality converts a slice of kHighsVarTypes to a slice of
+// VariableTypes.  It returns nil if all columns are continuous.
+func convertIntegrality(integrality []C.HighsInt) []VariableType {
+	var vts []VariableType
+	for c, hvt := range integrality {
+		if hvt == C.kHighsVarTypeContinuous {
+			continue
+		}
+		if vts == nil {
+			vts = make([]VariableType, len(integrality))
+		}
+		for vt, h := range variableTypeToHighs {
+			if h == hvt {
+				vts[c] = VariableType(vt)
+			}
+		}
+	}
+	return vts
+}
+
+// Counts returns the number of columns of each variable type, the number of
+// rows of each kind, and the number of nonzeros in the constraint and Hessian
+// matrices.  See Model.Counts for details.
+func (m *RawModel) Counts() (ModelCounts, error) {
+	model, err := m.ToModel()
+	if err != nil {
+		return ModelCounts{}, renameCallStatus(err, "Counts")
+	}
+	return model.Counts(), nil
+}
+
+// String summarizes a RawModel in the same form as Model.String.  If the
+// model cannot be retrieved from HiGHS, String describes the error instead.
+func (m *RawModel) String() string {
+	model, err := m.ToModel()
+	if err != nil {
+		return fmt.Sprintf("unavailable model (%v)", renameCallStatus(err, "String"))
+	}
+	return model.String()
+}
+
+// getNames uses a given function to retrieve n column or row names.  It
+// returns nil if HiGHS has no names or all names are empty.  The caller must
+// hold the model's lock.
+func getNames(n int, get func(C.HighsInt, *C.char) C.HighsInt) []string {
+	buf := (*C.char)(C.malloc(C.size_t(C.kHighsMaximumStringLength)))
+	defer C.free(unsafe.Pointer(buf))
+	names := make([]string, n)
+	anyNames := false
+	for i := range names {
+		if get(C.HighsInt(i), buf) != C.kHighsStatusOk {
+			return nil
+		}
+		names[i] = C.GoString(buf)
+		if names[i] != "" {
+			anyNames = true
+		}
+	}
+	if !anyNames {
+		return nil
+	}
+	return names
+}
+
+// RowEntries returns the nonzero constraint-matrix entries in a given row.
+func (m *RawModel) RowEntries(row int) ([]Nonzero, error) {
+	obj, err := m.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer m.unlock()
+	if nr := int(C.Highs_getNumRow(obj)); row < 0 || row >= nr {
+		return nil, fmt.Errorf("row %d is out of range [0, %d)", row, nr)
+	}
+	return rowEntries(obj, row, "RowEntries")
+}
+
+// rowEntries implements RowEntries for a valid row of a HiGHS object whose
+// handle the caller has already locked.  goName is the name of the calling
+// method, for use in error messages.
+func rowEntries(obj unsafe.Pointer, row int, goName string) ([]Nonzero, error) {
+	// Query the number of nonzeros, then the nonzeros themselves.
+	r := C.HighsInt(row)
+	var numRow, numNz C.HighsInt
+	var lower, upper C.double
+	status := C.Highs_getRowsByRange(obj, r, r, &numRow, &lower, &upper,
+		&numNz, nil, nil, nil)
+	err := newCallStatus(status, "Highs_getRowsByRange", goName)
+	if err != nil || numNz == 0 {
+		return nil, err
+	}
+	var start C.HighsInt
+	index := make([]C.HighsInt, numNz)
+	value := make([]C.double, numNz)
+	status = C.Highs_getRowsByRange(obj, r, r, &numRow, &lower, &upper,
+		&numNz, &start, &index[0], &value[0])
+	err = newCallStatus(status, "Highs_getRowsByRange", goName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert C values to Go values.
+	nzs := make([]Nonzero, numNz)
+	for k := range nzs {
+		nzs[k] = Nonzero{row, int(index[k]), float64(value[k])}
+	}
+	return nzs, nil
+}
+
+// ColumnEntries returns the nonzero constraint-matrix entries in a given
+// column.
+func (m *RawModel) ColumnEntries(col int) ([]Nonzero, error) {
+	obj, err := m.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer m.unlock()
+	if nc := int(C.Highs_getNumCol(obj)); col < 0 || col >= nc {
+		return nil, fmt.Errorf("column %d is out of range [0, %d)", col, nc)
+	}
+
+	// Query the number of nonzeros, then the nonzeros themselves.
+	c := C.HighsInt(col)
+	var numCol, numNz C.HighsInt
+	var cost, lower, upper C.double
+	status := C.Highs_getColsByRange(obj, c, c, &numCol, &cost, &lower, &upper,
+		&numNz, nil, nil, nil)
+	err = newCallStatus(status, "Highs_getColsByRange", "ColumnEntries")
+	if err != nil || numNz == 0 {
+		return nil, err
+	}
+	var start C.HighsInt
+	index := make([]C.HighsInt, numNz)
+	value := make([]C.double, numNz)
+	status = C.Highs_getColsByRange(obj, c, c, &numCol, &cost, &lower, &upper,
+		&numNz, &start, &index[0], &value[0])
+	err = newCallStatus(status, "Highs_getColsByRange", "ColumnEntries")
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert C values to Go values.
+	nzs := make([]Nonzero, numNz)
+	for k := range nzs {
+		nzs[k] = Nonzero{int(index[k]), col, float64(value[k])}
+	}
+	return nzs, nil
+}
+
+// BasicVariables returns the column or row that occupies each position of the
+// basis produced by the most recent solve, in the order HiGHS uses for its
+// basis-inverse routines.  The result has one element per row.  HiGHS
+// reports an error if the model has not been solved to the point of having
+// an invertible basis representation, for example if it was solved only by
+// the interior-point method without crossover or is a MIP.
+func (m *RawModel) BasicVariables() ([]BasicVariable, error) {
+	obj, err := m.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer m.unlock()
+
+	// Ask HiGHS for the basic variables.
+	nr := int(C.Highs_getNumRow(obj))
+	basic := make([]C.HighsInt, nr)
+	status := C.Highs_getBasicVariables(obj, sliceToPointer(basic))
+	err = newCallStatus(status, "Highs_getBasicVariables", "BasicVariables")
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert HiGHS's encoding, in which row r is represented as −(r+1),
+	// to BasicVariables.
+	bvs := make([]BasicVariable, nr)
+	for i, b := range basic {
+		if b < 0 {
+			bvs[i] = BasicVariable{Index: int(-b - 1), Row: true}
+		} else {
+			bvs[i] = BasicVariable{Index: int(b)}
+		}
+	}
+	return bvs, nil
+}
+
+// ReadSolutionFromFile replaces the solution HiGHS holds for the model with
+// one read from a named file in the format written by
+// RawSolution.WriteSolutionToFile when its second argument is false.  Use
+// CurrentSolution to retrieve the solution or Solve to start from it.
+func (m *RawModel) ReadSolutionFromFile(fn string) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+
+	cFName := C.CString(fn)
+	defer C.free(unsafe.Pointer(cFName))
+	status := C.Highs_readSolution(obj, cFName)
+	return newCallStatus(status, "Highs_readSolution", "ReadSolutionFromFile")
 }
 
-// Solve solves a model.
+// CurrentSolution returns the solution HiGHS currently holds for the model
+// without solving it again.  This may be the solution from a previous solve
+// or one provided by ReadSolutionFromFile or SetStartingPoint.  If
+// HiGHS's information about the solution is unavailable, as it is when the
+// solution was not produced by a solve, the objective value is computed from
+// the primal column values, and the dual values are omitted.
+func (m *RawModel) CurrentSolution() (*RawSolution, error) {
+	obj, err := m.lock()
+	if err != nil {
+		return &RawSolution{}, err
+	}
+	defer m.unlock()
+	return extractSolution(m.h, obj, "CurrentSolution")
+}
+
+// Solve solves a model.  If the model is found to be infeasible or unbounded,
+// the returned solution still contains HiGHS's final primal and dual values,
+// as well as a dual ray (Farkas certificate) or primal ray if HiGHS found
+// one, to help diagnose the cause.
+//
+// If HiGHS reports a warning while solving, Solve returns the solution
+// together with a CallStatus representing the warning.  Callers can use
+// CallStatus.IsWarning to distinguish this case from a failed solve, in
+// which the returned solution is empty.  In both cases the CallStatus is
+// wrapped in a *StatusError that also reports the model status and the last
+// lines HiGHS logged, if output_flag is true.  Warnings caused solely by reaching
+// a user-specified limit (e.g., time_limit) are not reported; the solution's
+// Status field indicates the limit that was reached.
+//
+// While Solve is running, RunTime and the information methods (GetIntInfo,
+// GetAllInfo, etc.) of RawSolutions previously returned by the same model
+// report the solve's progress so far.  Their other methods block until Solve
+// completes, after which they report values from the new solve.
 func (m *RawModel) Solve() (*RawSolution, error) {
+	obj, err := m.lock()
+	if err != nil {
+		return &RawSolution{}, err
+	}
+	defer m.unlock()
+	return m.solve(context.Background(), obj, "Solve")
+}
+
+// solve solves a model and returns its solution.  ctx is used only for
+// tracing.  goName is the name of the public method that invoked solve.  The
+// caller must hold the model's lock.
+func (m *RawModel) solve(ctx context.Context, obj unsafe.Pointer, goName string) (soln *RawSolution, err error) {
+	// Trace the solve.
+	end := startTrace(ctx, goName, sizeAttrs(obj)...)
+	defer func() {
+		var attrs []TraceAttr
+		if tracing() {
+			attrs = []TraceAttr{{"highs.run_time_seconds", float64(C.Highs_getRunTime(obj))}}
+			if err == nil || isWarning(err) {
+				attrs = append(attrs,
+					TraceAttr{"highs.model_status", soln.Status.String()},
+					TraceAttr{"highs.objective", soln.Objective})
+			}
+		}
+		end(err, attrs...)
+	}()
+
+	// Record statistics about the solve if requested.
+	if m.stats != nil {
+		start := time.Now()
+		defer func() {
+			_ = m.stats.Record(solveStats(obj, start, err))
+		}()
+	}
+
+	// Retain the last few lines HiGHS logs so they can be reported if the
+	// solve fails.
+	var tail []string
+	logID, logErr := m.h.addLogCallback(func(_ int, msg string) {
+		for _, line := range strings.Split(strings.TrimRight(msg, "\n"), "\n") {
+			if line == "" {
+				continue
+			}
+			tail = append(tail, line)
+			if len(tail) > logTailLines {
+				tail = tail[1:]
+			}
+		}
+	})
+	if logErr == nil {
+		defer func() { _ = m.h.removeCallback(logID) }()
+	}
+
+	// Publish progress for concurrent information queries.
+	stopLive := m.h.startLiveInfo()
+
 	// Solve the model.  We assume the user has already set up all the
 	// required parameters.
-	status := C.Highs_run(m.obj)
-	err := newCallStatus(status, "Highs_run", "Solve")
-	if err != nil {
+	m.h.beginSolve()
+	status := C.Highs_run(obj)
+	stopLive()
+	if err = m.h.endSolve(); err != nil {
 		return &RawSolution{}, err
 	}
 
+	// A warning from Highs_run does not prevent us from extracting the
+	// solution, so we return the solution along with the warning.  The
+	// exception is a warning that merely reports that a user-specified
+	// limit was reached, which the model status already conveys.
+	var runErr error
+	var runArgs []TraceAttr
+	if callLogging() {
+		runArgs = sizeAttrs(obj)
+	}
+	if cs, ok := newCallStatus(status, "Highs_run", goName, runArgs...).(CallStatus); ok {
+		runErr = &StatusError{
+			CallStatus: cs,
+			Status:     convertHighsModelStatus(C.Highs_getModelStatus(obj)),
+			LogTail:    tail,
+		}
+		if !cs.IsWarning() {
+			return &RawSolution{}, runErr
+		}
+	}
+	if stoppedAtLimit(obj) {
+		runErr = nil
+	}
+
 	// Extract the solution as Go data.
-	var soln RawSolution
-	soln.rm = m
-	hObj := m.obj
-	soln.Status = convertHighsModelStatus(C.Highs_getModelStatus(hObj))
-	nc := int(C.Highs_getNumCol(hObj))
-	nr := int(C.Highs_getNumRow(hObj))
+	soln, err = extractSolution(m.h, obj, goName)
+	if err != nil {
+		return soln, err
+	}
+	return soln, runErr
+}
+
+// extractSolution returns as Go data the solution currently stored in a
+// HiGHS object.  h is the handle that owns obj, and goName is the name of the
+// public method that invoked extractSolution.  The caller must hold the
+// model's lock.
+func extractSolution(h *handle, obj unsafe.Pointer, goName string) (*RawSolution, error) {
+	// Extract the primal solution.
+	soln := newRawSolution(h)
+	soln.Status = convertHighsModelStatus(C.Highs_getModelStatus(obj))
+	nc := int(C.Highs_getNumCol(obj))
+	nr := int(C.Highs_getNumRow(obj))
 	colValue := make([]C.double, nc)
 	colDual := make([]C.double, nc)
 	rowValue := make([]C.double, nr)
 	rowDual := make([]C.double, nr)
-	status = C.Highs_getSolution(hObj, &colValue[0], &colDual[0],
-		&rowValue[0], &rowDual[0])
-	err = newCallStatus(status, "Highs_getSolution", "Solve")
+	status := C.Highs_getSolution(obj, sliceToPointer(colValue), sliceToPointer(colDual),
+		sliceToPointer(rowValue), sliceToPointer(rowDual))
+	err := newCallStatus(status, "Highs_getSolution", goName)
 	if err != nil {
 		return &RawSolution{}, err
 	}
 	soln.ColumnPrimal = convertSlice[float64, C.double](colValue)
 	soln.RowPrimal = convertSlice[float64, C.double](rowValue)
-	soln.Objective, err = soln.GetFloat64Info("objective_function_value")
+	soln.Objective, err = getFloat64Info(obj, "objective_function_value")
 	if err != nil {
-		return &RawSolution{}, err
+		// HiGHS's information is invalid if the solution was not
+		// produced by a solve, so evaluate the objective function
+		// ourselves.
+		model, mErr := toModel(obj)
+		if mErr != nil {
+			return &RawSolution{}, mErr
+		}
+		soln.Objective, err = model.EvalObjective(soln.ColumnPrimal)
+		if err != nil {
+			return &RawSolution{}, err
+		}
 	}
 
-	// Assign dual slices only if the dual-solution status is "feasible".
-	dss, err := soln.GetIntInfo("dual_solution_status")
+	// Assign dual slices only if the dual-solution status is "feasible" or,
+	// to aid diagnosis, if the model was found to be infeasible or
+	// unbounded.  In the latter case, also retrieve whatever rays HiGHS
+	// can provide.  If the dual-solution status is unavailable, omit the
+	// duals.
+	dss, err := getIntInfo(obj, "dual_solution_status")
 	if err != nil {
-		return &RawSolution{}, err
+		dss = int(C.kHighsSolutionStatusNone)
 	}
-	if dss == int(C.kHighsSolutionStatusFeasible) {
+	diagnostic := soln.Status == Infeasible || soln.Status == Unbounded ||
+		soln.Status == UnboundedOrInfeasible
+	if dss == int(C.kHighsSolutionStatusFeasible) || diagnostic {
 		soln.ColumnDual = convertSlice[float64, C.double](colDual)
 		soln.RowDual = convertSlice[float64, C.double](rowDual)
 	}
+	if diagnostic {
+		soln.DualRay, soln.PrimalRay = getRays(obj, nc, nr)
+	}
 
 	// If basis data are available, convert them from C to Go.
-	bValid, err := soln.GetIntInfo("basis_validity")
+	bValid, err := getIntInfo(obj, "basis_validity")
 	if err == nil && bValid == int(C.kHighsBasisValidityValid) {
 		colBasisStatus := make([]C.HighsInt, nc)
 		rowBasisStatus := make([]C.HighsInt, nr)
-		status = C.Highs_getBasis(hObj, &colBasisStatus[0], &rowBasisStatus[0])
-		err = newCallStatus(status, "Highs_getBasis", "Solve")
+		status = C.Highs_getBasis(obj, sliceToPointer(colBasisStatus), sliceToPointer(rowBasisStatus))
+		err = newCallStatus(status, "Highs_getBasis", goName)
 		if err != nil {
 			return &RawSolution{}, err
 		}
@@ -468,5 +1715,47 @@ func (m *RawModel) Solve() (*RawSolution, error) {
 			soln.RowBasis[i] = convertHighsBasisStatus(rbs)
 		}
 	}
-	return &soln, nil
+	return soln, nil
+}
+
+// logTailLines is the maximum number of log lines a StatusError retains.
+const logTailLines = 20
+
+// getRays returns the dual ray (of length nr) and primal ray (of length nc)
+// that HiGHS found while solving a model.  Either ray is nil if HiGHS did
+// not find one.  The caller must hold the model's lock.
+func getRays(obj unsafe.Pointer, nc, nr int) (dual, primal []float64) {
+	var has C.HighsInt
+	dRay := make([]C.double, nr)
+	status := C.Highs_getDualRay(obj, &has, sliceToPointer(dRay))
+	if status == C.kHighsStatusOk && has != 0 {
+		dual = convertSlice[float64, C.double](dRay)
+	}
+	pRay := make([]C.double, nc)
+	status = C.Highs_getPrimalRay(obj, &has, sliceToPointer(pRay))
+	if status == C.kHighsStatusOk && has != 0 {
+		primal = convertSlice[float64, C.double](pRay)
+	}
+	return dual, primal
+}
+
+// stoppedAtLimit returns true if HiGHS's most recent solve terminated because
+// it reached a user-specified limit.  HiGHS reports such terminations as
+// warnings, but the best solution found is still available.  The caller must
+// hold the object's lock.
+func stoppedAtLimit(obj unsafe.Pointer) bool {
+	return convertHighsModelStatus(C.Highs_getModelStatus(obj)).IsLimit()
+}
+
+// sizeAttrs returns trace attributes describing the size of the model stored
+// in a HiGHS object.  The caller must hold the object's lock.
+func sizeAttrs(obj unsafe.Pointer) []TraceAttr {
+	if !tracing() {
+		return nil
+	}
+	return []TraceAttr{
+		{"highs.num_cols", int(C.Highs_getNumCol(obj))},
+		{"highs.num_rows", int(C.Highs_getNumRow(obj))},
+		{"highs.num_nonzeros", int(C.Highs_getNumNz(obj))},
+	}
 }
@@ -5,7 +5,8 @@ package highs
 
 import (
 	"io"
-	"os"
+	"runtime"
+	"time"
 	"unsafe"
 )
 
@@ -15,21 +16,78 @@ import (
 import "C"
 
 // A RawSolution encapsulates all the values returned by various HiGHS solvers
-// and provides methods to retrieve additional information.
+// and provides methods to retrieve additional information.  A RawSolution's
+// methods may be called from any goroutine, including while the RawModel that
+// produced the solution is being re-solved in another goroutine.  In that
+// case, RunTime and the Get*Info, GetAllInfo, and InfoSnapshot methods return
+// immediately with values from the running solve, and all other methods
+// block until the solve completes.
 type RawSolution struct {
-	rm       *RawModel // Model that produced the solution
-	Solution           // Values returned by the solver
+	h        *handle // HiGHS object that produced the solution
+	Solution         // Values returned by the solver
+}
+
+// newRawSolution returns an otherwise empty RawSolution that holds a
+// reference to a given handle.  The caller must hold the handle's lock.
+func newRawSolution(h *handle) *RawSolution {
+	h.acquire()
+	soln := &RawSolution{h: h}
+	runtime.SetFinalizer(soln, func(s *RawSolution) {
+		s.h.release()
+	})
+	return soln
+}
+
+// lock locks a solution's handle and returns the underlying HiGHS object.  It
+// returns ErrClosed, leaving the handle unlocked, if the solution was not
+// produced by a RawModel.
+func (s *RawSolution) lock() (unsafe.Pointer, error) {
+	return s.h.lock()
+}
+
+// unlock unlocks a solution's handle.
+func (s *RawSolution) unlock() {
+	s.h.unlock()
+}
+
+// RunTime returns the amount of time HiGHS has spent solving the model that
+// produced the solution.  RunTime does not block, even if another goroutine is
+// re-solving the model, so it can be polled to monitor a solve's progress.
+func (s *RawSolution) RunTime() (time.Duration, error) {
+	return s.h.runTime()
 }
 
 // GetIntInfo returns the integer value of a named piece of information.
+//
+// While the model that produced the solution is being re-solved, GetIntInfo
+// and the other Get*Info methods report the progress of that solve rather
+// than waiting for it to finish.  Only simplex_iteration_count,
+// ipm_iteration_count, and mip_node_count, which start at zero, and
+// objective_function_value, mip_dual_bound, and mip_gap, once the MIP solver
+// has found them, are available then; requests for other items return an
+// error.
 func (s *RawSolution) GetIntInfo(info string) (int, error) {
+	obj, snap, err := s.h.lockOrLive()
+	if err != nil {
+		return 0, err
+	}
+	if snap != nil {
+		return liveValue[int](snap, info, "GetIntInfo")
+	}
+	defer s.unlock()
+	return getIntInfo(obj, info)
+}
+
+// getIntInfo implements GetIntInfo for a HiGHS object whose handle the caller
+// has already locked.
+func getIntInfo(obj unsafe.Pointer, info string) (int, error) {
 	// Convert the info argument from Go to C.
 	str := C.CString(info)
 	defer C.free(unsafe.Pointer(str))
 
 	// Get the value.
 	var val C.HighsInt
-	status := C.Highs_getIntInfoValue(s.rm.obj, str, &val)
+	status := C.Highs_getIntInfoValue(obj, str, &val)
 	err := newCallStatus(status, "Highs_getIntInfoValue", "GetIntInfo")
 	if err != nil {
 		return 0, err
@@ -38,15 +96,29 @@ func (s *RawSolution) GetIntInfo(info string) (int, error) {
 }
 
 // GetInt64Info returns the 64-bit integer value of a named piece of
-// information.
+// information.  See GetIntInfo for its behavior during a solve.
 func (s *RawSolution) GetInt64Info(info string) (int64, error) {
+	obj, snap, err := s.h.lockOrLive()
+	if err != nil {
+		return 0, err
+	}
+	if snap != nil {
+		return liveValue[int64](snap, info, "GetInt64Info")
+	}
+	defer s.unlock()
+	return getInt64Info(obj, info)
+}
+
+// getInt64Info implements GetInt64Info for a HiGHS object whose handle the
+// caller has already locked.
+func getInt64Info(obj unsafe.Pointer, info string) (int64, error) {
 	// Convert the info argument from Go to C.
 	str := C.CString(info)
 	defer C.free(unsafe.Pointer(str))
 
 	// Get the value.
 	var val C.int64_t
-	status := C.Highs_getInt64InfoValue(s.rm.obj, str, &val)
+	status := C.Highs_getInt64InfoValue(obj, str, &val)
 	err := newCallStatus(status, "Highs_getInt64InfoValue", "GetInt64Info")
 	if err != nil {
 		return 0, err
@@ -55,15 +127,29 @@ func (s *RawSolution) GetInt64Info(info string) (int64, error) {
 }
 
 // GetFloat64Info returns the floating-point value of a named piece of
-// information.
+// information.  See GetIntInfo for its behavior during a solve.
 func (s *RawSolution) GetFloat64Info(info string) (float64, error) {
+	obj, snap, err := s.h.lockOrLive()
+	if err != nil {
+		return 0.0, err
+	}
+	if snap != nil {
+		return liveValue[float64](snap, info, "GetFloat64Info")
+	}
+	defer s.unlock()
+	return getFloat64Info(obj, info)
+}
+
+// getFloat64Info implements GetFloat64Info for a HiGHS object whose handle
+// the caller has already locked.
+func getFloat64Info(obj unsafe.Pointer, info string) (float64, error) {
 	// Convert the info argument from Go to C.
 	str := C.CString(info)
 	defer C.free(unsafe.Pointer(str))
 
 	// Get the value.
 	var val C.double
-	status := C.Highs_getDoubleInfoValue(s.rm.obj, str, &val)
+	status := C.Highs_getDoubleInfoValue(obj, str, &val)
 	err := newCallStatus(status, "Highs_getDoubleInfoValue", "GetFloat64Info")
 	if err != nil {
 		return 0.0, err
@@ -71,67 +157,168 @@ func (s *RawSolution) GetFloat64Info(info string) (float64, error) {
 	return float64(val), nil
 }
 
+// GetAllInfo returns the value of every item of information listed by
+// InfoItems that the linked version of HiGHS recognizes.  Each value in the
+// returned map is an int, int64, or float64, according to the item's type.
+// While the model is being re-solved, GetAllInfo returns only the items
+// listed under GetIntInfo that the running solve has reported so far.
+func (s *RawSolution) GetAllInfo() (map[string]any, error) {
+	obj, snap, err := s.h.lockOrLive()
+	if err != nil {
+		return nil, err
+	}
+	if snap != nil {
+		return snap, nil
+	}
+	defer s.unlock()
+	return getAllInfo(obj)
+}
+
+// InfoSnapshot returns the value of every item of information listed by
+// InfoItems, captured while holding the solution's lock so that all values
+// describe the same solve.  Items the linked version of HiGHS does not report
+// are left zero, as are, while the model is being re-solved, items the running
+// solve has not reported (see GetAllInfo).
+func (s *RawSolution) InfoSnapshot() (Info, error) {
+	obj, snap, err := s.h.lockOrLive()
+	if err != nil {
+		return Info{}, err
+	}
+	if snap != nil {
+		return newInfo(snap), nil
+	}
+	defer s.unlock()
+	all, err := getAllInfo(obj)
+	if err != nil {
+		return Info{}, err
+	}
+	return newInfo(all), nil
+}
+
+// getAllInfo implements GetAllInfo.  The caller must hold the solution's lock.
+func getAllInfo(obj unsafe.Pointer) (map[string]any, error) {
+	// Ask HiGHS for the type of each item, skipping unrecognized items.
+	var err error
+	all := make(map[string]any, len(infoItems))
+	for _, item := range infoItems {
+		str := C.CString(item.Name)
+		var infoType C.HighsInt
+		status := C.Highs_getInfoType(obj, str, &infoType)
+		C.free(unsafe.Pointer(str))
+		if status != C.kHighsStatusOk {
+			continue
+		}
+		switch infoType {
+		case C.kHighsInfoTypeInt:
+			all[item.Name], err = getIntInfo(obj, item.Name)
+		case C.kHighsInfoTypeInt64:
+			all[item.Name], err = getInt64Info(obj, item.Name)
+		case C.kHighsInfoTypeDouble:
+			all[item.Name], err = getFloat64Info(obj, item.Name)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return all, nil
+}
+
+// GetDualRay returns a dual ray for the model that produced the solution, if
+// HiGHS can provide one, and nil otherwise.  When the model is Infeasible,
+// the dual ray is a Farkas certificate of infeasibility, with one value per
+// row, which makes it suitable for generating Benders feasibility cuts.
+// Solution.DualRay holds the same values but is populated only for
+// infeasible or unbounded models; GetDualRay asks HiGHS regardless and
+// reports any error it encounters.
+func (s *RawSolution) GetDualRay() ([]float64, error) {
+	obj, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer s.unlock()
+	var has C.HighsInt
+	ray := make([]C.double, int(C.Highs_getNumRow(obj)))
+	status := C.Highs_getDualRay(obj, &has, sliceToPointer(ray))
+	err = newCallStatus(status, "Highs_getDualRay", "GetDualRay")
+	if err != nil || has == 0 {
+		return nil, err
+	}
+	return convertSlice[float64, C.double](ray), nil
+}
+
+// GetPrimalRay is analogous to GetDualRay but returns a primal ray, with one
+// value per column, which certifies that an Unbounded model's objective can
+// be improved without limit.
+func (s *RawSolution) GetPrimalRay() ([]float64, error) {
+	obj, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer s.unlock()
+	var has C.HighsInt
+	ray := make([]C.double, int(C.Highs_getNumCol(obj)))
+	status := C.Highs_getPrimalRay(obj, &has, sliceToPointer(ray))
+	err = newCallStatus(status, "Highs_getPrimalRay", "GetPrimalRay")
+	if err != nil || has == 0 {
+		return nil, err
+	}
+	return convertSlice[float64, C.double](ray), nil
+}
+
 // WriteSolutionToFile writes a textual version of the solution to a named
 // file.  If the second argument is false, WriteSolutiontoFile will use a more
 // computer-friendly format; if true, it will use a more human-friendly format.
 func (s *RawSolution) WriteSolutionToFile(fn string, pretty bool) error {
+	obj, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer s.unlock()
+
 	// Convert the filename argument from Go to C.
 	cFName := C.CString(fn)
 	defer C.free(unsafe.Pointer(cFName))
 
 	// Write the solution.
 	if pretty {
-		status := C.Highs_writeSolutionPretty(s.rm.obj, cFName)
+		status := C.Highs_writeSolutionPretty(obj, cFName)
 		return newCallStatus(status, "Highs_writeSolutionPretty", "WriteSolutionToFile")
 	}
-	status := C.Highs_writeSolution(s.rm.obj, cFName)
+	status := C.Highs_writeSolution(obj, cFName)
 	return newCallStatus(status, "Highs_writeSolution", "WriteSolutionToFile")
 }
 
 // WriteSolution writes a textual version of the solution to an io.Writer.  If
-// the second argument is false, WriteSolutiontoFile will use a more
+// the second argument is false, WriteSolution will use a more
 // computer-friendly format; if true, it will use a more human-friendly format.
+// Column and row names, if the model has them, are written in place of
+// generated identifiers such as C0 and R0.  Where the operating system
+// supports it, WriteSolution streams HiGHS's output through a pipe rather
+// than staging it in a temporary file.
 func (s *RawSolution) WriteSolution(w io.Writer, pretty bool) error {
-	// Create a throwaway file to use as a staging area.
-	tFile, err := os.CreateTemp("", "highs-*.txt")
-	if err != nil {
-		return err
-	}
-	fName := tFile.Name()
-	defer os.Remove(fName)
-	err = tFile.Close()
+	obj, err := s.lock()
 	if err != nil {
 		return err
 	}
+	defer s.unlock()
 
-	// Convert the throwaway filename from Go to C.
-	cFName := C.CString(fName)
-	defer C.free(unsafe.Pointer(cFName))
-
-	// Write the solution to the throwaway file.
-	if pretty {
-		status := C.Highs_writeSolutionPretty(s.rm.obj, cFName)
-		err = newCallStatus(status, "Highs_writeSolutionPretty", "WriteSolution")
-	} else {
-		status := C.Highs_writeSolution(s.rm.obj, cFName)
-		err = newCallStatus(status, "Highs_writeSolution", "WriteSolution")
-	}
-	if err != nil {
-		return err
+	// Define a function that writes the solution to a named file.
+	write := func(fn string) error {
+		cFName := C.CString(fn)
+		defer C.free(unsafe.Pointer(cFName))
+		if pretty {
+			status := C.Highs_writeSolutionPretty(obj, cFName)
+			return newCallStatus(status, "Highs_writeSolutionPretty", "WriteSolution")
+		}
+		status := C.Highs_writeSolution(obj, cFName)
+		return newCallStatus(status, "Highs_writeSolution", "WriteSolution")
 	}
 
-	// Copy the contents of the throwaway file to the io.Writer.
-	tFile, err = os.Open(fName)
-	if err != nil {
-		return err
-	}
-	_, err = io.Copy(w, tFile)
-	if err != nil {
-		return err
-	}
-	err = tFile.Close()
-	if err != nil {
+	// Write the solution to a pipe or, failing that, to a throwaway file.
+	if ok, err := writeViaPipe(w, write); ok {
 		return err
 	}
-	return nil
+	return writeViaTempFile(w, ".txt", write)
 }
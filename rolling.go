@@ -0,0 +1,164 @@
+// This file provides a driver for rolling-horizon optimization.
+
+package highs
+
+import (
+	"fmt"
+	"math"
+)
+
+// A HorizonBuilder constructs the model for n consecutive periods beginning
+// with period first.  The model's columns must be grouped by period, with
+// the same number of columns for each period, so column j of period t (first
+// ≤ t < first+n) is column (t−first)·k + j, where k is the number of columns
+// per period passed to NewRollingHorizon.  The model may contain additional
+// columns after those.
+type HorizonBuilder func(first, n int) (*Model, error)
+
+// A RollingHorizon solves a problem over a long or unbounded time horizon as
+// a sequence of models, each covering a window of consecutive periods.  After
+// each solve, the decisions for the earliest periods in the window are
+// committed, and the window moves forward past them.  This is the usual
+// approach to scheduling and planning problems that are too large to solve
+// over the full horizon or whose later data are not yet known.
+type RollingHorizon struct {
+	// Commit is the number of periods committed by each step.  A value of
+	// zero is treated as 1.
+	Commit int
+
+	// History is the number of most recently committed periods to include
+	// at the start of each window, with their columns fixed to their
+	// committed values.  This lets constraints that link consecutive
+	// periods, such as inventory balances, refer to committed decisions.
+	History int
+
+	build     HorizonBuilder // Function that constructs each window's model
+	cols      int            // Number of columns per period
+	window    int            // Number of uncommitted periods per window
+	first     int            // First uncommitted period
+	committed [][]float64    // Committed column values, indexed by period
+	prev      []float64      // Column values from the previous solve, indexed from prevFirst
+	prevFirst int            // First period represented in prev
+}
+
+// NewRollingHorizon returns a RollingHorizon that uses a given function to
+// construct models of window uncommitted periods, each with colsPerPeriod
+// columns.  The first window begins with period zero.
+func NewRollingHorizon(build HorizonBuilder, colsPerPeriod, window int) (*RollingHorizon, error) {
+	if colsPerPeriod < 1 {
+		return nil, fmt.Errorf("colsPerPeriod must be positive but was %d", colsPerPeriod)
+	}
+	if window < 1 {
+		return nil, fmt.Errorf("window must be positive but was %d", window)
+	}
+	return &RollingHorizon{build: build, cols: colsPerPeriod, window: window}, nil
+}
+
+// Next returns the first period that has not yet been committed, which is
+// the first uncommitted period of the next window to be solved.
+func (rh *RollingHorizon) Next() int {
+	return rh.first
+}
+
+// Committed returns the committed column values for each period committed
+// so far, indexed by period.
+func (rh *RollingHorizon) Committed() [][]float64 {
+	return rh.committed
+}
+
+// prepare constructs the model for the current window, fixes the columns of
+// its history periods, and supplies a starting point derived from the
+// previous solve.  It returns the model and the period of its first column.
+func (rh *RollingHorizon) prepare() (*Model, int, error) {
+	// Construct the model.
+	hist := rh.History
+	if hist > rh.first {
+		hist = rh.first
+	}
+	start := rh.first - hist
+	m, err := rh.build(start, hist+rh.window)
+	if err != nil {
+		return nil, 0, err
+	}
+	_, nc := m.modelSize()
+	if nc < (hist+rh.window)*rh.cols {
+		return nil, 0, fmt.Errorf("model has %d columns but at least %d are required",
+			nc, (hist+rh.window)*rh.cols)
+	}
+	var ok1, ok2 bool
+	m.ColLower, ok1 = expandToLen(nc, m.ColLower, math.Inf(-1))
+	m.ColUpper, ok2 = expandToLen(nc, m.ColUpper, math.Inf(1))
+	if !(ok1 && ok2) {
+		return nil, 0, fmt.Errorf("inconsistent column counts")
+	}
+
+	// Fix the history columns to their committed values.
+	for c := 0; c < hist*rh.cols; c++ {
+		v := rh.committed[start+c/rh.cols][c%rh.cols]
+		m.ColLower[c] = v
+		m.ColUpper[c] = v
+	}
+
+	// Start from the previous solution where the windows overlap and from
+	// the value nearest zero elsewhere.
+	x := make([]float64, nc)
+	for c := range x {
+		x[c] = math.Max(m.ColLower[c], math.Min(0.0, m.ColUpper[c]))
+		if c < hist*rh.cols {
+			continue
+		}
+		if i := c + (start-rh.prevFirst)*rh.cols; c < (hist+rh.window)*rh.cols && i >= 0 && i < len(rh.prev) {
+			x[c] = rh.prev[i]
+		}
+	}
+	m.Start = &StartingPoint{ColumnPrimal: x}
+	return m, start, nil
+}
+
+// Step solves the model for the current window and commits the decisions for
+// its first Commit periods.  It returns the solution to the window's model,
+// whose columns begin with any History periods.  If the window's model
+// is not solved to optimality, Step returns an error and commits nothing, so
+// the caller may adjust the model and try again.  As with Model.Solve, a
+// warning from HiGHS is returned along with an optimal solution, whose
+// periods are committed.
+func (rh *RollingHorizon) Step() (Solution, error) {
+	// Solve the model for the current window.
+	m, start, err := rh.prepare()
+	if err != nil {
+		return Solution{}, err
+	}
+	soln, warn := m.Solve()
+	if warn != nil && !isWarning(warn) {
+		return soln, warn
+	}
+	if soln.Status != Optimal {
+		return soln, fmt.Errorf("window beginning with period %d was not solved to optimality (status %s)",
+			rh.first, soln.Status)
+	}
+
+	// Commit the earliest periods, rounding integer columns so they can be
+	// fixed exactly in later windows.
+	commit := rh.Commit
+	if commit < 1 {
+		commit = 1
+	}
+	if commit > rh.window {
+		commit = rh.window
+	}
+	for t := rh.first; t < rh.first+commit; t++ {
+		c0 := (t - start) * rh.cols
+		vals := append([]float64(nil), soln.ColumnPrimal[c0:c0+rh.cols]...)
+		for j := range vals {
+			if c0+j < len(m.VarTypes) && m.VarTypes[c0+j] != ContinuousType &&
+				m.VarTypes[c0+j] != SemiContinuousType {
+				vals[j] = math.Round(vals[j])
+			}
+		}
+		rh.committed = append(rh.committed, vals)
+	}
+	rh.prev = soln.ColumnPrimal
+	rh.prevFirst = start
+	rh.first += commit
+	return soln, warn
+}
@@ -0,0 +1,74 @@
+// This file tests the RollingHorizon driver.
+
+package highs
+
+import (
+	"math"
+	"testing"
+)
+
+// inventoryModel is a HorizonBuilder for a production-planning problem.
+// Each period t has a production column p_t, which costs 1 in even periods
+// and 3 in odd periods and is limited to 5 units, and an inventory column
+// s_t, which costs 0.1 per unit held.  Each period's demand of 4 units is
+// met by production and the previous period's inventory:
+//
+//	s_{t−1} + p_t − s_t = 4
+//
+// The inventory before period 0 is zero.  Because a window's first period
+// after period 0 is a History period, whose columns are fixed, its balance
+// row is omitted.
+func inventoryModel(first, n int) (*Model, error) {
+	m := &Model{}
+	for i := 0; i < n; i++ {
+		cost := 1.0
+		if (first+i)%2 == 1 {
+			cost = 3.0
+		}
+		m.ColCosts = append(m.ColCosts, cost, 0.1)
+		m.ColLower = append(m.ColLower, 0.0, 0.0)
+		m.ColUpper = append(m.ColUpper, 5.0, math.Inf(1))
+		if i == 0 && first > 0 {
+			continue
+		}
+		coeffs := make([]float64, 2*n)
+		coeffs[2*i] = 1.0
+		coeffs[2*i+1] = -1.0
+		if i > 0 {
+			coeffs[2*i-1] = 1.0
+		}
+		m.AddDenseRow(4.0, coeffs, 4.0)
+	}
+	return m, nil
+}
+
+// TestRollingHorizonPrepare tests that a window's History columns are fixed
+// to their committed values and that its starting point is taken from the
+// previous solve where the windows overlap.
+func TestRollingHorizonPrepare(t *testing.T) {
+	rh, err := NewRollingHorizon(inventoryModel, 2, 2)
+	checkErr(t, err)
+	rh.History = 1
+	rh.first = 1
+	rh.committed = [][]float64{{5.0, 1.0}}
+	rh.prev = []float64{5.0, 1.0, 3.0, 0.5}
+	rh.prevFirst = 0
+	m, start, err := rh.prepare()
+	checkErr(t, err)
+	if start != 0 {
+		t.Fatalf("expected the window to begin with period 0 but saw %d", start)
+	}
+	compSlices(t, "ColLower", m.ColLower, []float64{5.0, 1.0, 0.0, 0.0, 0.0, 0.0})
+	compSlices(t, "ColUpper", m.ColUpper, []float64{5.0, 1.0, 5.0, math.Inf(1), 5.0, math.Inf(1)})
+	compSlices(t, "Start", m.Start.ColumnPrimal, []float64{5.0, 1.0, 3.0, 0.5, 0.0, 0.0})
+
+	// Ensure that invalid arguments are rejected.
+	if _, err = NewRollingHorizon(inventoryModel, 0, 2); err == nil {
+		t.Fatal("NewRollingHorizon accepted zero columns per period")
+	}
+	rh, err = NewRollingHorizon(inventoryModel, 3, 2)
+	checkErr(t, err)
+	if _, _, err = rh.prepare(); err == nil {
+		t.Fatal("prepare accepted a model with too few columns")
+	}
+}
@@ -0,0 +1,160 @@
+// This file provides helpers that fix the integer columns of a mixed-integer
+// model and solve the resulting LP, both as a rounding heuristic for finding
+// feasible solutions and as a means of obtaining duals for mixed-integer
+// solutions.
+
+package highs
+
+import (
+	"fmt"
+	"math"
+)
+
+// A RoundingStrategy specifies how RoundAndFix rounds the value of each
+// integer variable in an LP-relaxation solution.
+type RoundingStrategy int
+
+// These are the values a RoundingStrategy accepts:
+const (
+	RoundNearest  RoundingStrategy = iota // Round to the nearest integer, with halves rounded away from zero
+	RoundDown                             // Round toward −∞
+	RoundUp                               // Round toward +∞
+	RoundTruncate                         // Round toward zero
+)
+
+// round rounds a value according to a RoundingStrategy.
+func (rs RoundingStrategy) round(v float64) (float64, error) {
+	switch rs {
+	case RoundNearest:
+		return math.Round(v), nil
+	case RoundDown:
+		return math.Floor(v), nil
+	case RoundUp:
+		return math.Ceil(v), nil
+	case RoundTruncate:
+		return math.Trunc(v), nil
+	default:
+		return 0.0, fmt.Errorf("unrecognized rounding strategy %d", int(rs))
+	}
+}
+
+// roundColumn rounds the value of an integer or semi-integer column and
+// clamps the result to the column's bounds.  A semi-integer column whose
+// rounded value lies between zero and its lower bound is moved to whichever
+// of those is closer.
+func roundColumn(v float64, vt VariableType, lb, ub float64, rs RoundingStrategy) (float64, error) {
+	r, err := rs.round(v)
+	if err != nil {
+		return 0.0, err
+	}
+	lb, ub = math.Ceil(lb), math.Floor(ub)
+	if vt == SemiIntegerType && r < lb {
+		if r <= lb/2.0 {
+			return 0.0, nil
+		}
+		return lb, nil
+	}
+	return math.Max(lb, math.Min(r, ub)), nil
+}
+
+// fixIntegers returns a copy of a model in which each integer,
+// implicit-integer, and semi-integer column is made continuous and fixed at
+// its value in a given solution, rounded according to a RoundingStrategy.
+// Each semi-continuous column is likewise made continuous and either fixed at
+// zero or constrained to its nonzero range, whichever is closer to its value.
+// The result is an LP (or QP) over the remaining continuous columns.
+func (m *Model) fixIntegers(colValues []float64, rs RoundingStrategy) (Model, error) {
+	// Fill in default bounds and types as ToRawModel does.
+	_, nc := m.modelSize()
+	if len(colValues) != nc {
+		return Model{}, fmt.Errorf("solution has %d columns but the model has %d",
+			len(colValues), nc)
+	}
+	var ok bool
+	var colLower, colUpper []float64
+	var varTypes []VariableType
+	if colLower, ok = expandToLen(nc, m.ColLower, math.Inf(-1)); !ok {
+		return Model{}, fmt.Errorf("inconsistent column counts")
+	}
+	if colUpper, ok = expandToLen(nc, m.ColUpper, math.Inf(1)); !ok {
+		return Model{}, fmt.Errorf("inconsistent column counts")
+	}
+	if varTypes, ok = expandToLen(nc, m.VarTypes, ContinuousType); !ok {
+		return Model{}, fmt.Errorf("inconsistent column counts")
+	}
+
+	// Fix each integer column at its rounded value.
+	fixed := *m
+	fixed.ColLower = append([]float64(nil), colLower...)
+	fixed.ColUpper = append([]float64(nil), colUpper...)
+	fixed.VarTypes = make([]VariableType, nc)
+	for c, vt := range varTypes {
+		switch vt {
+		case IntegerType, ImplicitIntegerType, SemiIntegerType:
+			v, err := roundColumn(colValues[c], vt, colLower[c], colUpper[c], rs)
+			if err != nil {
+				return Model{}, err
+			}
+			fixed.ColLower[c] = v
+			fixed.ColUpper[c] = v
+		case SemiContinuousType:
+			if colValues[c] <= colLower[c]/2.0 {
+				fixed.ColLower[c] = 0.0
+				fixed.ColUpper[c] = 0.0
+			}
+		}
+	}
+	return fixed, nil
+}
+
+// RoundAndFix is a cheap primal heuristic for mixed-integer models.  Given a
+// solution to the model's LP relaxation, it rounds the value of each integer,
+// implicit-integer, and semi-integer column according to a RoundingStrategy,
+// fixes those columns at their rounded values, and solves the remaining LP for
+// the continuous columns.  Semi-continuous columns are fixed at zero or
+// restricted to their nonzero range, whichever is closer to their relaxation
+// value.  The Status field of the returned Solution is Optimal if the rounded
+// values admit a feasible solution and typically Infeasible if not.  The model
+// itself is not modified.
+func (m *Model) RoundAndFix(relax Solution, rs RoundingStrategy) (Solution, error) {
+	fixed, err := m.fixIntegers(relax.ColumnPrimal, rs)
+	if err != nil {
+		return Solution{}, err
+	}
+	return fixed.Solve()
+}
+
+// SolveWithMIPDuals solves a mixed-integer model and then, if an optimal
+// solution was found, fixes every integer column at its optimal value and
+// re-solves the resulting LP to obtain dual values.  The returned Solution's
+// ColumnPrimal, RowPrimal, Objective, and Status fields come from the MIP
+// solve, while its ColumnDual, RowDual, ColumnBasis, and RowBasis fields come
+// from the fixed LP.  The duals (often called "MIP shadow prices") are valid
+// only for perturbations that leave the integer columns' optimal values
+// unchanged.  As with Solve, a warning from the MIP solve is returned along
+// with the solution.
+func (m *Model) SolveWithMIPDuals() (Solution, error) {
+	// Solve the MIP.
+	soln, warn := m.Solve()
+	if (warn != nil && !isWarning(warn)) || soln.Status != Optimal {
+		return soln, warn
+	}
+
+	// Solve the fixed LP, and copy its duals into the MIP solution.
+	fixed, err := m.fixIntegers(soln.ColumnPrimal, RoundNearest)
+	if err != nil {
+		return Solution{}, err
+	}
+	lpSoln, err := fixed.Solve()
+	if err != nil && !isWarning(err) {
+		return Solution{}, err
+	}
+	if lpSoln.Status != Optimal {
+		return Solution{}, fmt.Errorf("fixed LP returned %s instead of Optimal", lpSoln.Status)
+	}
+	soln.ColumnDual = lpSoln.ColumnDual
+	soln.RowDual = lpSoln.RowDual
+	soln.ColumnBasis = lpSoln.ColumnBasis
+	soln.RowBasis = lpSoln.RowBasis
+	return soln, warn
+}
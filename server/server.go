@@ -0,0 +1,388 @@
+//go:build cgo
+
+/*
+Package server exposes HiGHS as an HTTP service.  A Server accepts models in
+either JSON or MPS form, solves them synchronously or as asynchronous jobs,
+and lets clients poll and cancel those jobs.
+
+The endpoints are as follows:
+
+	POST   /solve       Solve a model and return the result
+	POST   /jobs        Start solving a model and return a job ID
+	GET    /jobs/{id}   Return a job's state and, once available, its result
+	DELETE /jobs/{id}   Cancel a job and forget it
+
+Jobs that have finished or failed are forgotten automatically once they are
+older than the Server's JobTTL.
+
+The body of a POST is a Request expressed in JSON.  Because JSON cannot
+represent infinities, bounds should be expressed as values with magnitude of
+at least 1e20, which HiGHS treats as infinite.  Responses are expressed in
+JSON.
+
+Only HTTP is supported; the package has no dependencies beyond the standard
+library and the highs package.
+*/
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lanl/highs"
+)
+
+// A Request represents a model to solve and the options to apply while
+// solving it.  Exactly one of Model and MPS must be provided.
+type Request struct {
+	Model   *highs.Model   `json:"model,omitempty"`   // Model in structured form
+	MPS     string         `json:"mps,omitempty"`     // Model in MPS format
+	Options map[string]any `json:"options,omitempty"` // HiGHS options (name → value)
+}
+
+// A Result represents the outcome of solving a model.  Non-finite values are
+// reported as ±1.7976931348623157e308 (the largest float64), as JSON cannot
+// represent infinities or NaNs.  Warnings lists any warnings HiGHS issued
+// while loading or solving the model; these do not prevent a result from
+// being returned.
+type Result struct {
+	Status       string    `json:"status"`
+	Objective    float64   `json:"objective"`
+	ColumnPrimal []float64 `json:"column_primal,omitempty"`
+	RowPrimal    []float64 `json:"row_primal,omitempty"`
+	ColumnDual   []float64 `json:"column_dual,omitempty"`
+	RowDual      []float64 `json:"row_dual,omitempty"`
+	Warnings     []string  `json:"warnings,omitempty"`
+}
+
+// A JobState indicates the progress of an asynchronous job.
+type JobState string
+
+// These are the values a JobState accepts:
+const (
+	Running  JobState = "running"
+	Finished JobState = "finished"
+	Failed   JobState = "failed"
+)
+
+// A JobStatus is returned when a client queries an asynchronous job.
+type JobStatus struct {
+	ID     string   `json:"id"`               // Job identifier
+	State  JobState `json:"state"`            // Job progress
+	Result *Result  `json:"result,omitempty"` // Result, once State is Finished
+	Error  string   `json:"error,omitempty"`  // Error message, once State is Failed
+}
+
+// A job represents a single asynchronous solve.
+type job struct {
+	cancel context.CancelFunc // Function that cancels the solve
+	status JobStatus          // Current status, protected by Server.mu
+	done   time.Time          // Time the job stopped running, protected by Server.mu
+}
+
+// A Server is an http.Handler that solves models with HiGHS.
+type Server struct {
+	// MaxRequestBytes limits the size of a request body.  Zero means
+	// DefaultMaxRequestBytes.
+	MaxRequestBytes int64
+
+	// JobTTL is how long a finished or failed job is retained before
+	// being forgotten.  Zero means DefaultJobTTL.
+	JobTTL time.Duration
+
+	mu     sync.Mutex      // Protects all of the following fields
+	jobs   map[string]*job // Asynchronous jobs, indexed by ID
+	nextID uint64          // Number from which to form the next job ID
+}
+
+// DefaultMaxRequestBytes is the default limit on the size of a request body.
+const DefaultMaxRequestBytes = 64 << 20
+
+// DefaultJobTTL is the default length of time for which a finished or failed
+// job is retained.
+const DefaultJobTTL = time.Hour
+
+// New returns a new Server with no jobs.
+func New() *Server {
+	return &Server{jobs: make(map[string]*job)}
+}
+
+// ServeHTTP dispatches an HTTP request to the appropriate handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/solve":
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, "use POST to solve a model")
+			return
+		}
+		s.handleSolve(w, r)
+	case r.URL.Path == "/jobs":
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, "use POST to start a job")
+			return
+		}
+		s.handleStartJob(w, r)
+	case strings.HasPrefix(r.URL.Path, "/jobs/"):
+		id := strings.TrimPrefix(r.URL.Path, "/jobs/")
+		switch r.Method {
+		case http.MethodGet:
+			s.handleGetJob(w, id)
+		case http.MethodDelete:
+			s.handleDeleteJob(w, id)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "use GET or DELETE to access a job")
+		}
+	default:
+		writeError(w, http.StatusNotFound, "no such endpoint")
+	}
+}
+
+// writeJSON writes a value to an http.ResponseWriter in JSON format.
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error message to an http.ResponseWriter in JSON
+// format.
+func writeError(w http.ResponseWriter, code int, msg string) {
+	writeJSON(w, code, struct {
+		Error string `json:"error"`
+	}{msg})
+}
+
+// readRequest parses a Request from the body of an HTTP request.
+func (s *Server) readRequest(w http.ResponseWriter, r *http.Request) (*Request, error) {
+	maxBytes := s.MaxRequestBytes
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxRequestBytes
+	}
+	var req Request
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		return nil, err
+	}
+	if (req.Model == nil) == (req.MPS == "") {
+		return nil, errors.New("exactly one of model and mps must be specified")
+	}
+	return &req, nil
+}
+
+// handleSolve solves a model synchronously.  The solve is canceled if the
+// client disconnects.
+func (s *Server) handleSolve(w http.ResponseWriter, r *http.Request) {
+	req, err := s.readRequest(w, r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	res, err := Solve(r.Context(), req)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+// handleStartJob starts solving a model asynchronously.
+func (s *Server) handleStartJob(w http.ResponseWriter, r *http.Request) {
+	req, err := s.readRequest(w, r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Register the job, first forgetting any expired jobs.
+	ctx, cancel := context.WithCancel(context.Background())
+	s.mu.Lock()
+	s.evictJobs(time.Now())
+	s.nextID++
+	id := strconv.FormatUint(s.nextID, 10)
+	j := &job{
+		cancel: cancel,
+		status: JobStatus{ID: id, State: Running},
+	}
+	s.jobs[id] = j
+	status := j.status
+	s.mu.Unlock()
+
+	// Solve the model in the background.
+	go func() {
+		defer cancel()
+		res, err := Solve(ctx, req)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		j.done = time.Now()
+		if err != nil {
+			j.status.State = Failed
+			j.status.Error = err.Error()
+			return
+		}
+		j.status.State = Finished
+		j.status.Result = res
+	}()
+	writeJSON(w, http.StatusAccepted, status)
+}
+
+// evictJobs forgets all jobs that stopped running more than JobTTL before a
+// given time.  The caller must hold s.mu.
+func (s *Server) evictJobs(now time.Time) {
+	ttl := s.JobTTL
+	if ttl <= 0 {
+		ttl = DefaultJobTTL
+	}
+	for id, j := range s.jobs {
+		if j.status.State != Running && now.Sub(j.done) > ttl {
+			delete(s.jobs, id)
+		}
+	}
+}
+
+// handleGetJob reports the status of an asynchronous job.
+func (s *Server) handleGetJob(w http.ResponseWriter, id string) {
+	s.mu.Lock()
+	s.evictJobs(time.Now())
+	j, ok := s.jobs[id]
+	var status JobStatus
+	if ok {
+		status = j.status
+	}
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no such job %q", id))
+		return
+	}
+	writeJSON(w, http.StatusOK, status)
+}
+
+// handleDeleteJob cancels an asynchronous job, if it is still running, and
+// forgets it.
+func (s *Server) handleDeleteJob(w http.ResponseWriter, id string) {
+	s.mu.Lock()
+	j, ok := s.jobs[id]
+	delete(s.jobs, id)
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no such job %q", id))
+		return
+	}
+	j.cancel()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// setOption assigns a HiGHS option a value decoded from JSON.
+func setOption(raw *highs.RawModel, name string, value any) error {
+	switch v := value.(type) {
+	case bool:
+		return raw.SetBoolOption(name, v)
+	case string:
+		return raw.SetStringOption(name, v)
+	case float64:
+		// JSON numbers decode as float64.  Let the option's type
+		// determine how the number is interpreted.
+		return raw.SetOptionFromString(name, strconv.FormatFloat(v, 'f', -1, 64))
+	default:
+		return fmt.Errorf("option %q has unsupported type %T", name, value)
+	}
+}
+
+// Solve solves the model described by a Request.  It is exported for use by
+// programs that want Server's request handling without HTTP.  Warnings from
+// HiGHS are reported in the Result's Warnings field rather than as an error.
+func Solve(ctx context.Context, req *Request) (*Result, error) {
+	// Define a function that records warnings and passes along all other
+	// errors.
+	var warnings []string
+	tolerate := func(err error) error {
+		var cs highs.CallStatus
+		if errors.As(err, &cs) && cs.IsWarning() {
+			warnings = append(warnings, err.Error())
+			return nil
+		}
+		return err
+	}
+
+	// Load the model.
+	var raw *highs.RawModel
+	var err error
+	if req.Model != nil {
+		raw, err = req.Model.ToRawModelWithOptions(highs.RawModelOptions{
+			Validate: req.Model.Strict,
+		})
+		if err = tolerate(err); err != nil {
+			return nil, err
+		}
+		defer raw.Close()
+		err = raw.SetBoolOption("output_flag", false)
+	} else {
+		raw = highs.NewRawModel()
+		defer raw.Close()
+		err = raw.SetBoolOption("output_flag", false)
+		if err == nil {
+			err = tolerate(raw.ReadModel(strings.NewReader(req.MPS)))
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply the options.
+	for name, value := range req.Options {
+		err = setOption(raw, name, value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Solve the model.
+	soln, err := raw.SolveContext(ctx)
+	if err = tolerate(err); err != nil {
+		return nil, err
+	}
+	return &Result{
+		Status:       soln.Status.String(),
+		Objective:    finite(soln.Objective),
+		ColumnPrimal: finiteSlice(soln.ColumnPrimal),
+		RowPrimal:    finiteSlice(soln.RowPrimal),
+		ColumnDual:   finiteSlice(soln.ColumnDual),
+		RowDual:      finiteSlice(soln.RowDual),
+		Warnings:     warnings,
+	}, nil
+}
+
+// finite maps infinities to the largest-magnitude float64 of the same sign
+// and NaN to zero so that the result can be represented in JSON.
+func finite(x float64) float64 {
+	switch {
+	case math.IsNaN(x):
+		return 0.0
+	case math.IsInf(x, 1):
+		return math.MaxFloat64
+	case math.IsInf(x, -1):
+		return -math.MaxFloat64
+	default:
+		return x
+	}
+}
+
+// finiteSlice applies finite to each element of a slice.
+func finiteSlice(xs []float64) []float64 {
+	if xs == nil {
+		return nil
+	}
+	ys := make([]float64, len(xs))
+	for i, x := range xs {
+		ys[i] = finite(x)
+	}
+	return ys
+}
@@ -0,0 +1,209 @@
+//go:build cgo
+
+// This file tests the HiGHS HTTP service.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lanl/highs"
+)
+
+// requestBody is a JSON-encoded Request for the following model:
+//
+//	Satisfy 23 <= x_0 + x_1 <= 23
+//	        17 <= x_0 - x_1 <= 17
+const requestBody = `{
+  "model": {
+    "RowLower": [23, 17],
+    "RowUpper": [23, 17],
+    "ConstMatrix": [
+      {"Row": 0, "Col": 0, "Val": 1},
+      {"Row": 0, "Col": 1, "Val": 1},
+      {"Row": 1, "Col": 0, "Val": 1},
+      {"Row": 1, "Col": 1, "Val": -1}
+    ]
+  },
+  "options": {"time_limit": 60, "presolve": "off"}
+}`
+
+// checkResult confirms that a Result represents the solution to the model in
+// requestBody.
+func checkResult(t *testing.T, res *Result) {
+	t.Helper()
+	if res.Status != "Optimal" {
+		t.Fatalf("expected status Optimal but saw %s", res.Status)
+	}
+	if len(res.ColumnPrimal) != 2 || res.ColumnPrimal[0] != 20.0 || res.ColumnPrimal[1] != 3.0 {
+		t.Fatalf("expected column primal [20 3] but saw %v", res.ColumnPrimal)
+	}
+}
+
+// TestSolve tests synchronous solving over HTTP.
+func TestSolve(t *testing.T) {
+	ts := httptest.NewServer(New())
+	defer ts.Close()
+	resp, err := http.Post(ts.URL+"/solve", "application/json", bytes.NewBufferString(requestBody))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected HTTP status 200 but saw %d", resp.StatusCode)
+	}
+	var res Result
+	if err = json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		t.Fatal(err)
+	}
+	checkResult(t, &res)
+}
+
+// TestJobs tests asynchronous solving over HTTP.
+func TestJobs(t *testing.T) {
+	// Start a job.
+	ts := httptest.NewServer(New())
+	defer ts.Close()
+	resp, err := http.Post(ts.URL+"/jobs", "application/json", bytes.NewBufferString(requestBody))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var js JobStatus
+	err = json.NewDecoder(resp.Body).Decode(&js)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("expected HTTP status 202 but saw %d", resp.StatusCode)
+	}
+
+	// Poll the job until it finishes.
+	for js.State == Running {
+		time.Sleep(10 * time.Millisecond)
+		resp, err = http.Get(ts.URL + "/jobs/" + js.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = json.NewDecoder(resp.Body).Decode(&js)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if js.State != Finished {
+		t.Fatalf("job ended in state %s (%s)", js.State, js.Error)
+	}
+	checkResult(t, js.Result)
+
+	// Delete the job and ensure it is gone.
+	req, err := http.NewRequest(http.MethodDelete, ts.URL+"/jobs/"+js.ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, code := range []int{http.StatusNoContent, http.StatusNotFound} {
+		resp, err = http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != code {
+			t.Fatalf("expected HTTP status %d but saw %d", code, resp.StatusCode)
+		}
+	}
+}
+
+// TestBadRequest tests that malformed requests are rejected.
+func TestBadRequest(t *testing.T) {
+	ts := httptest.NewServer(New())
+	defer ts.Close()
+	for _, body := range []string{`{}`, `{"bogus": 1}`, `not JSON`} {
+		resp, err := http.Post(ts.URL+"/solve", "application/json", bytes.NewBufferString(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("expected HTTP status 400 for %q but saw %d", body, resp.StatusCode)
+		}
+	}
+}
+
+// TestSolveWarning tests that a warning from HiGHS is reported alongside a
+// result rather than as an error.  It solves the following model, in which
+// HiGHS ignores the tiny coefficient:
+//
+//	Satisfy 23 <= x_0 + x_1 + 1e-12 x_2 <= 23
+//	        17 <= x_0 - x_1             <= 17
+//	        0 <= x_2 <= 1
+func TestSolveWarning(t *testing.T) {
+	req := &Request{
+		Model: &highs.Model{
+			ColLower: []float64{0.0, 0.0, 0.0},
+			ColUpper: []float64{1.0e30, 1.0e30, 1.0},
+			RowLower: []float64{23.0, 17.0},
+			RowUpper: []float64{23.0, 17.0},
+			ConstMatrix: []highs.Nonzero{
+				{Row: 0, Col: 0, Val: 1.0},
+				{Row: 0, Col: 1, Val: 1.0},
+				{Row: 0, Col: 2, Val: 1.0e-12},
+				{Row: 1, Col: 0, Val: 1.0},
+				{Row: 1, Col: 1, Val: -1.0},
+			},
+		},
+	}
+	res, err := Solve(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Warnings) == 0 {
+		t.Fatal("expected a warning about the ignored coefficient")
+	}
+	res.ColumnPrimal = res.ColumnPrimal[:2]
+	checkResult(t, res)
+}
+
+// TestJobTTL tests that finished jobs are forgotten once they expire.
+func TestJobTTL(t *testing.T) {
+	// Run a job to completion.
+	srv := New()
+	srv.JobTTL = time.Millisecond
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+	resp, err := http.Post(ts.URL+"/jobs", "application/json", bytes.NewBufferString(requestBody))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var js JobStatus
+	err = json.NewDecoder(resp.Body).Decode(&js)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for {
+		srv.mu.Lock()
+		state := srv.jobs[js.ID].status.State
+		srv.mu.Unlock()
+		if state != Running {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Wait for the job to expire and ensure it is gone.
+	time.Sleep(10 * time.Millisecond)
+	resp, err = http.Get(ts.URL + "/jobs/" + js.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected HTTP status 404 but saw %d", resp.StatusCode)
+	}
+}
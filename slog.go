@@ -0,0 +1,47 @@
+//go:build go1.21 && cgo
+
+// This file integrates HiGHS logging with the log/slog package.
+
+package highs
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SlogLevel maps a LogType to a slog.Level.  LogDetailed and LogVerbose map
+// to slog.LevelDebug.
+func (lt LogType) SlogLevel() slog.Level {
+	switch lt {
+	case LogDetailed, LogVerbose:
+		return slog.LevelDebug
+	case LogWarning:
+		return slog.LevelWarn
+	case LogError:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// SetLogger routes HiGHS's log output to a structured logger, as with
+// SetLogFunc.  Each record carries a solve_id attribute that distinguishes
+// successive solves of the same model (zero for messages logged outside of a
+// solve).  Attach further attributes, such as a model name, with
+// slog.Logger.With.  A nil logger stops the redirection.
+func (m *RawModel) SetLogger(l *slog.Logger) error {
+	if l == nil {
+		return m.SetLogFunc(nil)
+	}
+	h := m.h
+	return m.SetLogFunc(func(lt LogType, msg string) {
+		// Log functions are invoked with the handle locked, so h.cb
+		// is stable.
+		var id uint64
+		if h.cb != nil {
+			id = h.cb.solveID
+		}
+		l.LogAttrs(context.Background(), lt.SlogLevel(), msg,
+			slog.Uint64("solve_id", id))
+	})
+}
@@ -0,0 +1,42 @@
+//go:build go1.21 && cgo
+
+// This file tests the highs package's integration with log/slog.
+
+package highs
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// TestSetLogger tests that HiGHS log messages are routed to a slog.Logger
+// along with the logger's attributes and a solve identifier.
+func TestSetLogger(t *testing.T) {
+	// Prepare a model that logs messages but not to the console.
+	model := NewRawModel()
+	checkErr(t, model.SetBoolOption("output_flag", true))
+	checkErr(t, model.SetBoolOption("log_to_console", false))
+	checkErr(t, model.AddColumnBounds([]float64{0.0, 0.0}, []float64{10.0, 10.0}))
+	checkErr(t, model.SetColumnCosts([]float64{1.0, 1.0}))
+	checkErr(t, model.AddDenseRow(4.0, []float64{1.0, 2.0}, 8.0))
+
+	// Solve the model twice, logging to a buffer.
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil)).With("model", "test")
+	checkErr(t, model.SetLogger(logger))
+	for i := 0; i < 2; i++ {
+		if _, err := model.Solve(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Check the output.
+	out := buf.String()
+	for _, s := range []string{"level=INFO", "model=test", "solve_id=1", "solve_id=2"} {
+		if !strings.Contains(out, s) {
+			t.Fatalf("log output does not contain %q:\n%s", s, out)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+// This file provides support for soft constraints, whose bounds may be
+// violated at a cost.
+
+package highs
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrSoftRows indicates that a model with soft rows was passed to a function
+// that does not support them.  Use Model.SoftenedModel to convert such a
+// model to an equivalent model with explicit slack columns.
+var ErrSoftRows = errors.New("model has soft rows")
+
+// hasSoftRows returns true if any row of the model has a nonzero penalty.
+func (m *Model) hasSoftRows() bool {
+	for _, p := range m.RowPenalties {
+		if p != 0.0 {
+			return true
+		}
+	}
+	return false
+}
+
+// A softSlack records a slack column that SoftenedModel added to a row.
+type softSlack struct {
+	row  int     // Row the slack column relaxes
+	col  int     // Index of the slack column
+	sign float64 // +1 for a slack that relaxes the lower bound; −1 for the upper bound
+}
+
+// softened converts a model with soft rows to an equivalent model with
+// explicit slack columns and returns a description of each slack column.
+func (m *Model) softened() (*Model, []softSlack, error) {
+	// Fill in defaults as ToRawModel does.
+	nr, nc := m.modelSize()
+	mInf, pInf := math.Inf(-1), math.Inf(1)
+	var ok1, ok2, ok3, ok4 bool
+	s := *m
+	s.RowPenalties = nil
+	s.ColCosts, ok1 = expandToLen(nc, m.ColCosts, 1.0)
+	s.ColLower, ok2 = expandToLen(nc, m.ColLower, mInf)
+	s.ColUpper, ok3 = expandToLen(nc, m.ColUpper, pInf)
+	if !(ok1 && ok2 && ok3) {
+		return nil, nil, fmt.Errorf("inconsistent column counts")
+	}
+	var penalties []float64
+	s.RowLower, ok1 = expandToLen(nr, m.RowLower, mInf)
+	s.RowUpper, ok2 = expandToLen(nr, m.RowUpper, pInf)
+	penalties, ok3 = expandToLen(nr, m.RowPenalties, 0.0)
+	if !(ok1 && ok2 && ok3) {
+		return nil, nil, fmt.Errorf("inconsistent row counts")
+	}
+	if len(m.VarTypes) > 0 {
+		if s.VarTypes, ok4 = expandToLen(nc, m.VarTypes, ContinuousType); !ok4 {
+			return nil, nil, fmt.Errorf("inconsistent column counts")
+		}
+	}
+
+	// Copy the slices that will be extended so as not to modify the
+	// caller's model.
+	s.ColCosts = append([]float64(nil), s.ColCosts...)
+	s.ColLower = append([]float64(nil), s.ColLower...)
+	s.ColUpper = append([]float64(nil), s.ColUpper...)
+	s.ConstMatrix = append([]Nonzero(nil), m.ConstMatrix...)
+	s.VarTypes = append([]VariableType(nil), s.VarTypes...)
+	if len(m.ColNames) > 0 {
+		s.ColNames = append([]string(nil), m.ColNames...)
+	}
+	if len(m.ColTags) > 0 {
+		s.ColTags = append([]any(nil), m.ColTags...)
+	}
+
+	// Add a slack column for each finite bound of each soft row.  A slack
+	// that relaxes the lower bound enters the row with coefficient +1, and
+	// a slack that relaxes the upper bound enters with coefficient −1.
+	// Each is penalized in the direction that worsens the objective.
+	var slacks []softSlack
+	for r, p := range penalties {
+		if p < 0.0 {
+			return nil, nil, fmt.Errorf("row %d has negative penalty %v", r, p)
+		}
+		if p == 0.0 {
+			continue
+		}
+		cost := p
+		if m.Maximize {
+			cost = -p
+		}
+		for _, sl := range []struct {
+			sign   float64
+			bound  float64
+			suffix string
+		}{
+			{1.0, s.RowLower[r], "under"},
+			{-1.0, s.RowUpper[r], "over"},
+		} {
+			if math.IsInf(sl.bound, 0) || math.Abs(sl.bound) >= highsInfinity {
+				continue
+			}
+			c := len(s.ColCosts)
+			s.ColCosts = append(s.ColCosts, cost)
+			s.ColLower = append(s.ColLower, 0.0)
+			s.ColUpper = append(s.ColUpper, pInf)
+			s.ConstMatrix = append(s.ConstMatrix, Nonzero{Row: r, Col: c, Val: sl.sign})
+			if len(s.VarTypes) > 0 {
+				s.VarTypes = append(s.VarTypes, ContinuousType)
+			}
+			if len(s.ColNames) > 0 {
+				name := fmt.Sprintf("row%d", r)
+				if r < len(m.RowNames) && m.RowNames[r] != "" {
+					name = m.RowNames[r]
+				}
+				s.ColNames = append(s.ColNames, name+"_"+sl.suffix)
+			}
+			if len(s.ColTags) > 0 {
+				s.ColTags = append(s.ColTags, nil)
+			}
+			slacks = append(slacks, softSlack{row: r, col: c, sign: sl.sign})
+		}
+	}
+	if len(s.VarTypes) == 0 {
+		s.VarTypes = nil
+	}
+	return &s, slacks, nil
+}
+
+// SoftenedModel returns a model equivalent to m in which each soft row (one
+// with a nonzero entry in RowPenalties) is relaxed by continuous,
+// nonnegative slack columns.  A slack column is added for each finite bound
+// of each soft row and is assigned a cost equal to the row's penalty,
+// negated when maximizing so that violations always worsen the objective.
+// The slack columns follow the original columns.  If the model has column
+// names, the slack columns are named after their rows with the suffix
+// "_under" or "_over".  The returned model has no RowPenalties and can be
+// passed to any function that accepts a Model.
+func (m *Model) SoftenedModel() (*Model, error) {
+	s, _, err := m.softened()
+	return s, err
+}
+
+// solveSoft solves a model with soft rows by solving the model returned by
+// SoftenedModel with a given function and mapping the solution back to the
+// original model.
+func (m *Model) solveSoft(solve func(*Model) (Solution, error)) (Solution, error) {
+	// Solve the softened model.
+	s, slacks, err := m.softened()
+	if err != nil {
+		return Solution{}, err
+	}
+	nr, nc := m.modelSize()
+	soln, err := solve(s)
+	if err != nil && len(soln.ColumnPrimal) == 0 {
+		return soln, err
+	}
+
+	// Compute the violation of each row, and remove the slacks' effect on
+	// the row activities.
+	if len(soln.ColumnPrimal) == len(s.ColCosts) {
+		soln.RowViolations = make([]float64, nr)
+		for _, sl := range slacks {
+			v := soln.ColumnPrimal[sl.col]
+			soln.RowViolations[sl.row] += v
+			if sl.row < len(soln.RowPrimal) {
+				soln.RowPrimal[sl.row] -= sl.sign * v
+			}
+		}
+	}
+
+	// Discard the slack columns.
+	trim := func(n int) int {
+		if n > nc {
+			return nc
+		}
+		return n
+	}
+	soln.ColumnPrimal = soln.ColumnPrimal[:trim(len(soln.ColumnPrimal))]
+	soln.ColumnDual = soln.ColumnDual[:trim(len(soln.ColumnDual))]
+	soln.ColumnBasis = soln.ColumnBasis[:trim(len(soln.ColumnBasis))]
+	if soln.PrimalRay != nil {
+		soln.PrimalRay = soln.PrimalRay[:trim(len(soln.PrimalRay))]
+	}
+	return soln, err
+}
@@ -0,0 +1,82 @@
+// This file tests support for soft constraints.
+
+package highs
+
+import (
+	"errors"
+	"io"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// softModel returns a model with two soft rows and one hard row:
+//
+//	Min.  x_0 + x_1
+//	s.t.  4 <= x_0 + x_1 <= 6   (penalty 0.5)
+//	           x_0 - x_1 <= 0   (penalty 2)
+//	      0 <= x_0       <= 10
+//	with  x_0, x_1 >= 0
+func softModel() *Model {
+	var m Model
+	m.ColCosts = []float64{1.0, 1.0}
+	m.ColLower = []float64{0.0, 0.0}
+	m.AddDenseRow(4.0, []float64{1.0, 1.0}, 6.0)
+	m.AddDenseRow(math.Inf(-1), []float64{1.0, -1.0}, 0.0)
+	m.AddDenseRow(0.0, []float64{1.0, 0.0}, 10.0)
+	m.ColNames = []string{"x0", "x1"}
+	m.RowNames = []string{"total", "order", "cap"}
+	m.RowPenalties = []float64{0.5, 2.0, 0.0}
+	return &m
+}
+
+// TestSoftenedModel tests that SoftenedModel adds a penalized slack column
+// for each finite bound of each soft row.
+func TestSoftenedModel(t *testing.T) {
+	m := softModel()
+	s, err := m.SoftenedModel()
+	checkErr(t, err)
+	compSlices(t, "ColCosts", s.ColCosts, []float64{1.0, 1.0, 0.5, 0.5, 2.0})
+	compSlices(t, "ColLower", s.ColLower, []float64{0.0, 0.0, 0.0, 0.0, 0.0})
+	expNames := []string{"x0", "x1", "total_under", "total_over", "order_over"}
+	if !reflect.DeepEqual(s.ColNames, expNames) {
+		t.Fatalf("expected column names %v but saw %v", expNames, s.ColNames)
+	}
+	expSlacks := []Nonzero{{0, 2, 1.0}, {0, 3, -1.0}, {1, 4, -1.0}}
+	if !reflect.DeepEqual(s.ConstMatrix[len(m.ConstMatrix):], expSlacks) {
+		t.Fatalf("expected slack coefficients %v but saw %v", expSlacks, s.ConstMatrix[len(m.ConstMatrix):])
+	}
+	if s.RowPenalties != nil || len(m.ColCosts) != 2 {
+		t.Fatal("SoftenedModel modified its receiver or retained the penalties")
+	}
+
+	// Ensure that soft rows are rejected where unsupported.
+	if err = m.WriteMPS(io.Discard); !errors.Is(err, ErrSoftRows) {
+		t.Fatalf("expected ErrSoftRows but saw %v", err)
+	}
+	m.RowPenalties[1] = -1.0
+	if _, err = m.SoftenedModel(); err == nil {
+		t.Fatal("SoftenedModel accepted a negative penalty")
+	}
+}
+
+// TestSolveSoft tests that the solution of a softened model is mapped back
+// to the original model.  It uses a stand-in solver that returns the
+// softened model's optimal solution, in which the first row is violated by
+// 4 units.
+func TestSolveSoft(t *testing.T) {
+	soln, err := softModel().solveSoft(func(s *Model) (Solution, error) {
+		return Solution{
+			Status:       Optimal,
+			ColumnPrimal: []float64{0.0, 0.0, 4.0, 0.0, 0.0},
+			RowPrimal:    []float64{4.0, 0.0, 0.0},
+			ColumnDual:   []float64{0.5, 0.5, 0.0, 1.0, 2.0},
+			Objective:    2.0,
+		}, nil
+	})
+	checkErr(t, err)
+	compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{0.0, 0.0})
+	compSlices(t, "ColumnDual", soln.ColumnDual, []float64{0.5, 0.5})
+	compSlices(t, "RowPrimal", soln.RowPrimal, []float64{0.0, 0.0, 0.0})
+	compSlices(t, "RowViolations", soln.RowViolations, []float64{4.0, 0.0, 0.0})
+}
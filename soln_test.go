@@ -1,3 +1,5 @@
+//go:build cgo
+
 // This file tests the high package's RawSolution wrapper.
 
 package highs
@@ -5,7 +7,13 @@ package highs
 import (
 	"bytes"
 	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 // modelAndSolve is a helper function that constructs and solves a simple MIP
@@ -116,6 +124,69 @@ func TestGetFloat64Info(t *testing.T) {
 	}
 }
 
+// TestGetAllInfo tests that GetAllInfo returns values of the types listed by
+// InfoItems.
+func TestGetAllInfo(t *testing.T) {
+	// Produce a solution.
+	soln, err := modelAndSolve()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Query it for all information.
+	all, err := soln.GetAllInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := all["mip_node_count"].(int64); !ok {
+		t.Fatalf("expected mip_node_count to be an int64 but saw %#v", all["mip_node_count"])
+	}
+	for _, item := range InfoItems() {
+		v, ok := all[item.Name]
+		if !ok {
+			continue // Not supported by the linked version of HiGHS
+		}
+		switch v.(type) {
+		case int:
+			ok = item.Type == IntInfo
+		case int64:
+			ok = item.Type == Int64Info
+		case float64:
+			ok = item.Type == Float64Info
+		default:
+			ok = false
+		}
+		if !ok {
+			t.Fatalf("expected %s to be of type %s but saw %#v", item.Name, item.Type, v)
+		}
+	}
+}
+
+// TestInfoSnapshot tests that InfoSnapshot agrees with the individual info
+// getters.
+func TestInfoSnapshot(t *testing.T) {
+	// Produce a solution.
+	soln, err := modelAndSolve()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Compare a few items to their values as retrieved individually.
+	in, err := soln.InfoSnapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if obj, err := soln.GetFloat64Info("objective_function_value"); err != nil || in.ObjectiveFunctionValue != obj {
+		t.Fatalf("expected an objective value of %v but saw %v (%v)", obj, in.ObjectiveFunctionValue, err)
+	}
+	if nodes, err := soln.GetInt64Info("mip_node_count"); err != nil || in.MIPNodeCount != nodes {
+		t.Fatalf("expected a node count of %v but saw %v (%v)", nodes, in.MIPNodeCount, err)
+	}
+	if pss, err := soln.GetIntInfo("primal_solution_status"); err != nil || in.PrimalSolutionStatus != pss {
+		t.Fatalf("expected a primal solution status of %v but saw %v (%v)", pss, in.PrimalSolutionStatus, err)
+	}
+}
+
 // TestWriteSolution tests the writing of a solution in a textual format.
 func TestWriteSolution(t *testing.T) {
 	// Produce a solution.
@@ -158,6 +229,52 @@ None
 	}
 }
 
+// TestWriteSolutionNoTempDir tests that a solution can be written to an
+// io.Writer even when no temporary file can be created.
+func TestWriteSolutionNoTempDir(t *testing.T) {
+	// Produce a solution.
+	soln, err := modelAndSolve()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Write the solution to a buffer with an unusable temporary directory.
+	var buf bytes.Buffer
+	t.Setenv("TMPDIR", filepath.Join(t.TempDir(), "no-such-directory"))
+	if _, err = os.Stat("/dev/fd"); err != nil {
+		t.Skip("pipes cannot be named on this system")
+	}
+	checkErr(t, soln.WriteSolution(&buf, false))
+	if !strings.HasPrefix(buf.String(), "Model status\nOptimal\n") {
+		t.Fatalf("unexpected solution text %q", buf.String())
+	}
+}
+
+// TestWriteViaPipe tests that writeViaPipe copies to an io.Writer everything a
+// function writes to a named file and returns the function's error.
+func TestWriteViaPipe(t *testing.T) {
+	// Write a string through a pipe.
+	var buf bytes.Buffer
+	exp := strings.Repeat("Hello, pipe!\n", 10000) // Larger than a pipe's buffer
+	ok, err := writeViaPipe(&buf, func(fn string) error {
+		return os.WriteFile(fn, []byte(exp), 0o644)
+	})
+	if !ok {
+		t.Skip("pipes cannot be named on this system")
+	}
+	checkErr(t, err)
+	if buf.String() != exp {
+		t.Fatalf("expected %d bytes but saw %d", len(exp), buf.Len())
+	}
+
+	// Ensure that errors are propagated.
+	myErr := errors.New("write failed")
+	_, err = writeViaPipe(&buf, func(string) error { return myErr })
+	if err != myErr {
+		t.Fatalf("expected %v but saw %v", myErr, err)
+	}
+}
+
 // TestWriteSolutionPretty tests the writing of a solution in a human-friendly
 // textual format.
 func TestWriteSolutionPretty(t *testing.T) {
@@ -193,3 +310,229 @@ Objective value: 23
 		t.Fatal("textual solution was not as expected")
 	}
 }
+
+// namedRawModel returns a RawModel for the following model, whose columns are
+// named "apples" and "cherries" and whose rows are named "fruit" and
+// "surplus":
+//
+//	Min.  x_0 + 2x_1
+//	s.t.  1 <= x_0 + x_1
+//	      0 <= x_0 - x_1
+//	      0 <= x_0, x_1
+//
+// The first column and row are named through a Model.  The second column and
+// row are named "bananas" and "balance" through the Model then renamed
+// through the RawModel.
+func namedRawModel(t *testing.T) *RawModel {
+	t.Helper()
+	var model Model
+	model.ColCosts = []float64{1.0, 2.0}
+	model.ColLower = []float64{0.0, 0.0}
+	model.AddDenseRow(1.0, []float64{1.0, 1.0}, math.Inf(1))
+	model.AddDenseRow(0.0, []float64{1.0, -1.0}, math.Inf(1))
+	model.ColNames = []string{"apples", "bananas"}
+	model.RowNames = []string{"fruit", "balance"}
+	raw, err := model.ToRawModel()
+	checkErr(t, err)
+	checkErr(t, raw.SetBoolOption("output_flag", false))
+	checkErr(t, raw.SetColumnName(1, "cherries"))
+	checkErr(t, raw.SetRowName(1, "surplus"))
+	return raw
+}
+
+// TestColumnRowName tests that column and row names, whether assigned through
+// a Model or a RawModel, are reported by ColumnName and RowName.
+func TestColumnRowName(t *testing.T) {
+	raw := namedRawModel(t)
+	defer raw.Close()
+	for _, tc := range []struct {
+		get  func(int) (string, error)
+		idx  int
+		name string
+	}{
+		{raw.ColumnName, 0, "apples"},
+		{raw.ColumnName, 1, "cherries"},
+		{raw.RowName, 0, "fruit"},
+		{raw.RowName, 1, "surplus"},
+	} {
+		name, err := tc.get(tc.idx)
+		checkErr(t, err)
+		if name != tc.name {
+			t.Fatalf("expected name %q but saw %q", tc.name, name)
+		}
+	}
+	if _, err := raw.RowName(2); err == nil {
+		t.Fatal("RowName accepted an out-of-range row")
+	}
+}
+
+// TestColumnRowByName tests that columns and rows can be found by their
+// current names but not by names that have been replaced.
+func TestColumnRowByName(t *testing.T) {
+	raw := namedRawModel(t)
+	defer raw.Close()
+	for _, tc := range []struct {
+		get  func(string) (int, error)
+		name string
+		idx  int
+	}{
+		{raw.ColumnByName, "cherries", 1},
+		{raw.RowByName, "fruit", 0},
+	} {
+		idx, err := tc.get(tc.name)
+		checkErr(t, err)
+		if idx != tc.idx {
+			t.Fatalf("expected %q to have index %d but saw %d", tc.name, tc.idx, idx)
+		}
+	}
+	if _, err := raw.RowByName("balance"); err == nil {
+		t.Fatal("RowByName found a replaced name")
+	}
+}
+
+// TestWriteSolutionNames tests that column and row names, whether assigned
+// through a Model or a RawModel, appear in both textual solution formats.
+// Only the presence of the names is checked so the test does not depend on
+// the details of HiGHS's formatting.
+func TestWriteSolutionNames(t *testing.T) {
+	// Solve a named model.
+	raw := namedRawModel(t)
+	defer raw.Close()
+	soln, err := raw.Solve()
+	checkErr(t, err)
+
+	// Ensure that the names appear in each format.
+	for _, pretty := range []bool{false, true} {
+		var buf bytes.Buffer
+		checkErr(t, soln.WriteSolution(&buf, pretty))
+		out := buf.String()
+		for _, name := range []string{"apples", "cherries", "fruit", "surplus"} {
+			if !strings.Contains(out, name) {
+				t.Fatalf("name %q is missing from solution (pretty=%v):\n%s", name, pretty, out)
+			}
+		}
+		for _, name := range []string{"bananas", "balance"} {
+			if strings.Contains(out, name) {
+				t.Fatalf("replaced name %q appears in solution (pretty=%v):\n%s", name, pretty, out)
+			}
+		}
+	}
+}
+
+// TestConcurrentInfo tests that a RawSolution can be queried while the model
+// that produced it is being re-solved in another goroutine.  It is most
+// useful when run with the race detector enabled.
+func TestConcurrentInfo(t *testing.T) {
+	// Produce an initial solution.
+	var model Model
+	model.ColCosts = []float64{3.0, 2.0, 1.0}
+	model.ColLower = []float64{0.0, 0.0, 0.0}
+	model.AddDenseRow(1.0, []float64{1.0, -1.0, 0.0}, math.Inf(1))
+	model.AddDenseRow(1.0, []float64{0.0, 1.0, -1.0}, math.Inf(1))
+	model.AddDenseRow(10.0, []float64{1.0, 1.0, 1.0}, math.Inf(1))
+	raw, err := model.ToRawModel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkErr(t, raw.SetBoolOption("output_flag", false))
+	soln, err := raw.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Repeatedly re-solve the model in the background.
+	done := make(chan error)
+	go func() {
+		for i := 0; i < 20; i++ {
+			if _, err := raw.Solve(); err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- nil
+	}()
+
+	// Poll the original solution until the background solves finish.
+	for {
+		select {
+		case err = <-done:
+			if err != nil {
+				t.Fatal(err)
+			}
+			return
+		default:
+		}
+		if _, err = soln.RunTime(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = raw.RunTime(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = soln.GetIntInfo("simplex_iteration_count"); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestLiveInfo tests that a RawSolution's information methods report the
+// information published by a running solve instead of waiting for the solve
+// to finish.  It simulates a running solve by holding the model's lock while
+// information is published.
+func TestLiveInfo(t *testing.T) {
+	// Produce a solution.
+	var model Model
+	model.ColCosts = []float64{1.0, 1.0}
+	model.AddDenseRow(23.0, []float64{1.0, 1.0}, 23.0)
+	model.AddDenseRow(17.0, []float64{1.0, -1.0}, 17.0)
+	raw, err := model.ToRawModel()
+	checkErr(t, err)
+	defer raw.Close()
+	checkErr(t, raw.SetBoolOption("output_flag", false))
+	soln, err := raw.Solve()
+	checkErr(t, err)
+
+	// Pretend that the model is being re-solved.
+	_, err = raw.lock()
+	checkErr(t, err)
+	stop := raw.h.startLiveInfo()
+	raw.h.live.Lock()
+	raw.h.liveInfo["simplex_iteration_count"] = 7
+	raw.h.liveInfo["mip_gap"] = 0.25
+	raw.h.live.Unlock()
+
+	// Query the solution from another goroutine, which would block if
+	// the queries required the lock.
+	errs := make(chan error, 1)
+	go func() {
+		if n, err := soln.GetIntInfo("simplex_iteration_count"); err != nil || n != 7 {
+			errs <- fmt.Errorf("expected 7 simplex iterations but saw %d (%v)", n, err)
+			return
+		}
+		if n, err := soln.GetInt64Info("mip_node_count"); err != nil || n != 0 {
+			errs <- fmt.Errorf("expected 0 MIP nodes but saw %d (%v)", n, err)
+			return
+		}
+		if in, err := soln.InfoSnapshot(); err != nil || in.MIPGap != 0.25 {
+			errs <- fmt.Errorf("expected a MIP gap of 0.25 but saw %v (%v)", in.MIPGap, err)
+			return
+		}
+		if _, err := soln.GetFloat64Info("max_dual_infeasibility"); err == nil {
+			errs <- errors.New("GetFloat64Info reported an item that is unavailable during a solve")
+			return
+		}
+		errs <- nil
+	}()
+	select {
+	case err = <-errs:
+	case <-time.After(10 * time.Second):
+		err = errors.New("information queries blocked during the solve")
+	}
+	stop()
+	raw.unlock()
+	checkErr(t, err)
+
+	// Ensure that the final values are reported once the solve ends.
+	if _, err = soln.GetFloat64Info("max_dual_infeasibility"); err != nil {
+		t.Fatal(err)
+	}
+}
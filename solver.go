@@ -0,0 +1,152 @@
+//go:build cgo
+
+// This file defines a solver-agnostic interface and an implementation of it
+// backed by HiGHS.
+
+package highs
+
+import (
+	"errors"
+	"fmt"
+)
+
+// A Solver is a minimal, solver-agnostic interface to a mathematical
+// optimizer.  Application code written against Solver rather than against a
+// concrete type can substitute a mock solver in tests.
+type Solver interface {
+	LoadModel(m *Model) error               // Load a model, replacing any previous model
+	SetOption(name string, value any) error // Set a solver option
+	Solve() (Solution, error)               // Solve the loaded model
+	Close() error                           // Release the solver's resources
+}
+
+// A ModelSolver is a Solver that uses HiGHS to solve Models.  Unlike
+// Model.Solve, a ModelSolver retains options across models.
+type ModelSolver struct {
+	raw  *RawModel      // Low-level model; nil until LoadModel is called
+	opts []solverOption // Options to apply to each loaded model
+}
+
+// solverOption is a single option name and value.
+type solverOption struct {
+	name  string
+	value any
+}
+
+// Ensure at compile time that ModelSolver implements Solver.
+var _ Solver = (*ModelSolver)(nil)
+
+// NewModelSolver returns a ModelSolver with no model loaded.  As with
+// Model.Solve, HiGHS's output is disabled unless the caller re-enables it by
+// setting the output_flag option.
+func NewModelSolver() *ModelSolver {
+	return &ModelSolver{
+		opts: []solverOption{{"output_flag", false}},
+	}
+}
+
+// applyOption applies a single option to a RawModel.  The option's type
+// determines which RawModel method is invoked.
+func applyOption(raw *RawModel, name string, value any) error {
+	switch v := value.(type) {
+	case bool:
+		return raw.SetBoolOption(name, v)
+	case int:
+		return raw.SetIntOption(name, v)
+	case float64:
+		return raw.SetFloat64Option(name, v)
+	case string:
+		return raw.SetStringOption(name, v)
+	default:
+		return fmt.Errorf("option %q has unsupported type %T", name, value)
+	}
+}
+
+// LoadModel loads a model into the solver, replacing any previously loaded
+// model.  All options set so far are applied to the new model.  A warning
+// from HiGHS is returned even though the model was loaded.
+func (s *ModelSolver) LoadModel(m *Model) error {
+	raw, err := m.ToRawModel()
+	if err != nil && !isWarning(err) {
+		return err
+	}
+	warn := err
+	for _, o := range s.opts {
+		err = applyOption(raw, o.name, o.value)
+		if err != nil {
+			raw.Close()
+			return err
+		}
+	}
+	if s.raw != nil {
+		s.raw.Close()
+	}
+	s.raw = raw
+	return warn
+}
+
+// SetOption sets a HiGHS option.  The value must be a bool, int, float64, or
+// string, according to the option's type.  The option applies to the current
+// model, if any, and to all models loaded subsequently.  Setting an option
+// again replaces its previous value.
+func (s *ModelSolver) SetOption(name string, value any) error {
+	if s.raw != nil {
+		err := applyOption(s.raw, name, value)
+		if err != nil {
+			return err
+		}
+	} else {
+		// Validate the value's type even when there is no model to
+		// which to apply it.
+		switch value.(type) {
+		case bool, int, float64, string:
+		default:
+			return fmt.Errorf("option %q has unsupported type %T", name, value)
+		}
+	}
+	for i, o := range s.opts {
+		if o.name == name {
+			s.opts[i].value = value
+			return nil
+		}
+	}
+	s.opts = append(s.opts, solverOption{name, value})
+	return nil
+}
+
+// SetDeterministic sets the options RawModel.SetDeterministic documents on
+// the current model, if any, and on all models loaded subsequently.  As with
+// RawModel.SetDeterministic, a program that changes the threads option after
+// solving must call ResetThreadPool before solving again.
+func (s *ModelSolver) SetDeterministic(det bool) error {
+	for _, o := range deterministicOptions(det) {
+		if err := s.SetOption(o.name, o.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Solve solves the most recently loaded model.  As with RawModel.Solve, a
+// warning from HiGHS is returned along with the solution.
+func (s *ModelSolver) Solve() (Solution, error) {
+	if s.raw == nil {
+		return Solution{}, errors.New("no model has been loaded")
+	}
+	soln, err := s.raw.Solve()
+	if err != nil && !isWarning(err) {
+		return Solution{}, err
+	}
+	return soln.Solution, err
+}
+
+// Close releases the HiGHS resources associated with the solver.  The solver
+// can still be used after Close by loading a new model.
+func (s *ModelSolver) Close() error {
+	if s.raw == nil {
+		return nil
+	}
+	err := s.raw.Close()
+	s.raw = nil
+	return err
+}
@@ -0,0 +1,93 @@
+//go:build cgo
+
+// This file tests the Solver interface and its HiGHS implementation.
+
+package highs
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// TestModelSolver uses a Solver to solve two models in succession:
+//
+//	Satisfy 1 <= x_0 - x_1 <= 1
+//	        5 <= x_0 + x_1 <= 5
+//
+// and
+//
+//	Satisfy 23 <= x_0 + x_1 <= 23
+//	        17 <= x_0 - x_1 <= 17
+func TestModelSolver(t *testing.T) {
+	// Create a solver and set an option before loading any model.
+	var s Solver = NewModelSolver()
+	defer s.Close()
+	checkErr(t, s.SetOption("time_limit", 60.0))
+	if err := s.SetOption("time_limit", []int{60}); err == nil {
+		t.Fatal("SetOption unexpectedly accepted an option of type []int")
+	}
+	if _, err := s.Solve(); err == nil {
+		t.Fatal("Solve unexpectedly succeeded without a model")
+	}
+
+	// Solve the first model.
+	var m1 Model
+	m1.AddDenseRow(1.0, []float64{1.0, -1.0}, 1.0)
+	m1.AddDenseRow(5.0, []float64{1.0, 1.0}, 5.0)
+	m1.ColLower = []float64{math.Inf(-1), math.Inf(-1)}
+	checkErr(t, s.LoadModel(&m1))
+	soln, err := s.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if soln.Status != Optimal {
+		t.Fatalf("Solve returned %s instead of Optimal", soln.Status)
+	}
+	compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{3.0, 2.0})
+
+	// Solve the second model.
+	var m2 Model
+	m2.AddDenseRow(23.0, []float64{1.0, 1.0}, 23.0)
+	m2.AddDenseRow(17.0, []float64{1.0, -1.0}, 17.0)
+	checkErr(t, s.LoadModel(&m2))
+	soln, err = s.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{20.0, 3.0})
+}
+
+// TestModelSolverSetOption tests that setting an option repeatedly replaces
+// its value rather than accumulating entries.
+func TestModelSolverSetOption(t *testing.T) {
+	s := NewModelSolver()
+	defer s.Close()
+	for _, v := range []float64{60.0, 30.0, 15.0} {
+		checkErr(t, s.SetOption("time_limit", v))
+	}
+	checkErr(t, s.SetOption("output_flag", true))
+	exp := []solverOption{{"output_flag", true}, {"time_limit", 15.0}}
+	if !reflect.DeepEqual(s.opts, exp) {
+		t.Fatalf("expected options %v but saw %v", exp, s.opts)
+	}
+}
+
+// TestModelSolverSetDeterministic tests that SetDeterministic records the
+// options RawModel.SetDeterministic assigns and that switching it off
+// replaces rather than accumulates them.
+func TestModelSolverSetDeterministic(t *testing.T) {
+	s := NewModelSolver()
+	defer s.Close()
+	checkErr(t, s.SetDeterministic(true))
+	checkErr(t, s.SetDeterministic(false))
+	exp := []solverOption{
+		{"output_flag", false},
+		{"threads", 0},
+		{"parallel", "choose"},
+		{"random_seed", 0},
+	}
+	if !reflect.DeepEqual(s.opts, exp) {
+		t.Fatalf("expected options %v but saw %v", exp, s.opts)
+	}
+}
@@ -0,0 +1,118 @@
+// This file provides conversions between the high-level Model and compressed
+// sparse matrix representations.
+
+package highs
+
+import "fmt"
+
+// checkCompressed validates a compressed sparse row or column matrix with n
+// major-axis entries (rows for CSR, columns for CSC) and m minor-axis entries.
+// start may contain either n entries, per HiGHS's convention, or n+1 entries,
+// the last of which must equal len(value), per the convention used by most Go
+// sparse-matrix packages.  checkCompressed returns the start slice extended to
+// n+1 entries.
+func checkCompressed(n, m int, start, index []int, value []float64) ([]int, error) {
+	// Check the slice lengths.
+	if len(index) != len(value) {
+		return nil, fmt.Errorf("index and value must be the same length (%d vs. %d)",
+			len(index), len(value))
+	}
+	switch len(start) {
+	case n:
+		start = append(start[:n:n], len(value))
+	case n + 1:
+		if start[n] != len(value) {
+			return nil, fmt.Errorf("final start entry (%d) does not match the number of nonzeros (%d)",
+				start[n], len(value))
+		}
+	default:
+		return nil, fmt.Errorf("expected %d or %d start entries but saw %d",
+			n, n+1, len(start))
+	}
+
+	// Check the start and index values.
+	if start[0] != 0 {
+		return nil, fmt.Errorf("first start entry must be 0, not %d", start[0])
+	}
+	for i := 0; i < n; i++ {
+		if start[i] < 0 || start[i] > start[i+1] {
+			return nil, fmt.Errorf("start entry %d (%d) is out of order", i, start[i])
+		}
+	}
+	for k, j := range index {
+		if j < 0 || j >= m {
+			return nil, fmt.Errorf("index entry %d (%d) is out of range [0, %d)", k, j, m)
+		}
+	}
+	return start, nil
+}
+
+// NewModelFromCSR returns a Model whose nr × nc constraint matrix is provided
+// in compressed sparse row form.  start may contain either nr entries, as in
+// RawModel.AddCompSparseRows, or nr+1 entries with a final entry of
+// len(value), as produced by most Go sparse-matrix packages.  As with
+// NewModelFromMatrix, the Model's RowLower, RowUpper, and ColCosts are
+// initialized to −∞, +∞, and zero, respectively, to preserve the matrix's
+// dimensions.
+func NewModelFromCSR(nr, nc int, start, index []int, value []float64) (*Model, error) {
+	start, err := checkCompressed(nr, nc, start, index, value)
+	if err != nil {
+		return nil, err
+	}
+	m := newModelWithDims(nr, nc)
+	m.ConstMatrix = make([]Nonzero, 0, len(value))
+	for i := 0; i < nr; i++ {
+		for k := start[i]; k < start[i+1]; k++ {
+			m.ConstMatrix = append(m.ConstMatrix, Nonzero{i, index[k], value[k]})
+		}
+	}
+	return m, nil
+}
+
+// NewModelFromCSC is the compressed sparse column analogue of
+// NewModelFromCSR.  start may contain either nc or nc+1 entries.
+func NewModelFromCSC(nr, nc int, start, index []int, value []float64) (*Model, error) {
+	start, err := checkCompressed(nc, nr, start, index, value)
+	if err != nil {
+		return nil, err
+	}
+	m := newModelWithDims(nr, nc)
+	m.ConstMatrix = make([]Nonzero, 0, len(value))
+	for j := 0; j < nc; j++ {
+		for k := start[j]; k < start[j+1]; k++ {
+			m.ConstMatrix = append(m.ConstMatrix, Nonzero{index[k], j, value[k]})
+		}
+	}
+	return m, nil
+}
+
+// ConstMatrixCSR returns a model's constraint matrix in compressed sparse row
+// form.  start contains one entry per row of the model, following HiGHS's
+// convention, so the result can be passed directly to
+// RawModel.AddCompSparseRows.  Append len(value) to start to obtain the
+// nr+1-entry form used by most Go sparse-matrix packages.
+func (m *Model) ConstMatrixCSR() (start, index []int, value []float64, err error) {
+	start, index, value, err = NonzerosToCSR(m.ConstMatrix, false)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	nr, _ := m.modelSize()
+	return padStarts(start, nr, len(value)), index, value, nil
+}
+
+// ConstMatrixCSC returns a model's constraint matrix in compressed sparse
+// column form.  start contains one entry per column of the model.
+func (m *Model) ConstMatrixCSC() (start, index []int, value []float64, err error) {
+	// Transpose the matrix, convert it to CSR, and interpret the result
+	// as CSC.
+	trans := make([]Nonzero, len(m.ConstMatrix))
+	for i, nz := range m.ConstMatrix {
+		trans[i] = Nonzero{Row: nz.Col, Col: nz.Row, Val: nz.Val}
+	}
+	start, index, value, err = NonzerosToCSR(trans, false)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	_, nc := m.modelSize()
+	return padStarts(start, nc, len(value)), index, value, nil
+}
@@ -0,0 +1,70 @@
+// This file tests conversions between Model and compressed sparse matrices.
+
+package highs
+
+import "testing"
+
+// TestCSRRoundTrip converts a compressed sparse row matrix with an empty row
+// to a Model and back.  The matrix (in MATLAB syntax) is
+// [ 1 0 2 ; 0 0 0 ; 0 3 0 ; 0 0 0 ].
+func TestCSRRoundTrip(t *testing.T) {
+	// Convert the nr+1 form of CSR to a Model.
+	model, err := NewModelFromCSR(4, 3,
+		[]int{0, 2, 2, 3, 3}, []int{0, 2, 1}, []float64{1.0, 2.0, 3.0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(model.RowLower) != 4 || len(model.ColCosts) != 3 {
+		t.Fatalf("incorrect dimensions %d×%d", len(model.RowLower), len(model.ColCosts))
+	}
+
+	// Convert back to CSR.
+	start, index, value, err := model.ConstMatrixCSR()
+	if err != nil {
+		t.Fatal(err)
+	}
+	compSlices(t, "start", start, []int{0, 2, 2, 3})
+	compSlices(t, "index", index, []int{0, 2, 1})
+	compSlices(t, "value", value, []float64{1.0, 2.0, 3.0})
+
+	// Convert to CSC.
+	start, index, value, err = model.ConstMatrixCSC()
+	if err != nil {
+		t.Fatal(err)
+	}
+	compSlices(t, "start", start, []int{0, 1, 2})
+	compSlices(t, "index", index, []int{0, 2, 0})
+	compSlices(t, "value", value, []float64{1.0, 3.0, 2.0})
+
+	// Convert the CSC form back to a Model and compare.
+	model2, err := NewModelFromCSC(4, 3, start, index, value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start, index, value, err = model2.ConstMatrixCSR()
+	if err != nil {
+		t.Fatal(err)
+	}
+	compSlices(t, "start", start, []int{0, 2, 2, 3})
+	compSlices(t, "index", index, []int{0, 2, 1})
+	compSlices(t, "value", value, []float64{1.0, 2.0, 3.0})
+}
+
+// TestCSRBad tests that NewModelFromCSR rejects malformed input.
+func TestCSRBad(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		start []int
+		index []int
+	}{
+		{"short start", []int{0}, []int{0, 1}},
+		{"bad sentinel", []int{0, 1, 3}, []int{0, 1}},
+		{"decreasing start", []int{1, 0}, []int{0, 1}},
+		{"index out of range", []int{0, 1}, []int{0, 2}},
+	} {
+		_, err := NewModelFromCSR(2, 2, tc.start, tc.index, []float64{1.0, 2.0})
+		if err == nil {
+			t.Fatalf("NewModelFromCSR failed to reject %s", tc.name)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// This file gathers the statistics a StatsRecorder records for each solve.
+
+package highs
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+// #include <stdlib.h>
+// #include "highs-externs.h"
+import "C"
+
+// SetStatsRecorder arranges for a record describing each subsequent solve of
+// the model to be written to a StatsRecorder.  A nil StatsRecorder stops
+// recording.  Gathering the statistics entails hashing the model and listing
+// its non-default options, which adds a small amount of time to each solve.
+func (m *RawModel) SetStatsRecorder(r *StatsRecorder) error {
+	_, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+	m.stats = r
+	return nil
+}
+
+// optionDeviations returns the name and value of each option whose value
+// differs from its default or nil if the options cannot be retrieved.  The
+// caller must hold the object's lock.
+func optionDeviations(obj unsafe.Pointer) map[string]string {
+	// Have HiGHS write the options to a throwaway file.
+	var buf bytes.Buffer
+	err := writeViaTempFile(&buf, ".set", func(fn string) error {
+		cFName := C.CString(fn)
+		defer C.free(unsafe.Pointer(cFName))
+		status := C.Highs_writeOptionsDeviations(obj, cFName)
+		err := newCallStatus(status, "Highs_writeOptionsDeviations", "Solve")
+		if err != nil && !isWarning(err) {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil
+	}
+
+	// Parse lines of the form "name = value", ignoring comments.
+	opts := make(map[string]string)
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, value, ok := strings.Cut(line, "=")
+		if ok {
+			opts[strings.TrimSpace(name)] = strings.TrimSpace(value)
+		}
+	}
+	return opts
+}
+
+// solveStats gathers statistics about the solve that just completed.  start
+// is the time at which the solve began, and err is the error it returned, if
+// any.  Statistics that cannot be retrieved are left zero.  The caller must
+// hold the object's lock.
+func solveStats(obj unsafe.Pointer, start time.Time, err error) SolveStats {
+	st := SolveStats{
+		Time:     start,
+		Columns:  int(C.Highs_getNumCol(obj)),
+		Rows:     int(C.Highs_getNumRow(obj)),
+		Nonzeros: int(C.Highs_getNumNz(obj)),
+		Options:  optionDeviations(obj),
+		Status:   convertHighsModelStatus(C.Highs_getModelStatus(obj)),
+		RunTime:  time.Duration(float64(C.Highs_getRunTime(obj)) * float64(time.Second)),
+	}
+	if model, mErr := toModel(obj); mErr == nil {
+		st.ModelHash, _ = model.Hash()
+	}
+	st.Objective, _ = getFloat64Info(obj, "objective_function_value")
+	st.MIPGap, _ = getFloat64Info(obj, "mip_gap")
+	st.SimplexIterations, _ = getIntInfo(obj, "simplex_iteration_count")
+	st.IPMIterations, _ = getIntInfo(obj, "ipm_iteration_count")
+	st.MIPNodes, _ = getInt64Info(obj, "mip_node_count")
+	if err != nil {
+		st.Error = err.Error()
+	}
+	return st
+}
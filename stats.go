@@ -0,0 +1,194 @@
+// This file provides support for recording statistics about each solve.
+
+package highs
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A StatsFormat specifies how a StatsRecorder encodes its records.
+type StatsFormat int
+
+// These are the values a StatsFormat accepts:
+const (
+	StatsJSON StatsFormat = iota // One JSON object per line
+	StatsCSV                     // Comma-separated values, preceded by a header line
+)
+
+// A SolveStats describes a single solve.
+type SolveStats struct {
+	Time              time.Time         // Time at which the solve began
+	ModelHash         string            // Hash of the model's mathematical content
+	Columns           int               // Number of columns
+	Rows              int               // Number of rows
+	Nonzeros          int               // Number of constraint-matrix nonzeros
+	Options           map[string]string // Options whose values differ from HiGHS's defaults
+	Status            ModelStatus       // Model status at the end of the solve
+	Objective         float64           // Objective value
+	MIPGap            float64           // Relative MIP gap
+	RunTime           time.Duration     // Time HiGHS spent solving
+	SimplexIterations int               // Number of simplex iterations
+	IPMIterations     int               // Number of interior-point iterations
+	MIPNodes          int64             // Number of branch-and-bound nodes
+	Error             string            // Error returned by the solve, if any
+}
+
+// statsFields lists the names of a SolveStats's fields as they appear in
+// JSON objects and in the CSV header.
+var statsFields = []string{
+	"time", "model_hash", "columns", "rows", "nonzeros", "options",
+	"status", "objective", "mip_gap", "run_time_seconds",
+	"simplex_iterations", "ipm_iterations", "mip_nodes", "error",
+}
+
+// statsFloat formats a floating-point statistic for CSV output.
+func statsFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// jsonFloat returns a floating-point statistic as a value that can be encoded
+// in JSON, which cannot represent infinities or NaNs.  Such values are
+// encoded as strings.
+func jsonFloat(v float64) any {
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return statsFloat(v)
+	}
+	return v
+}
+
+// values returns a SolveStats's fields in the order of statsFields.
+func (s SolveStats) values() []any {
+	return []any{
+		s.Time.Format(time.RFC3339Nano), s.ModelHash, s.Columns, s.Rows,
+		s.Nonzeros, s.Options, s.Status.String(), s.Objective, s.MIPGap,
+		s.RunTime.Seconds(), s.SimplexIterations, s.IPMIterations,
+		s.MIPNodes, s.Error,
+	}
+}
+
+// MarshalJSON encodes a SolveStats as a JSON object with snake-case keys.
+// Infinite and NaN values are encoded as strings.
+func (s SolveStats) MarshalJSON() ([]byte, error) {
+	// Encode the fields in a fixed order.
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i, v := range s.values() {
+		if f, ok := v.(float64); ok {
+			v = jsonFloat(f)
+		}
+		if m, ok := v.(map[string]string); ok && len(m) == 0 {
+			v = struct{}{}
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		fmt.Fprintf(&sb, "%q:%s", statsFields[i], data)
+	}
+	sb.WriteByte('}')
+	return []byte(sb.String()), nil
+}
+
+// csvRecord returns a SolveStats as a list of CSV fields.  Options are
+// written as semicolon-separated name=value pairs.
+func (s SolveStats) csvRecord() []string {
+	rec := make([]string, 0, len(statsFields))
+	for _, v := range s.values() {
+		switch v := v.(type) {
+		case float64:
+			rec = append(rec, statsFloat(v))
+		case map[string]string:
+			names := make([]string, 0, len(v))
+			for n := range v {
+				names = append(names, n)
+			}
+			sort.Strings(names)
+			opts := make([]string, len(names))
+			for i, n := range names {
+				opts[i] = n + "=" + v[n]
+			}
+			rec = append(rec, strings.Join(opts, ";"))
+		default:
+			rec = append(rec, fmt.Sprint(v))
+		}
+	}
+	return rec
+}
+
+// A StatsRecorder writes one record per solve to an io.Writer, for purposes
+// such as experiment tracking and performance-regression monitoring.  Use
+// RawModel.SetStatsRecorder to record each solve of a model.  A StatsRecorder
+// can be shared by multiple models, including models being solved
+// concurrently.
+type StatsRecorder struct {
+	mu     sync.Mutex
+	w      io.Writer   // Destination of the records
+	format StatsFormat // Encoding of the records
+	header bool        // true=the CSV header has been written
+	err    error       // First error encountered while writing
+}
+
+// NewStatsRecorder returns a StatsRecorder that appends records in a given
+// format to an io.Writer.
+func NewStatsRecorder(w io.Writer, f StatsFormat) *StatsRecorder {
+	return &StatsRecorder{w: w, format: f}
+}
+
+// Record writes a single record.  Record is invoked automatically by the
+// Solve methods of each RawModel with which the StatsRecorder was registered
+// but can also be invoked directly.  It returns an error if the record cannot
+// be written, which is also reported by Err.
+func (r *StatsRecorder) Record(s SolveStats) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var err error
+	switch r.format {
+	case StatsJSON:
+		var data []byte
+		data, err = s.MarshalJSON()
+		if err == nil {
+			_, err = r.w.Write(append(data, '\n'))
+		}
+	case StatsCSV:
+		cw := csv.NewWriter(r.w)
+		if !r.header {
+			err = cw.Write(statsFields)
+			r.header = err == nil
+		}
+		if err == nil {
+			err = cw.Write(s.csvRecord())
+		}
+		if err == nil {
+			cw.Flush()
+			err = cw.Error()
+		}
+	default:
+		err = fmt.Errorf("unrecognized StatsFormat %d", r.format)
+	}
+	if err != nil && r.err == nil {
+		r.err = err
+	}
+	return err
+}
+
+// Err returns the first error encountered while writing a record, or nil if
+// all records were written successfully.  Because records are written during
+// Solve, which does not fail on their account, Err should be checked once
+// recording is complete.
+func (r *StatsRecorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
@@ -0,0 +1,84 @@
+// This file tests the encoding of per-solve statistics.
+
+package highs
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestStatsRecorder tests that a StatsRecorder writes records in each format.
+func TestStatsRecorder(t *testing.T) {
+	st := SolveStats{
+		Time:      time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		ModelHash: "abc123",
+		Columns:   3,
+		Rows:      2,
+		Nonzeros:  4,
+		Options:   map[string]string{"presolve": "off", "mip_rel_gap": "0.01"},
+		Status:    Optimal,
+		Objective: 12.5,
+		MIPGap:    math.Inf(1),
+		RunTime:   1500 * time.Millisecond,
+		MIPNodes:  7,
+	}
+
+	// Write two records as JSON lines, and decode them again.
+	var buf bytes.Buffer
+	rec := NewStatsRecorder(&buf, StatsJSON)
+	checkErr(t, rec.Record(st))
+	checkErr(t, rec.Record(st))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 JSON lines but saw %d", len(lines))
+	}
+	var obj map[string]any
+	checkErr(t, json.Unmarshal([]byte(lines[0]), &obj))
+	for k, v := range map[string]any{
+		"model_hash":       "abc123",
+		"columns":          3.0,
+		"status":           "Optimal",
+		"objective":        12.5,
+		"mip_gap":          "+Inf",
+		"run_time_seconds": 1.5,
+		"mip_nodes":        7.0,
+		"error":            "",
+	} {
+		if obj[k] != v {
+			t.Fatalf("expected %s to be %v but saw %v", k, v, obj[k])
+		}
+	}
+
+	// Write two records as CSV, and ensure the header appears only once.
+	buf.Reset()
+	rec = NewStatsRecorder(&buf, StatsCSV)
+	checkErr(t, rec.Record(st))
+	checkErr(t, rec.Record(st))
+	lines = strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "time,model_hash,") {
+		t.Fatalf("expected a header and 2 CSV records but saw %q", buf.String())
+	}
+	exp := "2024-01-02T03:04:05Z,abc123,3,2,4,mip_rel_gap=0.01;presolve=off,Optimal,12.5,+Inf,1.5,0,0,7,"
+	if lines[1] != exp {
+		t.Fatalf("expected %q but saw %q", exp, lines[1])
+	}
+
+	// Ensure that write errors are retained.
+	rec = NewStatsRecorder(failingWriter{}, StatsJSON)
+	if rec.Record(st) == nil || rec.Err() == nil {
+		t.Fatal("expected a write error to be reported")
+	}
+}
+
+// A failingWriter is an io.Writer that always fails.
+type failingWriter struct{}
+
+// Write returns an error.
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
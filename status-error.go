@@ -0,0 +1,90 @@
+// This file defines an error type that reports the model status of a solve
+// that failed or did not reach optimality.
+
+package highs
+
+import (
+	"errors"
+	"fmt"
+)
+
+// These errors can be passed to errors.Is to test the model status carried
+// by a StatusError.
+var (
+	ErrInfeasible     = errors.New("model is infeasible")
+	ErrUnbounded      = errors.New("model is unbounded")
+	ErrTimeLimit      = errors.New("time limit reached")
+	ErrIterationLimit = errors.New("iteration limit reached")
+	ErrInterrupted    = errors.New("solve was interrupted")
+)
+
+// statusErrors maps each of the preceding errors to the model statuses it
+// matches.
+var statusErrors = map[error][]ModelStatus{
+	ErrInfeasible:     {Infeasible},
+	ErrUnbounded:      {Unbounded},
+	ErrTimeLimit:      {TimeLimit},
+	ErrIterationLimit: {IterationLimit},
+	ErrInterrupted:    {Interrupt},
+}
+
+// A StatusError reports the outcome of a solve that failed or did not reach
+// optimality.  (It is not named SolveError because that name is taken by a
+// ModelStatus.)  The Solve methods return a StatusError when HiGHS reports an
+// error or a warning, in which case the embedded CallStatus describes the
+// failing HiGHS call; Solution.Err returns a StatusError with a zero
+// CallStatus for any non-optimal solution.  errors.Is(err, ErrInfeasible),
+// errors.Is(err, ErrTimeLimit), and the like test the model status.
+type StatusError struct {
+	CallStatus             // Failing HiGHS call, if any
+	Status     ModelStatus // Model status at the end of the solve
+	LogTail    []string    // Final lines HiGHS logged during the solve, if any
+}
+
+// Error returns a StatusError as a string.
+func (e *StatusError) Error() string {
+	if e.CName == "" {
+		return fmt.Sprintf("solve ended with model status %s", e.Status)
+	}
+	return fmt.Sprintf("%s (model status %s)", e.CallStatus.Error(), e.Status)
+}
+
+// Unwrap returns the embedded CallStatus, if any.
+func (e *StatusError) Unwrap() error {
+	if e.CName == "" {
+		return nil
+	}
+	return e.CallStatus
+}
+
+// Is returns true if target is one of ErrInfeasible, ErrUnbounded,
+// ErrTimeLimit, ErrIterationLimit, or ErrInterrupted and corresponds to the
+// StatusError's model status.
+func (e *StatusError) Is(target error) bool {
+	for _, ms := range statusErrors[target] {
+		if e.Status == ms {
+			return true
+		}
+	}
+	return false
+}
+
+// Err returns nil if the solution is optimal or the model was empty and
+// otherwise a *StatusError carrying the solution's Status.  Because a solve
+// that ends with a status such as Infeasible or TimeLimit is not itself
+// considered a failure, Solve does not return an error in those cases; Err
+// provides an idiomatic way to treat them as errors:
+//
+//	soln, err := model.Solve()
+//	if err == nil {
+//		err = soln.Err()
+//	}
+//	if errors.Is(err, highs.ErrInfeasible) {
+//		...
+//	}
+func (s *Solution) Err() error {
+	if s.Status == Optimal || s.Status == ModelEmpty {
+		return nil
+	}
+	return &StatusError{Status: s.Status}
+}
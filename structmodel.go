@@ -0,0 +1,173 @@
+// This file provides support for building models whose variables are the
+// fields of a Go struct.
+
+package highs
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// A StructModel is a Model whose columns correspond to the exported fields of
+// a struct type T, in declaration order.  Each field's bounds, cost, and
+// integrality are specified by a struct tag of the form
+//
+//	`highs:"lb=0,ub=10,cost=2.5,int"`
+//
+// All keys are optional.  lb and ub default to −∞ and +∞, as in Model, and
+// cost defaults to 0.  The value of lb, ub, or cost may be "inf" or "-inf".
+// Fields of type float32 or float64 are continuous unless marked "int",
+// fields of any integer type are integer, and fields of type bool are
+// integer with bounds [0, 1] unless overridden.  Fields tagged `highs:"-"`
+// and fields of other types are not modeled.
+//
+// Constraints refer to columns by field name, which keeps small models
+// readable:
+//
+//	type Diet struct {
+//		Bread float64 `highs:"lb=0,cost=2"`
+//		Milk  float64 `highs:"lb=0,cost=3.5"`
+//	}
+//	sm, err := NewStructModel[Diet]()
+//	err = sm.AddConstraint(4, map[string]float64{"Bread": 1, "Milk": 2}, math.Inf(1))
+//	soln, err := sm.Solve()
+//	diet, err := sm.Values(soln.ColumnPrimal)
+type StructModel[T any] struct {
+	Model                 // Model being built
+	fields []int          // Index into T of the field represented by each column
+	colIdx map[string]int // Map from field name to column index
+}
+
+// NewStructModel returns a StructModel with one column per modeled field of
+// T and no rows.  It returns an error if T is not a struct type or if a field
+// has a malformed highs tag.
+func NewStructModel[T any]() (*StructModel[T], error) {
+	// Ensure that T is a struct.
+	var zero T
+	st := reflect.TypeOf(zero)
+	if st == nil || st.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%v is not a struct type", st)
+	}
+
+	// Add a column for each modeled field.
+	sm := &StructModel[T]{colIdx: make(map[string]int)}
+	m := &sm.Model
+	for i := 0; i < st.NumField(); i++ {
+		// Determine whether to model the field.
+		f := st.Field(i)
+		tag := f.Tag.Get("highs")
+		if !f.IsExported() || tag == "-" {
+			continue
+		}
+		lb, ub, vt := math.Inf(-1), math.Inf(1), ContinuousType
+		switch f.Type.Kind() {
+		case reflect.Float32, reflect.Float64:
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			vt = IntegerType
+		case reflect.Bool:
+			lb, ub, vt = 0.0, 1.0, IntegerType
+		default:
+			continue
+		}
+
+		// Parse the field's tag.
+		cost := 0.0
+		for _, kv := range strings.Split(tag, ",") {
+			kv = strings.TrimSpace(kv)
+			if kv == "" {
+				continue
+			}
+			if kv == "int" {
+				vt = IntegerType
+				continue
+			}
+			k, v, ok := strings.Cut(kv, "=")
+			if !ok {
+				return nil, fmt.Errorf("field %s: %q is not a valid highs tag element", f.Name, kv)
+			}
+			x, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %q is not a valid number", f.Name, v)
+			}
+			switch strings.TrimSpace(k) {
+			case "lb":
+				lb = x
+			case "ub":
+				ub = x
+			case "cost":
+				cost = x
+			default:
+				return nil, fmt.Errorf("field %s: %q is not a valid highs tag key", f.Name, k)
+			}
+		}
+
+		// Add the column.
+		sm.colIdx[f.Name] = len(sm.fields)
+		sm.fields = append(sm.fields, i)
+		m.ColCosts = append(m.ColCosts, cost)
+		m.ColLower = append(m.ColLower, lb)
+		m.ColUpper = append(m.ColUpper, ub)
+		m.VarTypes = append(m.VarTypes, vt)
+		m.ColNames = append(m.ColNames, f.Name)
+	}
+	return sm, nil
+}
+
+// Column returns the column index of the named field or an error if the
+// field is not modeled.
+func (sm *StructModel[T]) Column(field string) (int, error) {
+	c, ok := sm.colIdx[field]
+	if !ok {
+		var zero T
+		return 0, fmt.Errorf("%T has no modeled field named %q", zero, field)
+	}
+	return c, nil
+}
+
+// AddConstraint adds a row lb ≤ Σ coeffs[f]·f ≤ ub, where f ranges over
+// field names.  It returns an error, and leaves the model unmodified, if any
+// name does not refer to a modeled field.
+func (sm *StructModel[T]) AddConstraint(lb float64, coeffs map[string]float64, ub float64) error {
+	dense := make([]float64, len(sm.fields))
+	for f, v := range coeffs {
+		c, err := sm.Column(f)
+		if err != nil {
+			return err
+		}
+		dense[c] = v
+	}
+	sm.AddDenseRow(lb, dense, ub)
+	return nil
+}
+
+// Values returns a T whose modeled fields are assigned from a vector of
+// column values, such as Solution.ColumnPrimal.  Integer fields receive the
+// nearest integer, and bool fields are true if their value is at least ½.
+// Fields that are not modeled are left zero.
+func (sm *StructModel[T]) Values(colValues []float64) (T, error) {
+	var t T
+	if len(colValues) != len(sm.fields) {
+		return t, fmt.Errorf("expected %d column values but received %d",
+			len(sm.fields), len(colValues))
+	}
+	sv := reflect.ValueOf(&t).Elem()
+	for c, i := range sm.fields {
+		fv := sv.Field(i)
+		x := colValues[c]
+		switch fv.Kind() {
+		case reflect.Float32, reflect.Float64:
+			fv.SetFloat(x)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			fv.SetInt(int64(math.Round(x)))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			fv.SetUint(uint64(math.Max(math.Round(x), 0.0)))
+		case reflect.Bool:
+			fv.SetBool(x >= 0.5)
+		}
+	}
+	return t, nil
+}
@@ -0,0 +1,65 @@
+// This file tests building models from struct types.
+
+package highs
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// TestStructModel builds a model from the following struct type:
+//
+//	Min.  2*Bread + 3.5*Milk + 4*Cheese
+//	s.t.  Bread + 2*Milk + Cheese >= 4
+//	with  0 <= Bread <= 10, Milk >= 0 integer, Cheese binary
+func TestStructModel(t *testing.T) {
+	type diet struct {
+		Bread  float64 `highs:"lb=0, ub=10, cost=2"`
+		Milk   int     `highs:"lb=0,cost=3.5"`
+		Cheese bool    `highs:"cost=4"`
+		Notes  string
+		Extra  float64 `highs:"-"`
+		hidden float64
+	}
+
+	// Build the model.
+	sm, err := NewStructModel[diet]()
+	checkErr(t, err)
+	err = sm.AddConstraint(4.0, map[string]float64{"Bread": 1.0, "Milk": 2.0, "Cheese": 1.0}, math.Inf(1))
+	checkErr(t, err)
+	compSlices(t, "ColCosts", sm.ColCosts, []float64{2.0, 3.5, 4.0})
+	compSlices(t, "ColLower", sm.ColLower, []float64{0.0, 0.0, 0.0})
+	compSlices(t, "ColUpper", sm.ColUpper, []float64{10.0, math.Inf(1), 1.0})
+	compSlices(t, "VarTypes", sm.VarTypes, []VariableType{ContinuousType, IntegerType, IntegerType})
+	if !reflect.DeepEqual(sm.ColNames, []string{"Bread", "Milk", "Cheese"}) {
+		t.Fatalf("unexpected column names %v", sm.ColNames)
+	}
+	compSlices(t, "RowLower", sm.RowLower, []float64{4.0})
+	if len(sm.ConstMatrix) != 3 {
+		t.Fatalf("expected 3 nonzeros but saw %v", sm.ConstMatrix)
+	}
+
+	// Ensure that unknown fields are rejected.
+	if err = sm.AddConstraint(0.0, map[string]float64{"Extra": 1.0}, 1.0); err == nil {
+		t.Fatal("expected an error for an unmodeled field")
+	}
+
+	// Convert column values back to a struct.
+	d, err := sm.Values([]float64{1.5, 0.9999999, 1.0})
+	checkErr(t, err)
+	if d.Bread != 1.5 || d.Milk != 1 || !d.Cheese {
+		t.Fatalf("unexpected struct values %+v", d)
+	}
+
+	// Ensure that malformed tags and non-struct types are rejected.
+	type bad struct {
+		X float64 `highs:"lb=zero"`
+	}
+	if _, err = NewStructModel[bad](); err == nil {
+		t.Fatal("expected an error for a malformed tag")
+	}
+	if _, err = NewStructModel[float64](); err == nil {
+		t.Fatal("expected an error for a non-struct type")
+	}
+}
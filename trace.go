@@ -0,0 +1,62 @@
+// This file provides hooks for tracing the highs package's more expensive
+// operations with an external tracing system such as OpenTelemetry.
+
+package highs
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// A TraceAttr is a key/value pair that describes a traced operation.
+type TraceAttr struct {
+	Key   string
+	Value any // bool, int, float64, or string
+}
+
+// A TraceFunc is invoked at the start of each traced operation with the
+// operation's name (e.g., "Solve") and a set of attributes describing the
+// operation.  It returns a function that is invoked when the operation
+// completes, with the operation's error, if any, and additional attributes.
+//
+// The traced operations are Model.ToRawModel, RawModel.ReadModel,
+// RawModel.ReadModelFormat, RawModel.ReadModelFromFile,
+// RawModel.ReadModelFromFileFormat, RawModel.Solve, and RawModel.SolveContext.
+// ctx is the context passed to SolveContext or context.Background for the
+// other operations.
+type TraceFunc func(ctx context.Context, op string, attrs []TraceAttr) (end func(err error, attrs []TraceAttr))
+
+// traceFunc is the current TraceFunc or nil if tracing is disabled.
+var traceFunc atomic.Pointer[TraceFunc]
+
+// SetTraceFunc installs a TraceFunc for all subsequent operations, replacing
+// any previous TraceFunc.  A nil TraceFunc disables tracing.  The otelhighs
+// subpackage provides a TraceFunc that creates OpenTelemetry spans.
+func SetTraceFunc(fn TraceFunc) {
+	if fn == nil {
+		traceFunc.Store(nil)
+		return
+	}
+	traceFunc.Store(&fn)
+}
+
+// startTrace begins tracing an operation and returns a function that ends the
+// trace.  If tracing is disabled, the returned function does nothing.
+func startTrace(ctx context.Context, op string, attrs ...TraceAttr) func(err error, attrs ...TraceAttr) {
+	p := traceFunc.Load()
+	if p == nil {
+		return func(error, ...TraceAttr) {}
+	}
+	end := (*p)(ctx, op, attrs)
+	return func(err error, attrs ...TraceAttr) {
+		if end != nil {
+			end(err, attrs)
+		}
+	}
+}
+
+// tracing reports whether a TraceFunc is installed.  It lets callers skip
+// computing attributes that would be discarded.
+func tracing() bool {
+	return traceFunc.Load() != nil
+}
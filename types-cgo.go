@@ -0,0 +1,89 @@
+// This file provides conversions between the types defined in types.go and
+// their HiGHS equivalents.
+
+package highs
+
+// #include "highs-externs.h"
+import "C"
+
+// convertHighsBasisStatus converts a kHighsBasisStatus to a BasisStatus.
+func convertHighsBasisStatus(hbs C.HighsInt) BasisStatus {
+	switch hbs {
+	case C.kHighsBasisStatusLower:
+		return Lower
+	case C.kHighsBasisStatusBasic:
+		return Basic
+	case C.kHighsBasisStatusUpper:
+		return Upper
+	case C.kHighsBasisStatusZero:
+		return Zero
+	case C.kHighsBasisStatusNonbasic:
+		return NonBasic
+	default:
+		return UnknownBasisStatus
+	}
+}
+
+// basisStatusToHighs maps a BasisStatus to a kHighsBasisStatus.  This slice
+// must be kept up to date with the BasisStatus constants.  UnknownBasisStatus
+// has no HiGHS equivalent and maps to -1.
+var basisStatusToHighs = []C.HighsInt{
+	-1,
+	C.kHighsBasisStatusLower,
+	C.kHighsBasisStatusBasic,
+	C.kHighsBasisStatusUpper,
+	C.kHighsBasisStatusZero,
+	C.kHighsBasisStatusNonbasic,
+}
+
+// convertHighsModelStatus converts a kHighsModelStatus to a ModelStatus.
+func convertHighsModelStatus(hms C.HighsInt) ModelStatus {
+	switch hms {
+	case C.kHighsModelStatusNotset:
+		return NotSet
+	case C.kHighsModelStatusLoadError:
+		return LoadError
+	case C.kHighsModelStatusModelError:
+		return ModelError
+	case C.kHighsModelStatusPresolveError:
+		return PresolveError
+	case C.kHighsModelStatusSolveError:
+		return SolveError
+	case C.kHighsModelStatusPostsolveError:
+		return PostsolveError
+	case C.kHighsModelStatusModelEmpty:
+		return ModelEmpty
+	case C.kHighsModelStatusOptimal:
+		return Optimal
+	case C.kHighsModelStatusInfeasible:
+		return Infeasible
+	case C.kHighsModelStatusUnboundedOrInfeasible:
+		return UnboundedOrInfeasible
+	case C.kHighsModelStatusUnbounded:
+		return Unbounded
+	case C.kHighsModelStatusObjectiveBound:
+		return ObjectiveBound
+	case C.kHighsModelStatusObjectiveTarget:
+		return ObjectiveTarget
+	case C.kHighsModelStatusTimeLimit:
+		return TimeLimit
+	case C.kHighsModelStatusIterationLimit:
+		return IterationLimit
+	case C.kHighsModelStatusSolutionLimit:
+		return SolutionLimit
+	case C.kHighsModelStatusInterrupt:
+		return Interrupt
+	default:
+		return UnknownModelStatus
+	}
+}
+
+// variableTypeToHighs maps a VariableType to a kHighsVarType.  This slice must
+// be kept up to date with the VariableType constants.
+var variableTypeToHighs = []C.HighsInt{
+	C.kHighsVarTypeContinuous,
+	C.kHighsVarTypeInteger,
+	C.kHighsVarTypeSemiContinuous,
+	C.kHighsVarTypeSemiInteger,
+	C.kHighsVarTypeImplicitInteger,
+}
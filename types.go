@@ -2,8 +2,12 @@
 
 package highs
 
-// #include "highs-externs.h"
-import "C"
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
 
 // A Nonzero represents a nonzero entry in a sparse matrix.  Rows and columns
 // are indexed from zero.
@@ -26,26 +30,15 @@ const (
 	NonBasic
 )
 
-// convertHighsBasisStatus converts a kHighsBasisStatus to a BasisStatus.
-func convertHighsBasisStatus(hbs C.HighsInt) BasisStatus {
-	switch hbs {
-	case C.kHighsBasisStatusLower:
-		return Lower
-	case C.kHighsBasisStatusBasic:
-		return Basic
-	case C.kHighsBasisStatusUpper:
-		return Upper
-	case C.kHighsBasisStatusZero:
-		return Zero
-	case C.kHighsBasisStatusNonbasic:
-		return NonBasic
-	default:
-		return UnknownBasisStatus
-	}
-}
-
 //go:generate stringer -type=BasisStatus
 
+// A BasicVariable identifies the column or row (more precisely, the row's
+// slack variable) that occupies one position of a basis.
+type BasicVariable struct {
+	Index int  // Column or row index
+	Row   bool // true=Index is a row index; false=Index is a column index
+}
+
 // A ModelStatus represents the status of an attempt to solve a model.
 type ModelStatus int
 
@@ -67,47 +60,52 @@ const (
 	ObjectiveTarget
 	TimeLimit
 	IterationLimit
+	SolutionLimit
+	Interrupt
 )
 
-// convertHighsModelStatus converts a kHighsModelStatus to a ModelStatus.
-func convertHighsModelStatus(hms C.HighsInt) ModelStatus {
-	switch hms {
-	case C.kHighsModelStatusNotset:
-		return NotSet
-	case C.kHighsModelStatusLoadError:
-		return LoadError
-	case C.kHighsModelStatusModelError:
-		return ModelError
-	case C.kHighsModelStatusPresolveError:
-		return PresolveError
-	case C.kHighsModelStatusSolveError:
-		return SolveError
-	case C.kHighsModelStatusPostsolveError:
-		return PostsolveError
-	case C.kHighsModelStatusModelEmpty:
-		return ModelEmpty
-	case C.kHighsModelStatusOptimal:
-		return Optimal
-	case C.kHighsModelStatusInfeasible:
-		return Infeasible
-	case C.kHighsModelStatusUnboundedOrInfeasible:
-		return UnboundedOrInfeasible
-	case C.kHighsModelStatusUnbounded:
-		return Unbounded
-	case C.kHighsModelStatusObjectiveBound:
-		return ObjectiveBound
-	case C.kHighsModelStatusObjectiveTarget:
-		return ObjectiveTarget
-	case C.kHighsModelStatusTimeLimit:
-		return TimeLimit
-	case C.kHighsModelStatusIterationLimit:
-		return IterationLimit
+//go:generate stringer -type=ModelStatus
+
+// IsOptimal returns true if the solver found an optimal solution.
+func (ms ModelStatus) IsOptimal() bool {
+	return ms == Optimal
+}
+
+// IsFeasible returns true if the status guarantees that the solver found a
+// feasible (though not necessarily optimal) solution.  A solve that stopped
+// at a time or iteration limit or that was interrupted may also have found a
+// feasible solution, but the status alone does not indicate whether it did.
+func (ms ModelStatus) IsFeasible() bool {
+	switch ms {
+	case Optimal, ObjectiveTarget, SolutionLimit:
+		return true
 	default:
-		return UnknownModelStatus
+		return false
 	}
 }
 
-//go:generate stringer -type=ModelStatus
+// IsLimit returns true if the solver stopped because it reached a
+// user-specified limit or was interrupted by the user.  The best solution
+// found before stopping, if any, is still available.
+func (ms ModelStatus) IsLimit() bool {
+	switch ms {
+	case ObjectiveBound, ObjectiveTarget, TimeLimit, IterationLimit, SolutionLimit, Interrupt:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsError returns true if the solver failed to load, presolve, solve, or
+// postsolve the model.
+func (ms ModelStatus) IsError() bool {
+	switch ms {
+	case LoadError, ModelError, PresolveError, SolveError, PostsolveError:
+		return true
+	default:
+		return false
+	}
+}
 
 // A VariableType indicates the type of a model variable.
 type VariableType int
@@ -121,14 +119,178 @@ const (
 	ImplicitIntegerType
 )
 
-// variableTypeToHighs maps a VariableType to a kHighsVarType.  This slice must
-// be kept up to date with the VariableType constants.
-var variableTypeToHighs = []C.HighsInt{
-	C.kHighsVarTypeContinuous,
-	C.kHighsVarTypeInteger,
-	C.kHighsVarTypeSemiContinuous,
-	C.kHighsVarTypeSemiInteger,
-	C.kHighsVarTypeImplicitInteger,
+//go:generate stringer -type=VariableType
+
+// A ModelFormat specifies the file format in which a model is read or
+// written.
+type ModelFormat int
+
+// These are the values a ModelFormat accepts:
+const (
+	MPSFormat ModelFormat = iota // Mathematical Programming System (free format)
+	LPFormat                     // CPLEX LP format
+	EMSFormat                    // HiGHS's own "ems" format
+)
+
+//go:generate stringer -type=ModelFormat
+
+// modelFormatExts maps each ModelFormat to the filename extension HiGHS uses
+// to recognize it.  This slice must be kept up to date with the ModelFormat
+// constants.
+var modelFormatExts = []string{".mps", ".lp", ".ems"}
+
+// extension returns the filename extension HiGHS associates with a
+// ModelFormat or an error if the ModelFormat is invalid.
+func (f ModelFormat) extension() (string, error) {
+	if f < 0 || int(f) >= len(modelFormatExts) {
+		return "", fmt.Errorf("unrecognized model format %d", int(f))
+	}
+	return modelFormatExts[f], nil
 }
 
-//go:generate stringer -type=VariableType
+// parseEnum is a helper function for ParseBasisStatus, ParseModelStatus, and
+// ParseVariableType.  It returns the value among the first n values of an
+// enumerated type whose String method returns a given string, ignoring case.
+func parseEnum[T interface {
+	~int
+	fmt.Stringer
+}](s string, n int) (T, error) {
+	for i := 0; i < n; i++ {
+		if v := T(i); strings.EqualFold(v.String(), s) {
+			return v, nil
+		}
+	}
+	var v T
+	return v, fmt.Errorf("unrecognized %T %q", v, s)
+}
+
+// marshalEnum is a helper function for the MarshalText methods of the
+// enumerated types.  It returns the String method's output for each of the
+// first n values of an enumerated type and an error for any other value.
+func marshalEnum[T interface {
+	~int
+	fmt.Stringer
+}](v T, n int) ([]byte, error) {
+	if v < 0 || int(v) >= n {
+		return nil, fmt.Errorf("invalid %T %d", v, int(v))
+	}
+	return []byte(v.String()), nil
+}
+
+// unmarshalEnumJSON is a helper function for the UnmarshalJSON methods of the
+// enumerated types.  It accepts either a JSON string, which is parsed like
+// UnmarshalText, or, for compatibility with data written before the types
+// were marshaled as text, a JSON number in the range [0, n).  A JSON null
+// leaves the value unmodified.
+func unmarshalEnumJSON[T interface {
+	~int
+	fmt.Stringer
+}](data []byte, v *T, n int) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		return nil
+	case len(data) > 0 && data[0] == '"':
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		x, err := parseEnum[T](s, n)
+		if err != nil {
+			return err
+		}
+		*v = x
+		return nil
+	default:
+		var i int
+		if err := json.Unmarshal(data, &i); err != nil {
+			return fmt.Errorf("invalid %T %s", *v, data)
+		}
+		if i < 0 || i >= n {
+			return fmt.Errorf("invalid %T %d", *v, i)
+		}
+		*v = T(i)
+		return nil
+	}
+}
+
+// ParseBasisStatus returns the BasisStatus whose String method returns a
+// given string, ignoring case.
+func ParseBasisStatus(s string) (BasisStatus, error) {
+	return parseEnum[BasisStatus](s, len(_BasisStatus_index)-1)
+}
+
+// MarshalText implements encoding.TextMarshaler for BasisStatus.
+func (bs BasisStatus) MarshalText() ([]byte, error) {
+	return marshalEnum(bs, len(_BasisStatus_index)-1)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for BasisStatus.
+func (bs *BasisStatus) UnmarshalText(text []byte) error {
+	v, err := ParseBasisStatus(string(text))
+	if err != nil {
+		return err
+	}
+	*bs = v
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler for BasisStatus.  It accepts both the
+// textual form produced by MarshalText and the underlying integer.
+func (bs *BasisStatus) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, bs, len(_BasisStatus_index)-1)
+}
+
+// ParseModelStatus returns the ModelStatus whose String method returns a
+// given string, ignoring case.
+func ParseModelStatus(s string) (ModelStatus, error) {
+	return parseEnum[ModelStatus](s, len(_ModelStatus_index)-1)
+}
+
+// MarshalText implements encoding.TextMarshaler for ModelStatus.
+func (ms ModelStatus) MarshalText() ([]byte, error) {
+	return marshalEnum(ms, len(_ModelStatus_index)-1)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for ModelStatus.
+func (ms *ModelStatus) UnmarshalText(text []byte) error {
+	v, err := ParseModelStatus(string(text))
+	if err != nil {
+		return err
+	}
+	*ms = v
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler for ModelStatus.  It accepts both the
+// textual form produced by MarshalText and the underlying integer.
+func (ms *ModelStatus) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, ms, len(_ModelStatus_index)-1)
+}
+
+// ParseVariableType returns the VariableType whose String method returns a
+// given string, ignoring case.
+func ParseVariableType(s string) (VariableType, error) {
+	return parseEnum[VariableType](s, len(_VariableType_index)-1)
+}
+
+// MarshalText implements encoding.TextMarshaler for VariableType.
+func (vt VariableType) MarshalText() ([]byte, error) {
+	return marshalEnum(vt, len(_VariableType_index)-1)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for VariableType.
+func (vt *VariableType) UnmarshalText(text []byte) error {
+	v, err := ParseVariableType(string(text))
+	if err != nil {
+		return err
+	}
+	*vt = v
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler for VariableType.  It accepts both the
+// textual form produced by MarshalText and the underlying integer.
+func (vt *VariableType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, vt, len(_VariableType_index)-1)
+}
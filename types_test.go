@@ -0,0 +1,201 @@
+// This file tests the methods defined on the highs package's enumerated types.
+
+package highs
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestModelStatusPredicates tests that each ModelStatus is classified
+// correctly by IsOptimal, IsFeasible, IsLimit, and IsError.
+func TestModelStatusPredicates(t *testing.T) {
+	type class struct{ optimal, feasible, limit, error bool }
+	for ms, exp := range map[ModelStatus]class{
+		UnknownModelStatus:    {},
+		NotSet:                {},
+		LoadError:             {error: true},
+		ModelError:            {error: true},
+		PresolveError:         {error: true},
+		SolveError:            {error: true},
+		PostsolveError:        {error: true},
+		ModelEmpty:            {},
+		Optimal:               {optimal: true, feasible: true},
+		Infeasible:            {},
+		UnboundedOrInfeasible: {},
+		Unbounded:             {},
+		ObjectiveBound:        {limit: true},
+		ObjectiveTarget:       {feasible: true, limit: true},
+		TimeLimit:             {limit: true},
+		IterationLimit:        {limit: true},
+		SolutionLimit:         {feasible: true, limit: true},
+		Interrupt:             {limit: true},
+	} {
+		act := class{ms.IsOptimal(), ms.IsFeasible(), ms.IsLimit(), ms.IsError()}
+		if act != exp {
+			t.Fatalf("%s: expected %+v but observed %+v", ms, exp, act)
+		}
+	}
+}
+
+// TestParseEnums tests that ParseBasisStatus, ParseModelStatus, and
+// ParseVariableType invert the corresponding String methods.
+func TestParseEnums(t *testing.T) {
+	for bs := UnknownBasisStatus; bs <= NonBasic; bs++ {
+		v, err := ParseBasisStatus(bs.String())
+		if err != nil || v != bs {
+			t.Fatalf("ParseBasisStatus(%q) returned %v, %v", bs.String(), v, err)
+		}
+	}
+	for ms := UnknownModelStatus; ms <= Interrupt; ms++ {
+		v, err := ParseModelStatus(ms.String())
+		if err != nil || v != ms {
+			t.Fatalf("ParseModelStatus(%q) returned %v, %v", ms.String(), v, err)
+		}
+	}
+	for vt := ContinuousType; vt <= ImplicitIntegerType; vt++ {
+		v, err := ParseVariableType(vt.String())
+		if err != nil || v != vt {
+			t.Fatalf("ParseVariableType(%q) returned %v, %v", vt.String(), v, err)
+		}
+	}
+
+	// Case is ignored, but unknown names are rejected.
+	if v, err := ParseModelStatus("optimal"); err != nil || v != Optimal {
+		t.Fatalf("ParseModelStatus(%q) returned %v, %v", "optimal", v, err)
+	}
+	if _, err := ParseVariableType("Binary"); err == nil {
+		t.Fatal("ParseVariableType accepted an unknown name")
+	}
+	if _, err := ParseBasisStatus("BasisStatus(9)"); err == nil {
+		t.Fatal("ParseBasisStatus accepted an invalid value")
+	}
+}
+
+// TestEnumText tests that the enumerated types round-trip through their
+// MarshalText and UnmarshalText methods and that invalid values are rejected.
+func TestEnumText(t *testing.T) {
+	// Round-trip a value of each type.
+	text, err := SemiIntegerType.MarshalText()
+	checkErr(t, err)
+	if string(text) != "SemiIntegerType" {
+		t.Fatalf("expected %q but saw %q", "SemiIntegerType", text)
+	}
+	var vt VariableType
+	checkErr(t, vt.UnmarshalText(text))
+	if vt != SemiIntegerType {
+		t.Fatalf("expected %s but saw %s", SemiIntegerType, vt)
+	}
+	var bs BasisStatus
+	checkErr(t, bs.UnmarshalText([]byte("Basic")))
+	if bs != Basic {
+		t.Fatalf("expected %s but saw %s", Basic, bs)
+	}
+	var ms ModelStatus
+	checkErr(t, ms.UnmarshalText([]byte("TimeLimit")))
+	if ms != TimeLimit {
+		t.Fatalf("expected %s but saw %s", TimeLimit, ms)
+	}
+
+	// Ensure that invalid values are rejected.
+	if _, err = ModelStatus(-1).MarshalText(); err == nil {
+		t.Fatal("MarshalText accepted an invalid ModelStatus")
+	}
+	if err = ms.UnmarshalText([]byte("Done")); err == nil {
+		t.Fatal("UnmarshalText accepted an invalid ModelStatus")
+	}
+}
+
+// TestSolutionJSON tests that a Solution's enumerated fields are encoded in
+// JSON as strings and that both strings and integers are decoded.
+func TestSolutionJSON(t *testing.T) {
+	// Encode a solution.
+	soln := Solution{
+		Status:      Optimal,
+		ColumnBasis: []BasisStatus{Basic, Lower},
+		RowBasis:    []BasisStatus{Upper},
+	}
+	data, err := json.Marshal(soln)
+	checkErr(t, err)
+	for _, s := range []string{`"Status":"Optimal"`, `"ColumnBasis":["Basic","Lower"]`, `"RowBasis":["Upper"]`} {
+		if !strings.Contains(string(data), s) {
+			t.Fatalf("expected %s to contain %s", data, s)
+		}
+	}
+
+	// Decode the solution.
+	var soln2 Solution
+	checkErr(t, json.Unmarshal(data, &soln2))
+	if !reflect.DeepEqual(soln, soln2) {
+		t.Fatalf("expected %+v but saw %+v", soln, soln2)
+	}
+
+	// Decode a model and solution that use integers.
+	var model Model
+	checkErr(t, json.Unmarshal([]byte(`{"VarTypes":[0,1,"SemiIntegerType"]}`), &model))
+	exp := []VariableType{ContinuousType, IntegerType, SemiIntegerType}
+	if !reflect.DeepEqual(model.VarTypes, exp) {
+		t.Fatalf("expected %v but saw %v", exp, model.VarTypes)
+	}
+	checkErr(t, json.Unmarshal([]byte(`{"Status":8,"ColumnBasis":[2,"lower"]}`), &soln2))
+	if soln2.Status != Optimal || !reflect.DeepEqual(soln2.ColumnBasis, []BasisStatus{Basic, Lower}) {
+		t.Fatalf("unexpected solution %+v", soln2)
+	}
+
+	// Ensure that invalid values are rejected.
+	for _, s := range []string{`{"Status":99}`, `{"Status":"Finished"}`, `{"Status":1.5}`, `{"Status":true}`} {
+		if json.Unmarshal([]byte(s), &soln2) == nil {
+			t.Fatalf("accepted invalid solution %s", s)
+		}
+	}
+}
+
+// TestStatusError tests that a StatusError matches the errors corresponding
+// to its model status and exposes its embedded CallStatus.
+func TestStatusError(t *testing.T) {
+	// Check the errors reported for solutions with various statuses.
+	soln := Solution{Status: Optimal}
+	if err := soln.Err(); err != nil {
+		t.Fatalf("expected no error for an optimal solution but saw %v", err)
+	}
+	soln.Status = Infeasible
+	err := soln.Err()
+	if !errors.Is(err, ErrInfeasible) || errors.Is(err, ErrTimeLimit) {
+		t.Fatalf("expected %v to match only ErrInfeasible", err)
+	}
+	soln.Status = TimeLimit
+	err = soln.Err()
+	if !errors.Is(err, ErrTimeLimit) || errors.Is(err, ErrInfeasible) {
+		t.Fatalf("expected %v to match only ErrTimeLimit", err)
+	}
+	var cs CallStatus
+	if errors.As(err, &cs) {
+		t.Fatalf("expected %v not to contain a CallStatus", err)
+	}
+
+	// Check a StatusError that wraps a warning from HiGHS.
+	err = &StatusError{
+		CallStatus: CallStatus{Status: statusWarning, CName: "Highs_run", GoName: "Solve"},
+		Status:     Unbounded,
+	}
+	if !errors.Is(err, ErrUnbounded) {
+		t.Fatalf("expected %v to match ErrUnbounded", err)
+	}
+	if !errors.As(err, &cs) || !cs.IsWarning() || cs.CName != "Highs_run" {
+		t.Fatalf("expected %v to contain a warning from Highs_run", err)
+	}
+	exp := "Solve completed with a warning (model status Unbounded)"
+	if err.Error() != exp {
+		t.Fatalf("expected %q but saw %q", exp, err.Error())
+	}
+
+	// Check that renaming the failing call preserves the StatusError.
+	err = renameCallStatus(err, "SolveWithSignals")
+	var se *StatusError
+	if !errors.As(err, &se) || se.GoName != "SolveWithSignals" || se.Status != Unbounded {
+		t.Fatalf("expected %v to be a StatusError from SolveWithSignals", err)
+	}
+}
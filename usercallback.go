@@ -0,0 +1,142 @@
+// This file lets applications register a Go function to receive the events
+// HiGHS reports through its callback mechanism.
+
+package highs
+
+import (
+	"fmt"
+	"time"
+	"unsafe"
+)
+
+// #include "highs-externs.h"
+import "C"
+
+// A CallbackType identifies an event that HiGHS reports to a function
+// registered with SetCallback.
+type CallbackType int
+
+// These are the values a CallbackType accepts:
+const (
+	CallbackLogging              CallbackType = iota // HiGHS logged a message
+	CallbackSimplexInterrupt                         // The simplex solver offers to stop
+	CallbackIPMInterrupt                             // The interior-point solver offers to stop
+	CallbackMIPSolution                              // The MIP solver found a feasible solution
+	CallbackMIPImprovingSolution                     // The MIP solver found an improving solution
+	CallbackMIPLogging                               // The MIP solver logged its progress
+	CallbackMIPInterrupt                             // The MIP solver offers to stop
+)
+
+// callbackTypeToHighs maps a CallbackType to a HiGHS callback type.
+var callbackTypeToHighs = []int{
+	cbLogging,
+	cbSimplexInterrupt,
+	cbIpmInterrupt,
+	cbMipSolution,
+	cbMipImprovingSolution,
+	cbMipLogging,
+	cbMipInterrupt,
+}
+
+// A CallbackData describes an event reported by HiGHS.  Fields that do not
+// pertain to the event are left zero.
+type CallbackData struct {
+	Type              CallbackType  // Type of event
+	Message           string        // Message text (CallbackLogging only)
+	LogType           LogType       // Message severity (CallbackLogging only)
+	RunTime           time.Duration // Time elapsed since the solve began
+	SimplexIterations int           // Number of simplex iterations performed so far
+	IPMIterations     int           // Number of interior-point iterations performed so far
+	Objective         float64       // Objective value of the current solution
+	MIPNodes          int64         // Number of branch-and-bound nodes explored so far
+	MIPPrimalBound    float64       // Best known objective value
+	MIPDualBound      float64       // Best proven bound on the objective value
+	MIPGap            float64       // Relative gap between MIPPrimalBound and MIPDualBound
+	MIPSolution       []float64     // Column values of the solution (CallbackMIPSolution and CallbackMIPImprovingSolution only)
+}
+
+// A CallbackFunc receives an event reported by HiGHS.  Returning true asks
+// HiGHS to stop solving at its next opportunity, in which case the solve
+// ends with model status Interrupt.  Only the interrupt event types are
+// guaranteed to offer such an opportunity promptly.
+type CallbackFunc func(d *CallbackData) (interrupt bool)
+
+// SetCallback arranges for fn to be invoked with each event of the given
+// types that HiGHS reports during subsequent solves, replacing any function
+// previously registered with SetCallback.  A nil fn stops the invocations.
+// This lets an application monitor a long solve and abort it
+// programmatically.  If no types are given, fn receives events of every
+// type.  Logging events are delivered only when the output_flag option is
+// true.
+//
+// fn is invoked from within Solve and must not call methods on the same
+// RawModel or its RawSolutions.  SetCallback can be combined with
+// SetLogFunc, SolveContext, Incumbents, and the package's other uses of
+// HiGHS callbacks.
+func (m *RawModel) SetCallback(fn CallbackFunc, types ...CallbackType) error {
+	_, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+
+	// Remove any previous callback function.
+	for len(m.cbIDs) > 0 {
+		err = m.h.removeCallback(m.cbIDs[0])
+		if err != nil {
+			return err
+		}
+		m.cbIDs = m.cbIDs[1:]
+	}
+	if fn == nil {
+		return nil
+	}
+
+	// Register the new callback function for each type of event.
+	if len(types) == 0 {
+		for ct := range callbackTypeToHighs {
+			types = append(types, CallbackType(ct))
+		}
+	}
+	h := m.h
+	for _, ct := range types {
+		if ct < 0 || int(ct) >= len(callbackTypeToHighs) {
+			return fmt.Errorf("unrecognized callback type %d", int(ct))
+		}
+	}
+	for _, ct := range types {
+		ct := ct
+		id, err := h.addCallback(callbackTypeToHighs[ct], func(msg string, out *C.HighsCallbackDataOut, in *C.HighsCallbackDataIn) {
+			d := &CallbackData{
+				Type:              ct,
+				RunTime:           time.Duration(float64(out.running_time) * float64(time.Second)),
+				SimplexIterations: int(out.simplex_iteration_count),
+				IPMIterations:     int(out.ipm_iteration_count),
+				Objective:         float64(out.objective_function_value),
+				MIPNodes:          int64(out.mip_node_count),
+				MIPPrimalBound:    float64(out.mip_primal_bound),
+				MIPDualBound:      float64(out.mip_dual_bound),
+				MIPGap:            float64(out.mip_gap),
+			}
+			switch ct {
+			case CallbackLogging:
+				d.Message = msg
+				d.LogType = LogType(out.log_type)
+			case CallbackMIPSolution, CallbackMIPImprovingSolution:
+				if out.mip_solution != nil {
+					nc := int(C.Highs_getNumCol(h.obj))
+					cSoln := unsafe.Slice((*C.double)(unsafe.Pointer(out.mip_solution)), nc)
+					d.MIPSolution = convertSlice[float64, C.double](cSoln)
+				}
+			}
+			if fn(d) && in != nil {
+				in.user_interrupt = 1
+			}
+		})
+		if err != nil {
+			return err
+		}
+		m.cbIDs = append(m.cbIDs, id)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+// This file provides utility functions that depend on cgo.
+
+package highs
+
+// #include "highs-externs.h"
+import "C"
+
+// These are the kHighsStatus values a CallStatus can represent, expressed as
+// Go values.
+var (
+	statusError   = int(C.kHighsStatusError)
+	statusWarning = int(C.kHighsStatusWarning)
+)
+
+// newCallStatus constructs a CallStatus or returns nil if the status
+// is kHighsStatusOk.  It also logs the call if a CallLogFunc is installed,
+// in which case args describe the shapes of the call's arguments.
+func newCallStatus(st C.HighsInt, hName, gName string, args ...TraceAttr) error {
+	logCall(int(st), hName, gName, args)
+	if st == C.kHighsStatusOk {
+		return nil
+	}
+	return CallStatus{
+		Status: int(st),
+		CName:  hName,
+		GoName: gName,
+	}
+}
+
+// nonzerosToCSR converts a list of Nonzero elements to a compressed sparse row
+// representation in the form of a set of C vectors accepted by the HiGHS APIs.
+func nonzerosToCSR(nz []Nonzero, tri bool) (start, index []C.HighsInt, value []C.double, err error) {
+	return csrFromNonzeros[C.HighsInt, C.double](nz, tri)
+}
@@ -3,15 +3,15 @@
 package highs
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"sort"
 
 	"golang.org/x/exp/constraints"
 )
 
-// #include "highs-externs.h"
-import "C"
-
 // A CallStatus wraps a kHighsStatus returned by a call to HiGHS.  A CallStatus
 // may be an error or just a warning.
 type CallStatus struct {
@@ -23,9 +23,9 @@ type CallStatus struct {
 // Error returns a CallStatus as a string.
 func (e CallStatus) Error() string {
 	switch e.Status {
-	case int(C.kHighsStatusError):
+	case statusError:
 		return fmt.Sprintf("%s failed with an error", e.GoName)
-	case int(C.kHighsStatusWarning):
+	case statusWarning:
 		return fmt.Sprintf("%s completed with a warning", e.GoName)
 	default:
 		return fmt.Sprintf("%s exited with an unknown status", e.GoName)
@@ -34,20 +34,33 @@ func (e CallStatus) Error() string {
 
 // IsWarning returns true if the CallStatus is merely a warning.
 func (e CallStatus) IsWarning() bool {
-	return e.Status == int(C.kHighsStatusWarning)
+	return e.Status == statusWarning
 }
 
-// newCallStatus constructs a CallStatus or returns nil if the status
-// is kHighsStatusOk.
-func newCallStatus(st C.HighsInt, hName, gName string) error {
-	if st == C.kHighsStatusOk {
-		return nil
+// isWarning returns true if an error is a CallStatus that represents only a
+// warning.
+func isWarning(err error) bool {
+	var cs CallStatus
+	return errors.As(err, &cs) && cs.IsWarning()
+}
+
+// renameCallStatus hides the fact that a highs package function was invoked
+// internally by replacing the GoName field of a CallStatus error, including
+// one embedded in a StatusError.  Other errors, including nil, are returned
+// unmodified.
+func renameCallStatus(err error, goName string) error {
+	var se *StatusError
+	if errors.As(err, &se) && se.CName != "" {
+		sc := *se
+		sc.GoName = goName
+		return &sc
 	}
-	return CallStatus{
-		Status: int(st),
-		CName:  hName,
-		GoName: gName,
+	var cs CallStatus
+	if errors.As(err, &cs) {
+		cs.GoName = goName
+		return cs
 	}
+	return err
 }
 
 // A numeric is any integer or any floating-point type.
@@ -128,28 +141,28 @@ func filterNonzeros(nz []Nonzero, tri bool) ([]Nonzero, error) {
 	return noDups, nil
 }
 
-// nonzerosToCSR converts a list of Nonzero elements to a compressed sparse row
-// representation in the form of a set of C vectors accepted by the HiGHS APIs.
-func nonzerosToCSR(nz []Nonzero, tri bool) (start, index []C.HighsInt, value []C.double, err error) {
+// csrFromNonzeros converts a list of Nonzero elements to a compressed sparse
+// row representation with arbitrary index and value types.  Rows containing no
+// nonzeros still receive a start entry.
+func csrFromNonzeros[I, F numeric](nz []Nonzero, tri bool) (start, index []I, value []F, err error) {
 	// Allocate memory for all of our return vectors.
 	var nonzeros []Nonzero
 	nonzeros, err = filterNonzeros(nz, tri)
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	start = make([]C.HighsInt, 0, len(nonzeros))
-	index = make([]C.HighsInt, 0, len(nonzeros))
-	value = make([]C.double, 0, len(nonzeros))
+	start = make([]I, 0, len(nonzeros))
+	index = make([]I, 0, len(nonzeros))
+	value = make([]F, 0, len(nonzeros))
 
-	// Construct slices of C types.
+	// Construct slices of the requested types.
 	prevRow := -1
 	for _, nz := range nonzeros {
-		if nz.Row > prevRow {
-			start = append(start, C.HighsInt(len(value)))
-			prevRow = nz.Row
+		for ; prevRow < nz.Row; prevRow++ {
+			start = append(start, I(len(value)))
 		}
-		index = append(index, C.HighsInt(nz.Col))
-		value = append(value, C.double(nz.Val))
+		index = append(index, I(nz.Col))
+		value = append(value, F(nz.Val))
 	}
 	return start, index, value, nil
 }
@@ -159,16 +172,11 @@ func nonzerosToCSR(nz []Nonzero, tri bool) (start, index []C.HighsInt, value []C
 // start, index, and value slices used by RawModel's AddCompSparseRows and
 // AddCompSparseHessian methods.  Pass true for the second argument to check
 // that the matrix is upper triangular (required for Hessian matrices) or false
-// to ignore that check.
+// to ignore that check.  Following HiGHS's convention, start contains one
+// entry per row, up to and including the last row that contains a nonzero,
+// and no trailing sentinel.
 func NonzerosToCSR(nz []Nonzero, tri bool) (start, index []int, value []float64, err error) {
-	cStart, cIndex, cValue, err := nonzerosToCSR(nz, tri)
-	if err != nil {
-		return nil, nil, nil, err
-	}
-	start = convertSlice[int, C.HighsInt](cStart)
-	index = convertSlice[int, C.HighsInt](cIndex)
-	value = convertSlice[float64, C.double](cValue)
-	return start, index, value, nil
+	return csrFromNonzeros[int, float64](nz, tri)
 }
 
 // expandToLen takes a length, a slice, and a value.  If the slice has the
@@ -190,6 +198,15 @@ func expandToLen[T any](n int, xs []T, v T) ([]T, bool) {
 	}
 }
 
+// padStarts extends a slice of row or column starts to a given length by
+// appending empty rows or columns, each of which starts at nnz.
+func padStarts[T numeric](start []T, n int, nnz T) []T {
+	for len(start) < n {
+		start = append(start, nnz)
+	}
+	return start
+}
+
 // sliceToPointer returns a pointer to the first element of a slice or nil if
 // the slice is empty.
 func sliceToPointer[T any](xs []T) *T {
@@ -198,3 +215,120 @@ func sliceToPointer[T any](xs []T) *T {
 	}
 	return &xs[0]
 }
+
+// completeStart expands a partial assignment of column values, given as a map
+// from column index to value, to a full vector of column values.  Each
+// unassigned column receives the value closest to zero that lies within its
+// bounds.  completeStart returns an error if any index is out of range.
+func completeStart(lb, ub []float64, values map[int]float64) ([]float64, error) {
+	start := make([]float64, len(lb))
+	for c := range start {
+		switch {
+		case lb[c] > 0.0:
+			start[c] = lb[c]
+		case ub[c] < 0.0:
+			start[c] = ub[c]
+		}
+	}
+	for c, v := range values {
+		if c < 0 || c >= len(start) {
+			return nil, fmt.Errorf("column %d is out of range [0, %d)", c, len(start))
+		}
+		start[c] = v
+	}
+	return start, nil
+}
+
+// writeViaPipe invokes a function that writes to a named file, passing it a
+// name for the write end of a pipe, and copies everything written to an
+// io.Writer.  No file is created, so writeViaPipe works even when the file
+// system is read-only.  The first return value is false if the operating
+// system provides no name for a pipe, in which case the caller should fall
+// back to writeViaTempFile.
+func writeViaPipe(w io.Writer, write func(fn string) error) (bool, error) {
+	// Create a pipe, and name its write end.
+	if _, err := os.Stat("/dev/fd"); err != nil {
+		return false, nil
+	}
+	pr, pw, err := os.Pipe()
+	if err != nil {
+		return false, nil
+	}
+	fn := fmt.Sprintf("/dev/fd/%d", pw.Fd())
+
+	// Copy the pipe's contents to the io.Writer in the background.  Drain
+	// the pipe even if the io.Writer fails so the writer never blocks.
+	copyErr := make(chan error, 1)
+	go func() {
+		_, err := io.Copy(w, pr)
+		if err != nil {
+			_, _ = io.Copy(io.Discard, pr)
+		}
+		pr.Close()
+		copyErr <- err
+	}()
+
+	// Write to the pipe, then wait for the copy to finish.
+	err = write(fn)
+	pw.Close()
+	cErr := <-copyErr
+	if err != nil {
+		return true, err
+	}
+	return true, cErr
+}
+
+// writeViaTempFile invokes a function that writes to a named file, passing it
+// the name of a temporary file with a given extension, and copies the file's
+// contents to an io.Writer.
+func writeViaTempFile(w io.Writer, ext string, write func(fn string) error) error {
+	// Create a throwaway file to use as a staging area.
+	tFile, err := os.CreateTemp("", "highs-*"+ext)
+	if err != nil {
+		return err
+	}
+	fName := tFile.Name()
+	defer os.Remove(fName)
+	err = tFile.Close()
+	if err != nil {
+		return err
+	}
+
+	// Write to the throwaway file.
+	err = write(fName)
+	if err != nil {
+		return err
+	}
+
+	// Copy the contents of the throwaway file to the io.Writer.
+	tFile, err = os.Open(fName)
+	if err != nil {
+		return err
+	}
+	defer tFile.Close()
+	_, err = io.Copy(w, tFile)
+	return err
+}
+
+// readViaTempFile copies the contents of an io.Reader to a temporary file with
+// a given extension and invokes a function that reads from a named file,
+// passing it the temporary file's name.
+func readViaTempFile(r io.Reader, ext string, read func(fn string) error) error {
+	// Copy from the reader to a throwaway file.
+	tFile, err := os.CreateTemp("", "highs-*"+ext)
+	if err != nil {
+		return err
+	}
+	fName := tFile.Name()
+	defer os.Remove(fName)
+	_, err = io.Copy(tFile, r)
+	if cErr := tFile.Close(); err == nil {
+		err = cErr
+	}
+	if err != nil {
+		return err
+	}
+
+	// Read from the throwaway file.
+	return read(fName)
+}
@@ -5,6 +5,7 @@ package highs
 import (
 	"errors"
 	"math"
+	"os/exec"
 	"testing"
 )
 
@@ -46,3 +47,13 @@ func checkErr(t *testing.T, e error) {
 	// Fail on everything else.
 	t.Fatal(e)
 }
+
+// skipIfNoSolver skips a test if an error from Model.Solve indicates that no
+// HiGHS executable was found.  This happens only when cgo is unavailable, in
+// which case Model.Solve runs an external executable.
+func skipIfNoSolver(t *testing.T, err error) {
+	t.Helper()
+	if errors.Is(err, exec.ErrNotFound) {
+		t.Skip("no highs executable was found")
+	}
+}
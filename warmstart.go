@@ -0,0 +1,131 @@
+// This file provides support for starting continuous solves from a given
+// point.
+
+package highs
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// #include "highs-externs.h"
+import "C"
+
+// SetStartingPoint provides HiGHS with an initial point from which to begin
+// the next solve of a continuous (LP or QP) model.  This can accelerate a
+// sequence of solves of related models, such as QPs that differ only in
+// their costs.  Each non-nil slice in sp must have one value per column or
+// row, as appropriate, and sp.ColumnPrimal must be non-nil.
+func (m *RawModel) SetStartingPoint(sp StartingPoint) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+	return setStartingPoint(obj, sp, "SetStartingPoint")
+}
+
+// setStartingPoint implements SetStartingPoint.  The caller must hold the
+// model's lock.
+func setStartingPoint(obj unsafe.Pointer, sp StartingPoint, goName string) error {
+	// Check the dimensions of the starting point.
+	nc := int(C.Highs_getNumCol(obj))
+	nr := int(C.Highs_getNumRow(obj))
+	if sp.ColumnPrimal == nil {
+		return fmt.Errorf("a starting point requires primal column values")
+	}
+	for _, v := range []struct {
+		name string
+		xs   []float64
+		n    int
+	}{
+		{"primal column", sp.ColumnPrimal, nc},
+		{"primal row", sp.RowPrimal, nr},
+		{"dual column", sp.ColumnDual, nc},
+		{"dual row", sp.RowDual, nr},
+	} {
+		if v.xs != nil && len(v.xs) != v.n {
+			return fmt.Errorf("expected %d %s values but saw %d", v.n, v.name, len(v.xs))
+		}
+	}
+
+	// Pass the starting point to HiGHS.
+	colValue := convertSlice[C.double, float64](sp.ColumnPrimal)
+	var rowValue, colDual, rowDual []C.double
+	if sp.RowPrimal != nil {
+		rowValue = convertSlice[C.double, float64](sp.RowPrimal)
+	}
+	if sp.ColumnDual != nil {
+		colDual = convertSlice[C.double, float64](sp.ColumnDual)
+	}
+	if sp.RowDual != nil {
+		rowDual = convertSlice[C.double, float64](sp.RowDual)
+	}
+	status := C.Highs_setSolution(obj, sliceToPointer(colValue),
+		sliceToPointer(rowValue), sliceToPointer(colDual), sliceToPointer(rowDual))
+	return newCallStatus(status, "Highs_setSolution", goName,
+		TraceAttr{"col_value", len(colValue)}, TraceAttr{"row_value", len(rowValue)},
+		TraceAttr{"col_dual", len(colDual)}, TraceAttr{"row_dual", len(rowDual)})
+}
+
+// SetBasis provides HiGHS with a basis from which to begin the next simplex
+// solve of an LP.  This makes it possible to hot-start a model from the
+// ColumnBasis and RowBasis of a previous solution, including one obtained
+// from a different RawModel.  colStatus must have one value per column and
+// rowStatus one value per row, and neither may contain UnknownBasisStatus.
+func (m *RawModel) SetBasis(colStatus, rowStatus []BasisStatus) error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+
+	// Check the dimensions of the basis.
+	nc := int(C.Highs_getNumCol(obj))
+	nr := int(C.Highs_getNumRow(obj))
+	if len(colStatus) != nc {
+		return fmt.Errorf("expected %d column basis statuses but saw %d", nc, len(colStatus))
+	}
+	if len(rowStatus) != nr {
+		return fmt.Errorf("expected %d row basis statuses but saw %d", nr, len(rowStatus))
+	}
+
+	// Convert the basis from Go to C.
+	toHighs := func(what string, bs []BasisStatus) ([]C.HighsInt, error) {
+		hbs := make([]C.HighsInt, len(bs))
+		for i, s := range bs {
+			if s <= UnknownBasisStatus || int(s) >= len(basisStatusToHighs) {
+				return nil, fmt.Errorf("%s %d has invalid basis status %s", what, i, s)
+			}
+			hbs[i] = basisStatusToHighs[s]
+		}
+		return hbs, nil
+	}
+	colBasis, err := toHighs("column", colStatus)
+	if err != nil {
+		return err
+	}
+	rowBasis, err := toHighs("row", rowStatus)
+	if err != nil {
+		return err
+	}
+
+	// Pass the basis to HiGHS.
+	status := C.Highs_setBasis(obj, sliceToPointer(colBasis), sliceToPointer(rowBasis))
+	return newCallStatus(status, "Highs_setBasis", "SetBasis",
+		TraceAttr{"col_status", len(colBasis)}, TraceAttr{"row_status", len(rowBasis)})
+}
+
+// SetLogicalBasis provides HiGHS with the logical basis, in which every row's
+// slack variable is basic and every column is nonbasic, as the basis from
+// which to begin the next simplex solve.  This discards any basis retained
+// from a previous solve.
+func (m *RawModel) SetLogicalBasis() error {
+	obj, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+	status := C.Highs_setLogicalBasis(obj)
+	return newCallStatus(status, "Highs_setLogicalBasis", "SetLogicalBasis")
+}